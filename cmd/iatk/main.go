@@ -4,23 +4,69 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"iatk/internal/pkg/aws/cassette"
 	"iatk/internal/pkg/jsonrpc"
-	publicrpc "iatk/internal/pkg/public-rpc"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/rpcserver"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+)
 
-	"github.com/aws/smithy-go"
+var (
+	serve    = flag.Bool("serve", false, "keep running and answer newline-delimited requests from stdin until it is closed")
+	socket   = flag.String("socket", "", "with -serve, accept connections on this unix socket instead of reading stdin")
+	httpAddr = flag.String("http", "", "keep running and answer requests POSTed over HTTP on this address, e.g. 127.0.0.1:8080, until interrupted. "+
+		"Clients must present the bearer token in $"+rpcserver.TokenEnv+" if it is set, which is required unless the address is a loopback one")
+	newCassette = flag.Bool("new-cassette", false, "create an empty cassette at $"+cassette.PathEnv+", replacing any previous recording, and exit. "+
+		"Calls recorded afterwards with $"+cassette.ModeEnv+" set to record are appended to it")
 )
 
 func main() {
+	flag.Parse()
+
+	if err := logging.Init(os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+	if *newCassette {
+		path := os.Getenv(cassette.PathEnv)
+		if path == "" {
+			log.Fatalf("-new-cassette requires $%s", cassette.PathEnv)
+		}
+		if err := cassette.New(path); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := cassette.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	if *serve || *httpAddr != "" {
+		if err := runServer(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var msg json.RawMessage
+	errDecode := json.NewDecoder(os.Stdin).Decode(&msg)
+	if errDecode == nil && rpcserver.IsBatch(msg) {
+		fmt.Print(string(rpcserver.Handle(context.Background(), msg, notify)))
+		os.Exit(0)
+	}
 
 	var jsonrpcData jsonrpc.Request
-	decoder := json.NewDecoder(os.Stdin)
-	decoder.DisallowUnknownFields()
-	errDecode := decoder.Decode(&jsonrpcData)
+	if errDecode == nil {
+		jsonrpcData, errDecode = rpcserver.DecodeRequest(bytes.NewReader(msg))
+	}
 
 	var resp jsonrpc.Response
 	if errDecode != nil {
@@ -38,71 +84,32 @@ func main() {
 		log.Fatal(errDecode)
 	}
 
-	rpcStruct, err := publicrpc.GetRPCStruct(jsonrpcData.Method, jsonrpcData.Params)
-	if err != nil {
-		var errNoMethodFound *publicrpc.ErrNoMethodFound
-		if errors.As(err, &errNoMethodFound) {
-			resp = jsonrpc.NoMethodFoundError(jsonrpcData.ID)
-		}
-		var errParameter *publicrpc.ErrParameter
-		if errors.As(err, &errParameter) {
-			resp = jsonrpc.InvalidParamsError(jsonrpcData.ID)
-		}
+	ctx := rpcserver.WithProgress(context.Background(), jsonrpcData, notify)
+	resp = rpcserver.Dispatch(ctx, jsonrpcData)
 
-		exitWithResponse(resp, jsonrpcData)
-	}
+	// Print "Response"
+	exitWithResponse(resp, jsonrpcData)
+}
 
-	respString, errRPC := rpcStruct.RPCMethod(jsonrpcData.Metadata)
-
-	if errRPC != nil {
-		var oe *smithy.OperationError
-		if errors.As(errRPC, &oe) {
-			resp = jsonrpc.Response{
-				JSONRPC: "2.0",
-				ID:      jsonrpcData.ID,
-				Error: &jsonrpc.ErrIatk{
-					Code:    10,
-					Message: fmt.Sprintf("failed to call service: %s, operation: %s, error: %v", oe.Service(), oe.Operation(), oe.Unwrap()),
-				},
-			}
-			exitWithResponse(resp, jsonrpcData)
-		}
+func runServer() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-		var apiErr smithy.APIError
-		if errors.As(errRPC, &apiErr) {
-			resp = jsonrpc.Response{
-				JSONRPC: "2.0",
-				ID:      jsonrpcData.ID,
-				Error: &jsonrpc.ErrIatk{
-					Code:    10,
-					Message: apiErr.ErrorMessage(),
-				},
-			}
-
-			exitWithResponse(resp, jsonrpcData)
+	if *httpAddr != "" {
+		if *socket != "" {
+			return errors.New("-http and -socket cannot be used together")
 		}
-
-		resp = jsonrpc.Response{
-			JSONRPC: "2.0",
-			ID:      jsonrpcData.ID,
-			Error: &jsonrpc.ErrIatk{
-				Code:    10,
-				Message: errRPC.Error(),
-			},
-		}
-
-		exitWithResponse(resp, jsonrpcData)
-
+		return rpcserver.ListenAndServeHTTP(ctx, *httpAddr, os.Getenv(rpcserver.TokenEnv))
 	}
-
-	resp = jsonrpc.Response{
-		JSONRPC: "2.0",
-		ID:      jsonrpcData.ID,
-		Result:  respString,
+	if *socket != "" {
+		return rpcserver.ListenAndServe(ctx, *socket)
 	}
+	return rpcserver.Serve(ctx, os.Stdin, os.Stdout)
+}
 
-	// Print "Response"
-	exitWithResponse(resp, jsonrpcData)
+// notify prints a notification ahead of the response, on a line of its own.
+func notify(buffer []byte) {
+	fmt.Print(string(buffer))
 }
 
 func exitWithResponse(resp jsonrpc.Response, jsonrpcData jsonrpc.Request) {
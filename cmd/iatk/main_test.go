@@ -0,0 +1,208 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"iatk/internal/pkg/aws/cassette"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runMainEnv names the environment variable making the test binary run main instead of the tests, for
+// tests to run iatk in processes of its own.
+const runMainEnv = "IATK_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runIatk runs iatk with args in a process of its own, as a client calling it once per request does,
+// and returns what it printed.
+func runIatk(t *testing.T, env []string, stdin string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), append(env, runMainEnv+"=1")...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	require.NoError(t, err, stderr.String())
+	return string(out)
+}
+
+// call runs the request of method with params in a process of its own and returns its result.
+func call(t *testing.T, env []string, method string, params map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	req, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": "1", "method": method, "params": params})
+	require.NoError(t, err)
+	var resp struct {
+		Result map[string]interface{}
+		Error  interface{}
+	}
+	out := runIatk(t, env, string(req))
+	require.NoError(t, json.Unmarshal([]byte(out), &resp), out)
+	require.Nil(t, resp.Error, out)
+	return resp.Result
+}
+
+const (
+	testAccountID   = "123456789012"
+	testEventBusARN = "arn:aws:events:us-east-1:" + testAccountID + ":event-bus/default"
+	testEvent       = `{"source":"com.test","detail-type":"Order","detail":{"id":1}}`
+)
+
+// fakeAWS answers the EventBridge and SQS calls of a listener, with an event waiting on its queue.
+func fakeAWS(t *testing.T, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if target := r.Header.Get("X-Amz-Target"); target != "" {
+			var in map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			name, _ := in["Name"].(string)
+			var out interface{}
+			switch strings.TrimPrefix(target, "AWSEvents.") {
+			case "DescribeEventBus":
+				out = map[string]string{"Name": "default", "Arn": testEventBusARN}
+			case "PutRule":
+				out = map[string]string{"RuleArn": "arn:aws:events:us-east-1:" + testAccountID + ":rule/" + name}
+			case "DescribeRule":
+				out = map[string]string{
+					"Name": name, "EventBusName": "default", "EventPattern": `{"source":["com.test"]}`,
+					"Arn": "arn:aws:events:us-east-1:" + testAccountID + ":rule/" + name,
+				}
+			case "PutTargets":
+				out = map[string]int{"FailedEntryCount": 0}
+			case "ListTargetsByRule":
+				out = map[string][]map[string]string{"Targets": {{"Id": in["Rule"].(string)}}}
+			case "RemoveTargets", "DeleteRule":
+				out = map[string]interface{}{}
+			default:
+				t.Errorf("unexpected call %s", target)
+			}
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			require.NoError(t, json.NewEncoder(w).Encode(out))
+			return
+		}
+
+		require.NoError(t, r.ParseForm())
+		action := r.Form.Get("Action")
+		queueName := r.Form.Get("QueueName")
+		if u := r.Form.Get("QueueUrl"); u != "" {
+			queueName = path.Base(u)
+		}
+		queueURL := "http://" + r.Host + "/" + testAccountID + "/" + queueName
+		var result string
+		switch action {
+		case "CreateQueue", "GetQueueUrl":
+			result = "<QueueUrl>" + queueURL + "</QueueUrl>"
+		case "GetQueueAttributes":
+			result = "<Attribute><Name>QueueArn</Name><Value>arn:aws:sqs:us-east-1:" + testAccountID + ":" + queueName + "</Value></Attribute>"
+		case "ListQueueTags":
+			result = "<Tag><Key>iatk:TestHarness:Target</Key><Value>" + testEventBusARN + "</Value></Tag>"
+		case "ReceiveMessage":
+			var body bytes.Buffer
+			require.NoError(t, xml.EscapeText(&body, []byte(testEvent)))
+			sum := md5.Sum([]byte(testEvent))
+			result = "<Message><MessageId>1</MessageId><ReceiptHandle>handle</ReceiptHandle><MD5OfBody>" + hex.EncodeToString(sum[:]) +
+				"</MD5OfBody><Body>" + body.String() + "</Body></Message>"
+		case "DeleteMessageBatch":
+			result = "<DeleteMessageBatchResultEntry><Id>0</Id></DeleteMessageBatchResultEntry>"
+		case "DeleteQueue":
+		default:
+			t.Errorf("unexpected call %s", action)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintf(w, "<%[1]sResponse><%[1]sResult>%[2]s</%[1]sResult><ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></%[1]sResponse>", action, result)
+	}))
+}
+
+// roundTrip adds a listener, polls its event and removes it, each in a process of its own.
+func roundTrip(t *testing.T, env []string, endpointURL string) {
+	t.Helper()
+	aws := map[string]interface{}{"Region": "us-east-1", "EndpointURL": endpointURL}
+	with := func(params map[string]interface{}) map[string]interface{} {
+		for k, v := range aws {
+			params[k] = v
+		}
+		return params
+	}
+
+	added := call(t, env, "test_harness.eventbridge.add_listener", with(map[string]interface{}{
+		"EventBusName": "default", "EventPattern": `{"source":["com.test"]}`,
+	}))
+	id := added["output"].(map[string]interface{})["Id"].(string)
+
+	polled := call(t, env, "test_harness.eventbridge.poll_events", with(map[string]interface{}{
+		"ListenerId": id, "WaitTimeSeconds": 1,
+	}))
+	assert.Equal(t, []interface{}{testEvent}, polled["output"])
+
+	removed := call(t, env, "test_harness.eventbridge.remove_listeners", with(map[string]interface{}{
+		"Ids": []string{id},
+	}))
+	assert.Equal(t, []interface{}{map[string]interface{}{"Id": id, "Status": "Destroyed"}}, pick(removed["output"], "Id", "Status"))
+}
+
+// pick keeps the given fields of the objects of the array v.
+func pick(v interface{}, fields ...string) []interface{} {
+	var out []interface{}
+	for _, x := range v.([]interface{}) {
+		m := map[string]interface{}{}
+		for _, f := range fields {
+			m[f] = x.(map[string]interface{})[f]
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestCassetteRoundTrip(t *testing.T) {
+	var calls int32
+	server := fakeAWS(t, &calls)
+	endpointURL := server.URL
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+	require.NoError(t, os.WriteFile(cassettePath, []byte("left over from a previous recording\n"), 0o600))
+
+	record := []string{
+		cassette.PathEnv + "=" + cassettePath, cassette.ModeEnv + "=record",
+		"AWS_ACCESS_KEY_ID=AKID", "AWS_SECRET_ACCESS_KEY=SECRET", "AWS_CONFIG_FILE=" + os.DevNull, "AWS_SHARED_CREDENTIALS_FILE=" + os.DevNull,
+	}
+	runIatk(t, record, "", "-new-cassette")
+	roundTrip(t, record, endpointURL)
+	recorded := atomic.LoadInt32(&calls)
+	assert.NotZero(t, recorded)
+
+	lines, err := os.ReadFile(cassettePath)
+	require.NoError(t, err)
+	// the processes appended their calls to the new cassette
+	assert.Len(t, strings.Split(strings.TrimSpace(string(lines)), "\n"), int(recorded))
+
+	// replayed without network by processes generating listener IDs and creation times of their own
+	server.Close()
+	time.Sleep(time.Second)
+	replay := []string{cassette.PathEnv + "=" + cassettePath, cassette.ModeEnv + "=replay"}
+	roundTrip(t, replay, endpointURL)
+	assert.Equal(t, recorded, atomic.LoadInt32(&calls))
+}
@@ -40,6 +40,14 @@ func TestCliErrCases(t *testing.T) {
 			input:  `{"jsonrpc": "2.0","id": "42","method": "invalid-hello-world","params": {"name": "Jacob"}}`,
 			expect: `{"jsonrpc":"2.0","id":"42","error":{"code":-32601,"message":"Method not found"}}`,
 		},
+		"empty batch": {
+			input:  `[]`,
+			expect: `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`,
+		},
+		"batch": {
+			input:  `[{"jsonrpc": "2.0","id": "42","method": "invalid-hello-world","params": {}}, 1]`,
+			expect: `[{"jsonrpc":"2.0","id":"42","error":{"code":-32601,"message":"Method not found"}},{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}]`,
+		},
 		"valid function but invalid parameters": {
 			input:  `{"jsonrpc": "2.0","id": "42","method": "get_physical_id","params": {"LogicalResourceId": "HelloWorldFunction", "StackName": "townhalldemo1","DoesntExist": []}}`,
 			expect: `{"jsonrpc":"2.0","id":"42","error":{"code":-32602,"message":"Invalid params"}}`,
@@ -102,7 +110,7 @@ func (s *PhysicalIDWithCredsSuite) TestCliGetPhysicalIdCredCases() {
 	}{
 		"logical id not found": {
 			input:  `{"jsonrpc": "2.0","id": "42","method": "get_physical_id","params": {"LogicalResourceId": "HelloWorldFunction", "StackName": "ZionDoesntExistStack"}}`,
-			expect: `{"jsonrpc":"2.0","id":"42","error":{"code":10,"message":"failed to call service: CloudFormation, operation: DescribeStackResource, error: https response error StatusCode: 400, RequestID: [a-z0-9_-]{36}, api error ValidationError: Stack 'ZionDoesntExistStack' does not exist"}}`,
+			expect: `{"jsonrpc":"2.0","id":"42","error":{"code":12,"message":"failed to call service: CloudFormation, operation: DescribeStackResource, error: https response error StatusCode: 400, RequestID: [a-z0-9_-]{36}, api error ValidationError: Stack 'ZionDoesntExistStack' does not exist"}}`,
 			region: "us-east-1",
 		},
 		"logical id found": {
@@ -62,6 +62,7 @@ func (s *GenerateBareboneEventSuite) SetupSuite() {
 		[]types.Capability{})
 	s.Require().NoError(err, "failed to create stack")
 	output, err := iatkcfn.GetStackOuput(
+		context.TODO(),
 		s.stackName,
 		[]string{
 			"TestSchemaRegistryName",
@@ -320,7 +321,7 @@ func (s *GenerateBareboneEventSuite) TestInvalidParams() {
 					}
 				}`, test_method, s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `requires both "RegistryName" and "SchemaName"`,
 		},
 		{
@@ -336,7 +337,7 @@ func (s *GenerateBareboneEventSuite) TestInvalidParams() {
 					}
 				}`, test_method, s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `requires both "RegistryName" and "SchemaName"`,
 		},
 	}
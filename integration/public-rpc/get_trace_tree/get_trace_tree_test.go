@@ -75,6 +75,7 @@ func (s *GetTraceTreeSuite) SetupSuite() {
 		})
 	s.Require().NoError(err, "failed to create stack")
 	output, _ := iatkcfn.GetStackOuput(
+		context.TODO(),
 		s.stackName,
 		[]string{"ProducerFunctionName", "StateMachineArn", "ProducerFunctionNameLinkedTraces"},
 		s.cfnClient,
@@ -262,7 +263,7 @@ func (s *GetTraceTreeSuite) TestErrors() {
 					}
 				}`, test_method, s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `missing required param "TracingHeader"`,
 		},
 		{
@@ -279,7 +280,7 @@ func (s *GetTraceTreeSuite) TestErrors() {
 					}
 				}`, test_method, s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  "invalid tracing header provided",
 		},
 	}
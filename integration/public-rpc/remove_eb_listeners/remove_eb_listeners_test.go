@@ -85,10 +85,10 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByResourceGrou
 		numResourceGroups    int
 		createResourceGroups func(num int) []string
 		input                func(resourceGroupIDs []string) []byte
-		expectErr            bool
+		expectStatus         string
 	}{
 		"should sucessfully destroy": {
-			expectErr:         false,
+			expectStatus:      "Destroyed",
 			numResourceGroups: 5,
 			createResourceGroups: func(count int) []string {
 				resourceGroupIDs := []string{}
@@ -113,7 +113,7 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByResourceGrou
 			},
 		},
 		"should succeed with empty resource group ids in input": {
-			expectErr:         false,
+			expectStatus:      "Destroyed",
 			numResourceGroups: 0,
 			createResourceGroups: func(count int) []string {
 				return []string{}
@@ -133,7 +133,7 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByResourceGrou
 			},
 		},
 		"should succeed with duplicated resource group ids in input": {
-			expectErr:         false,
+			expectStatus:      "Destroyed",
 			numResourceGroups: 2,
 			createResourceGroups: func(count int) []string {
 				resourceGroupIDs := []string{}
@@ -160,8 +160,8 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByResourceGrou
 				return out
 			},
 		},
-		"should fail with resource group id containing no resources": {
-			expectErr:         true,
+		"should report invalid resource group id as failed": {
+			expectStatus:      "Failed",
 			numResourceGroups: 0,
 			createResourceGroups: func(count int) []string {
 				return []string{}
@@ -195,11 +195,9 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByResourceGrou
 			log.Printf("response: %v", out.String())
 			var actual jsonrpc.Response
 			json.Unmarshal([]byte(out.String()), &actual)
-			if !tt.expectErr {
-				output := actual.Result.(map[string]interface{})["output"].(string)
-				assert.Equal(s.T(), "success", output)
-			} else {
-				assert.NotNil(t, actual.Error)
+			s.Require().Nil(actual.Error)
+			for _, result := range actual.Result.(map[string]interface{})["output"].([]interface{}) {
+				assert.Equal(t, tt.expectStatus, result.(map[string]interface{})["Status"])
 			}
 			assertResourceGroupsAreDestoryed(s.T(), resourceGroupIDs, s.cfg)
 		})
@@ -211,10 +209,8 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByTagFilters()
 		createResourceGroups func() []string
 		tagFilters           []types.TagFilter
 		input                func(tagFilters []types.TagFilter) []byte
-		expectErr            bool
 	}{
 		"should succeed": {
-			expectErr: false,
 			tagFilters: []types.TagFilter{
 				{Key: aws.String("foo"), Values: []string{"bar"}},
 			},
@@ -257,11 +253,11 @@ func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersByTagFilters()
 			log.Printf("response: %v", out.String())
 			var actual jsonrpc.Response
 			json.Unmarshal([]byte(out.String()), &actual)
-			if !tt.expectErr {
-				output := actual.Result.(map[string]interface{})["output"].(string)
-				assert.Equal(s.T(), "success", output)
-			} else {
-				assert.NotNil(t, actual.Error)
+			s.Require().Nil(actual.Error)
+			output := actual.Result.(map[string]interface{})["output"].([]interface{})
+			assert.Len(t, output, len(resourceGroupIDs))
+			for _, result := range output {
+				assert.Equal(t, "Destroyed", result.(map[string]interface{})["Status"])
 			}
 			assertResourceGroupsAreDestoryed(s.T(), resourceGroupIDs, s.cfg)
 		})
@@ -291,6 +287,48 @@ func createTestingResources(t *testing.T, cfg aws.Config, eventBusName, eventBus
 	return output["Id"].(string)
 }
 
+func (s *EventBusDestroyTestingResourcesSuite) TestRemoveListenersDryRun() {
+	resourceGroupIDs := []string{
+		createTestingResources(s.T(), s.cfg, s.eventBusName, s.eventBusRule, s.region, nil),
+		createTestingResources(s.T(), s.cfg, s.eventBusName, s.eventBusRule, s.region, nil),
+	}
+	invoke := func(dryRun bool) []interface{} {
+		rJson := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      "42",
+			"method":  test_method,
+			"params": map[string]interface{}{
+				"Ids":    resourceGroupIDs,
+				"DryRun": dryRun,
+				"Region": s.region,
+			},
+		}
+		input, _ := json.Marshal(rJson)
+		var out strings.Builder
+		var sErr strings.Builder
+		iatk.Invoke(s.T(), input, &out, &sErr, nil)
+		log.Printf("response: %v", out.String())
+		var actual jsonrpc.Response
+		json.Unmarshal([]byte(out.String()), &actual)
+		s.Require().Nil(actual.Error)
+		return actual.Result.(map[string]interface{})["output"].([]interface{})
+	}
+
+	output := invoke(true)
+	s.Require().Len(output, len(resourceGroupIDs))
+	for _, result := range output {
+		assert.Equal(s.T(), "WouldDestroy", result.(map[string]interface{})["Status"])
+		assert.NotEmpty(s.T(), result.(map[string]interface{})["ComponentArns"])
+	}
+	for _, id := range resourceGroupIDs {
+		_, err := listener.Get(context.TODO(), id, listener.NewOptions(s.cfg))
+		assert.NoError(s.T(), err)
+	}
+
+	invoke(false)
+	assertResourceGroupsAreDestoryed(s.T(), resourceGroupIDs, s.cfg)
+}
+
 func deleteEventBus(t *testing.T, client *eventbridge.Client, eventBusName string) {
 	_, err := client.DeleteEventBus(context.TODO(), &eventbridge.DeleteEventBusInput{
 		Name: aws.String(eventBusName),
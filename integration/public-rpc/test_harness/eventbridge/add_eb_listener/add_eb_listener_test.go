@@ -238,6 +238,61 @@ func (s *AddEbListenerSuite) TestAddEbListenerWithTarget() {
 	}
 }
 
+func (s *AddEbListenerSuite) TestAddEbListenerWithEventPattern() {
+	cases := []struct {
+		testname      string
+		params        map[string]any
+		expectPattern string
+	}{
+		{
+			testname: "add listener without rule",
+			params: map[string]any{
+				"EventBusName": s.eventBusName,
+				"EventPattern": `{"source":["my.app"]}`,
+				"Region":       s.region,
+			},
+			expectPattern: `{"source":["my.app"]}`,
+		},
+		{
+			testname: "add listener with pattern refinements",
+			params: map[string]any{
+				"EventBusName":            s.eventBusName,
+				"RuleName":                s.eventBusRule,
+				"EventPatternRefinements": []string{`{"detail":{"id":["42"]}}`},
+				"Region":                  s.region,
+			},
+			expectPattern: `{"detail":{"id":["42"]},"detail-type":["customerCreated"],"source":["aws.events"]}`,
+		},
+	}
+
+	for _, tt := range cases {
+		s.Run(tt.testname, func() {
+			req, _ := json.Marshal(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "42",
+				"method":  method,
+				"params":  tt.params,
+			})
+			res := s.invoke(req)
+			s.Require().Nilf(res.Error, "expect error to be nil, actual: %v", res.Error)
+			queueURL, queueARN, ruleName, ruleARN, expectTags := s.assertOutput(res, nil)
+			s.queueURLs = append(s.queueURLs, queueURL)
+			s.ruleNames = append(s.ruleNames, ruleName)
+
+			s.assertQueue(queueURL, queueARN, ruleARN, expectTags)
+			s.assertRule(ruleName, s.eventBusName, expectTags)
+			s.assertRuleTarget(ruleName, s.eventBusName, false)
+
+			r, err := s.ebClient.DescribeRule(context.TODO(), &eventbridge.DescribeRuleInput{
+				Name:         aws.String(ruleName),
+				EventBusName: aws.String(s.eventBusName),
+			})
+			s.Require().NoError(err)
+			s.JSONEq(tt.expectPattern, aws.ToString(r.EventPattern))
+		})
+	}
+}
+
 func (s *AddEbListenerSuite) TestErrors() {
 	cases := []struct {
 		testname      string
@@ -260,11 +315,11 @@ func (s *AddEbListenerSuite) TestErrors() {
 				out, _ := json.Marshal(r)
 				return out
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `missing required param "EventBusName"`,
 		},
 		{
-			testname: "missing rule name",
+			testname: "missing rule name and event pattern",
 			request: func() []byte {
 				r := map[string]any{
 					"jsonrpc": "2.0",
@@ -278,8 +333,8 @@ func (s *AddEbListenerSuite) TestErrors() {
 				out, _ := json.Marshal(r)
 				return out
 			},
-			expectErrCode: 10,
-			expectErrMsg:  `missing required param "RuleName"`,
+			expectErrCode: 14,
+			expectErrMsg:  `missing required param "RuleName" or "EventPattern"`,
 		},
 		{
 			testname: "custom tags contain reserved key",
@@ -300,7 +355,7 @@ func (s *AddEbListenerSuite) TestErrors() {
 				out, _ := json.Marshal(r)
 				return out
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `invalid tags: reserved tag key "iatk:TestHarness:Created" found in provided tags`,
 		},
 		{
@@ -340,7 +395,7 @@ func (s *AddEbListenerSuite) TestErrors() {
 				out, _ := json.Marshal(r)
 				return out
 			},
-			expectErrCode: 10,
+			expectErrCode: 12,
 			expectErrMsg:  `failed to locate test target: RuleName "DoesNotExistTarget" was provided but not found`,
 		},
 	}
@@ -311,7 +311,7 @@ func (s *PollEventsSuite) TestReceiveMatchingEvents() {
 			for i := 0; i < len(tt.events()); i++ {
 				// NOTE: make repeated calls since number of events is small (<1,000)
 				// see https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_ReceiveMessage.html
-				events := s.invokeAndAssertPollEventsRPC(listenerID, tt.pollWaitTimeSeconds, tt.maxNumMessages)
+				events := s.invokeAndAssertPollEventsRPC(listenerID, tt.pollWaitTimeSeconds, tt.maxNumMessages, "")
 				received = append(received, events...)
 			}
 			s.Len(received, tt.expectCount)
@@ -323,6 +323,35 @@ func (s *PollEventsSuite) TestReceiveMatchingEvents() {
 	}
 }
 
+func (s *PollEventsSuite) TestReceiveEventsSharedByPatterns() {
+	err := s.sendEvents([]ebEvent{
+		{Source: "com.test.0", DetailType: "foo", Detail: map[string]string{"id": "first", "abc": "def"}},
+		{Source: "com.test.0", DetailType: "foo", Detail: map[string]string{"id": "second", "abc": "def"}},
+	})
+	s.Require().NoError(err, "failed to send events")
+	s.T().Log("sleep for 3s")
+	time.Sleep(3 * time.Second)
+
+	listenerID := s.listenerIDs[0]
+	// the events not matching a pattern are left for the following ones
+	for _, id := range []string{"second", "first"} {
+		eventPattern := fmt.Sprintf(`{"detail":{"id":[%q]}}`, id)
+		received := []string{}
+		for i := 0; i < 5 && len(received) == 0; i++ {
+			received = append(received, s.invokeAndAssertPollEventsRPC(listenerID, aws.Int32(3), aws.Int32(10), eventPattern)...)
+		}
+		s.Require().Lenf(received, 1, "expect to receive event %v", id)
+
+		var payload map[string]interface{}
+		err := json.Unmarshal([]byte(received[0]), &payload)
+		s.Require().NoErrorf(err, "unexpected recevied payload: %v", received[0])
+		s.Equal(id, payload["detail"].(map[string]interface{})["id"])
+	}
+
+	// all events were deleted once received
+	s.Empty(s.invokeAndAssertPollEventsRPC(listenerID, aws.Int32(3), aws.Int32(10), ""))
+}
+
 func (s *PollEventsSuite) TestErrors() {
 	cases := []struct {
 		testname      string
@@ -343,7 +372,7 @@ func (s *PollEventsSuite) TestErrors() {
 					}
 				}`, poll_events_method, s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `missing required param "ListenerId"`,
 		},
 		{
@@ -361,7 +390,7 @@ func (s *PollEventsSuite) TestErrors() {
 					}
 				}`, poll_events_method, s.listenerIDs[0], s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `"WaitTimeSeconds" must be an integer between 0 and 20`,
 		},
 		{
@@ -379,7 +408,7 @@ func (s *PollEventsSuite) TestErrors() {
 					}
 				}`, poll_events_method, s.listenerIDs[0], s.region))
 			},
-			expectErrCode: 10,
+			expectErrCode: 14,
 			expectErrMsg:  `"MaxNumberOfMessages" must be an integer between 1 and 10`,
 		},
 		{
@@ -413,7 +442,7 @@ func (s *PollEventsSuite) TestErrors() {
 	}
 }
 
-func (s *PollEventsSuite) invokeAndAssertPollEventsRPC(listenerID string, waitTimeSeconds, maxNumberOfMessages *int32) []string {
+func (s *PollEventsSuite) invokeAndAssertPollEventsRPC(listenerID string, waitTimeSeconds, maxNumberOfMessages *int32, eventPattern string) []string {
 	params := map[string]any{
 		"ListenerId": listenerID,
 		"Region":     s.region,
@@ -424,6 +453,9 @@ func (s *PollEventsSuite) invokeAndAssertPollEventsRPC(listenerID string, waitTi
 	if maxNumberOfMessages != nil {
 		params["MaxNumberOfMessages"] = *maxNumberOfMessages
 	}
+	if eventPattern != "" {
+		params["EventPattern"] = eventPattern
+	}
 
 	reqMap := map[string]any{
 		"jsonrpc": "2.0",
@@ -0,0 +1,345 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cassette records the AWS API calls of iatk to a file and replays them, without network, in a
+// later run, so that test suites can run offline and flaky runs can be reproduced exactly.
+//
+// A cassette may be recorded by several processes, e.g. one per call of a client running the binary for
+// each, so recording appends to it; New starts an empty one. Replayed calls are answered by the recorded
+// call of the same operation with the same request body, once the values generated anew by each run,
+// such as the IDs of test harnesses and their creation time, are normalized, see Normalize.
+//
+// Credentials found in request and response bodies, e.g. those returned by STS AssumeRole, are redacted
+// before being written, see Redact.
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// PathEnv names the environment variable holding the path of the cassette file. AWS API calls are
+	// neither recorded nor replayed if it is not set.
+	PathEnv = "IATK_CASSETTE"
+	// ModeEnv names the environment variable holding the mode of the cassette: record or replay.
+	// Defaults to replay.
+	ModeEnv = "IATK_CASSETTE_MODE"
+)
+
+// Mode tells whether a cassette records calls or replays them.
+type Mode string
+
+const (
+	// ModeRecord sends calls to AWS and appends them to the cassette file.
+	ModeRecord Mode = "record"
+	// ModeReplay answers calls with the responses recorded in the cassette file, without sending them.
+	ModeReplay Mode = "replay"
+)
+
+// ParseMode parses a mode name, case insensitive. An empty name is replay.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "", string(ModeReplay):
+		return ModeReplay, nil
+	case string(ModeRecord):
+		return ModeRecord, nil
+	}
+	return "", fmt.Errorf("invalid cassette mode %q, expected record or replay", s)
+}
+
+// Interaction is an AWS API call recorded in a cassette file, one per line.
+type Interaction struct {
+	Service   string   `json:"service"`
+	Operation string   `json:"operation"`
+	Request   Request  `json:"request"`
+	Response  Response `json:"response"`
+}
+
+// Request is the HTTP request of an Interaction.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+// Response is the HTTP response of an Interaction.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// ErrNoInteraction is returned instead of sending a call when replaying if no recorded interaction with
+// the same normalized request body is left for it.
+type ErrNoInteraction struct {
+	Service   string
+	Operation string
+}
+
+func (e *ErrNoInteraction) Error() string {
+	return fmt.Sprintf("no recorded interaction left in cassette for %s %s", e.Service, e.Operation)
+}
+
+// Cassette records AWS API calls to a file or replays them.
+type Cassette struct {
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New creates an empty cassette file at path, replacing any previous recording.
+func New(path string) error {
+	f, err := os.OpenFile(path, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create cassette: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to create cassette: %w", err)
+	}
+	return nil
+}
+
+// Open opens the cassette file at path in the given mode. When replaying, the file must exist. When
+// recording, it is created if need be, and calls are appended to it.
+func Open(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	if mode == ModeRecord {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open cassette: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return nil, fmt.Errorf("failed to open cassette: %w", err)
+		}
+		return c, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var i Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return nil, fmt.Errorf("failed to read cassette %s: %w", path, err)
+		}
+		c.interactions = append(c.interactions, i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette %s: %w", path, err)
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+var current *Cassette
+
+// Init opens the cassette given by the environment, if any, for Current to return.
+func Init() error {
+	path := os.Getenv(PathEnv)
+	if path == "" {
+		current = nil
+		return nil
+	}
+	mode, err := ParseMode(os.Getenv(ModeEnv))
+	if err != nil {
+		return err
+	}
+	c, err := Open(path, mode)
+	if err != nil {
+		return err
+	}
+	current = c
+	return nil
+}
+
+// Current returns the cassette opened by Init, or nil if there is none.
+func Current() *Cassette {
+	return current
+}
+
+// Mode returns the mode c was opened in.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// AddMiddleware adds to stack the middleware recording or replaying the calls of an AWS SDK client. It
+// is meant for the APIOptions of an aws.Config.
+func (c *Cassette) AddMiddleware(stack *middleware.Stack) error {
+	// innermost, so that every attempt of a call is seen as sent
+	return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("IatkCassette", c.handleDeserialize), middleware.After)
+}
+
+func (c *Cassette) handleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	middleware.DeserializeOutput, middleware.Metadata, error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return next.HandleDeserialize(ctx, in)
+	}
+	var body []byte
+	if stream := req.GetStream(); stream != nil {
+		b, err := io.ReadAll(stream)
+		if err != nil {
+			return middleware.DeserializeOutput{}, middleware.Metadata{}, fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = b
+		if req, err = req.SetStream(bytes.NewReader(body)); err != nil {
+			return middleware.DeserializeOutput{}, middleware.Metadata{}, err
+		}
+		in.Request = req
+	}
+	i := Interaction{
+		Service:   awsmiddleware.GetServiceID(ctx),
+		Operation: awsmiddleware.GetOperationName(ctx),
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   Redact(string(body)),
+		},
+	}
+
+	if c.mode == ModeReplay {
+		recorded, err := c.next(i)
+		if err != nil {
+			return middleware.DeserializeOutput{}, middleware.Metadata{}, err
+		}
+		resp := &http.Response{
+			StatusCode:    recorded.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+			Header:        recorded.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Response.Body)),
+			ContentLength: int64(len(recorded.Response.Body)),
+			Request:       req.Build(ctx),
+		}
+		if resp.Header == nil {
+			resp.Header = http.Header{}
+		}
+		return middleware.DeserializeOutput{RawResponse: &smithyhttp.Response{Response: resp}}, middleware.Metadata{}, nil
+	}
+
+	out, metadata, err := next.HandleDeserialize(ctx, in)
+	resp, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok || resp == nil || resp.Response == nil {
+		// the call was not answered, e.g. the connection failed, there is nothing to replay
+		return out, metadata, err
+	}
+	respBody, errRead := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if errRead != nil {
+		return out, metadata, fmt.Errorf("failed to read response body: %w", errRead)
+	}
+	i.Response = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       Redact(string(respBody)),
+	}
+	if errRecord := c.record(i); errRecord != nil {
+		return out, metadata, errRecord
+	}
+	return out, metadata, err
+}
+
+// next returns the recorded interaction answering i: the first one not replayed yet of the same
+// operation with the same normalized request body.
+func (c *Cassette) next(i Interaction) (Interaction, error) {
+	body := Normalize(i.Request.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for j, recorded := range c.interactions {
+		if c.used[j] || recorded.Service != i.Service || recorded.Operation != i.Operation || Normalize(recorded.Request.Body) != body {
+			continue
+		}
+		c.used[j] = true
+		return recorded, nil
+	}
+	return Interaction{}, &ErrNoInteraction{Service: i.Service, Operation: i.Operation}
+}
+
+// record appends i to the cassette file, with a single write so that calls recorded at once do not
+// interleave their lines.
+func (c *Cassette) record(i Interaction) error {
+	line, err := json.Marshal(i)
+	if err != nil {
+		return fmt.Errorf("failed to encode interaction: %w", err)
+	}
+	line = append(line, '\n')
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open cassette: %w", err)
+	}
+	_, errWrite := f.Write(line)
+	errClose := f.Close()
+	if errWrite != nil {
+		return fmt.Errorf("failed to record interaction: %w", errWrite)
+	}
+	if errClose != nil {
+		return fmt.Errorf("failed to record interaction: %w", errClose)
+	}
+	return nil
+}
+
+var (
+	// idPattern matches the IDs of test harnesses, e.g. "iatk_eb_<xid>", which also name their resources.
+	idPattern = regexp.MustCompile(`(iatk_[a-z]+_)[0-9a-v]{20}\b`)
+	// timePattern matches RFC3339 times, e.g. of the creation time tagged on test harnesses, with their
+	// colons possibly URL encoded.
+	timePattern = regexp.MustCompile(`(?i)\d{4}-\d{2}-\d{2}T\d{2}(?::|%3A)\d{2}(?::|%3A)\d{2}(?:\.\d+)?(?:Z|(?:[+-]|%2B)\d{2}(?::|%3A)\d{2})`)
+)
+
+// Normalize replaces the values of body, a request body, that differ between runs making the same calls
+// with placeholders, so that a call replayed matches the one recorded: the IDs of test harnesses and
+// RFC3339 times.
+func Normalize(body string) string {
+	body = idPattern.ReplaceAllString(body, "${1}<id>")
+	return timePattern.ReplaceAllString(body, "<time>")
+}
+
+// Redacted replaces the credentials redacted from a cassette.
+const Redacted = "REDACTED"
+
+// credentialFields are the fields of requests and responses of the AWS APIs holding credentials, e.g.
+// those of STS AssumeRole or SSO GetRoleCredentials.
+var credentialFields = []string{
+	"AccessKeyId", "SecretAccessKey", "SessionToken", "WebIdentityToken", "SAMLAssertion", "Password",
+}
+
+var (
+	xmlCredentialPattern  = regexp.MustCompile(`(?i)(<(` + strings.Join(credentialFields, "|") + `)>)[^<]*(</)`)
+	jsonCredentialPattern = regexp.MustCompile(`(?i)("(` + strings.Join(credentialFields, "|") + `)"\s*:\s*")(?:[^"\\]|\\.)*(")`)
+	formCredentialPattern = regexp.MustCompile(`(?i)((?:^|&)(` + strings.Join(credentialFields, "|") + `)=)[^&]*`)
+)
+
+// Redact replaces the credentials in body, an XML, JSON or form encoded request or response body, with
+// Redacted.
+func Redact(body string) string {
+	body = xmlCredentialPattern.ReplaceAllString(body, "${1}"+Redacted+"${3}")
+	body = jsonCredentialPattern.ReplaceAllString(body, "${1}"+Redacted+"${3}")
+	return formCredentialPattern.ReplaceAllString(body, "${1}"+Redacted)
+}
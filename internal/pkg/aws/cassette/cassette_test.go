@@ -0,0 +1,243 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getQueueURLResponse(url string) string {
+	return `<GetQueueUrlResponse><GetQueueUrlResult><QueueUrl>` + url + `</QueueUrl></GetQueueUrlResult>` +
+		`<ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></GetQueueUrlResponse>`
+}
+
+func newClient(c *Cassette, endpoint string) *sqs.Client {
+	return sqs.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: endpoint}, nil
+		}),
+		APIOptions: []func(*middleware.Stack) error{c.AddMiddleware},
+	})
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(getQueueURLResponse("https://sqs/" + r.Form.Get("QueueName"))))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	recorder, err := Open(path, ModeRecord)
+	require.NoError(t, err)
+	client := newClient(recorder, server.URL)
+	for _, name := range []string{"first", "second"} {
+		out, err := client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+		require.NoError(t, err)
+		assert.Equal(t, "https://sqs/"+name, *out.QueueUrl)
+	}
+	assert.EqualValues(t, 2, calls)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), 2)
+
+	player, err := Open(path, ModeReplay)
+	require.NoError(t, err)
+	// nothing listens there, replayed calls must not be sent
+	client = newClient(player, "http://127.0.0.1:1")
+	// requests with a recorded body get its response, whatever the order
+	out, err := client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("second")})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sqs/second", *out.QueueUrl)
+	// others get none
+	_, err = client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("third")})
+	var errNoInteraction *ErrNoInteraction
+	require.ErrorAs(t, err, &errNoInteraction)
+	assert.Equal(t, &ErrNoInteraction{Service: "SQS", Operation: "GetQueueUrl"}, errNoInteraction)
+	out, err = client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("first")})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sqs/first", *out.QueueUrl)
+	// each recorded interaction is replayed once
+	_, err = client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("first")})
+	require.ErrorAs(t, err, &errNoInteraction)
+	assert.EqualValues(t, 2, calls)
+
+	// recording again, e.g. in another process, appends to the previous recording
+	recorder, err = Open(path, ModeRecord)
+	require.NoError(t, err)
+	_, err = newClient(recorder, server.URL).GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("third")})
+	require.NoError(t, err)
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), 3)
+
+	// until a new cassette replaces it
+	require.NoError(t, New(path))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, b)
+}
+
+func TestReplayNormalized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	line := `{"service":"SQS","operation":"GetQueueUrl","request":{"method":"POST","url":"http://sqs/","body":"Action=GetQueueUrl&QueueName=iatk_eb_cjmq4k1ohpbs73d0ecv0&Version=2012-11-05"},` +
+		`"response":{"status_code":200,"header":{"Content-Type":["text/xml"]},"body":"` + getQueueURLResponse("https://sqs/iatk_eb_cjmq4k1ohpbs73d0ecv0") + `"}}`
+	require.NoError(t, os.WriteFile(path, []byte(line+"\n"), 0o644))
+
+	player, err := Open(path, ModeReplay)
+	require.NoError(t, err)
+	// the listener of the replayed run has an ID of its own
+	out, err := newClient(player, "http://127.0.0.1:1").GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("iatk_eb_ck2q6v1ohpbs7b8hbtlg")})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sqs/iatk_eb_cjmq4k1ohpbs73d0ecv0", *out.QueueUrl)
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]struct {
+		body   string
+		expect string
+	}{
+		"id": {
+			body:   `{"Name":"iatk_eb_cjmq4k1ohpbs73d0ecv0","Description":"rule for Listener \"iatk_eb_cjmq4k1ohpbs73d0ecv0\""}`,
+			expect: `{"Name":"iatk_eb_<id>","Description":"rule for Listener \"iatk_eb_<id>\""}`,
+		},
+		"id in url": {
+			body:   `QueueUrl=https%3A%2F%2Fsqs%2F123456789012%2Fiatk_eb_cjmq4k1ohpbs73d0ecv0`,
+			expect: `QueueUrl=https%3A%2F%2Fsqs%2F123456789012%2Fiatk_eb_<id>`,
+		},
+		"time": {
+			body:   `{"Key":"iatk:TestHarness:Created","Value":"2023-09-01T10:00:00+02:00"}`,
+			expect: `{"Key":"iatk:TestHarness:Created","Value":"<time>"}`,
+		},
+		"url encoded time": {
+			body:   `Tag.1.Key=iatk%3ATestHarness%3ACreated&Tag.1.Value=2023-09-01T10%3A00%3A00Z`,
+			expect: `Tag.1.Key=iatk%3ATestHarness%3ACreated&Tag.1.Value=<time>`,
+		},
+		"other values": {
+			body:   `{"Name":"my-rule-cjmq4k1ohpbs73d0ecv0","Limit":20}`,
+			expect: `{"Name":"my-rule-cjmq4k1ohpbs73d0ecv0","Limit":20}`,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, Normalize(tt.body))
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	cases := map[string]struct {
+		body   string
+		expect string
+	}{
+		"xml": {
+			body: `<AssumeRoleResponse><AssumeRoleResult><Credentials><AccessKeyId>ASIA123</AccessKeyId>` +
+				`<SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>` +
+				`<Expiration>2023-09-01T10:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`,
+			expect: `<AssumeRoleResponse><AssumeRoleResult><Credentials><AccessKeyId>REDACTED</AccessKeyId>` +
+				`<SecretAccessKey>REDACTED</SecretAccessKey><SessionToken>REDACTED</SessionToken>` +
+				`<Expiration>2023-09-01T10:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`,
+		},
+		"json": {
+			body:   `{"roleCredentials":{"accessKeyId":"ASIA123","secretAccessKey":"se\"cret","sessionToken":"token","expiration":1}}`,
+			expect: `{"roleCredentials":{"accessKeyId":"REDACTED","secretAccessKey":"REDACTED","sessionToken":"REDACTED","expiration":1}}`,
+		},
+		"form": {
+			body:   `Action=AssumeRoleWithWebIdentity&RoleArn=arn&WebIdentityToken=token&Version=2011-06-15`,
+			expect: `Action=AssumeRoleWithWebIdentity&RoleArn=arn&WebIdentityToken=REDACTED&Version=2011-06-15`,
+		},
+		"no credentials": {
+			body:   getQueueURLResponse("https://sqs/queue"),
+			expect: getQueueURLResponse("https://sqs/queue"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, Redact(tt.body))
+		})
+	}
+}
+
+func TestReplayErrorResponse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	line := `{"service":"SQS","operation":"GetQueueUrl","request":{"method":"POST","url":"http://sqs/","body":"Action=GetQueueUrl&QueueName=q&Version=2012-11-05"},` +
+		`"response":{"status_code":400,"header":{"Content-Type":["text/xml"]},"body":"<ErrorResponse><Error><Type>Sender</Type>` +
+		`<Code>AWS.SimpleQueueService.NonExistentQueue</Code><Message>no queue</Message></Error><RequestId>1</RequestId></ErrorResponse>"}}`
+	require.NoError(t, os.WriteFile(path, []byte(line+"\n"), 0o644))
+
+	player, err := Open(path, ModeReplay)
+	require.NoError(t, err)
+	_, err = newClient(player, "http://127.0.0.1:1").GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("q")})
+	assert.ErrorContains(t, err, "NonExistentQueue")
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.jsonl"), ModeReplay)
+	assert.ErrorContains(t, err, "failed to open cassette")
+
+	path := filepath.Join(t.TempDir(), "invalid.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	_, err = Open(path, ModeReplay)
+	assert.ErrorContains(t, err, "failed to read cassette")
+
+	// recording creates the file if need be
+	_, err = Open(filepath.Join(t.TempDir(), "missing.jsonl"), ModeRecord)
+	assert.NoError(t, err)
+}
+
+func TestParseMode(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Mode
+		err      string
+	}{
+		{"", ModeReplay, ""},
+		{"replay", ModeReplay, ""},
+		{"RECORD", ModeRecord, ""},
+		{"rewind", "", `invalid cassette mode "rewind", expected record or replay`},
+	}
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			mode, err := ParseMode(tt.input)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Setenv(PathEnv, "")
+	require.NoError(t, Init())
+	assert.Nil(t, Current())
+
+	t.Setenv(PathEnv, filepath.Join(t.TempDir(), "cassette.jsonl"))
+	t.Setenv(ModeEnv, "record")
+	require.NoError(t, Init())
+	t.Cleanup(func() { current = nil })
+	require.NotNil(t, Current())
+	assert.Equal(t, ModeRecord, Current().Mode())
+}
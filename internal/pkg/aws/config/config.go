@@ -5,27 +5,281 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"iatk/internal/pkg/aws/cassette"
 	"iatk/internal/pkg/jsonrpc"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	smithymiddleware "github.com/aws/smithy-go/middleware"
 )
 
-func GetAWSConfig(ctx context.Context, region string, profile string, metadata *jsonrpc.Metadata) (aws.Config, error) {
+type cacheKey struct {
+	region  string
+	profile string
+}
+
+// cache holds configs already loaded by this process, so that a long-lived process
+// does not reload shared config files and shares one credentials cache per region and profile.
+var cache = struct {
+	sync.Mutex
+	m map[cacheKey]*cacheEntry
+}{m: map[cacheKey]*cacheEntry{}}
+
+// cacheEntry is a config loaded, or being loaded, once done is closed.
+type cacheEntry struct {
+	done chan struct{}
+	cfg  aws.Config
+	err  error
+}
+
+// loadDefaultConfig loads a config from the environment and shared config files, as the AWS SDK does.
+var loadDefaultConfig = config.LoadDefaultConfig
+
+type roleKey struct {
+	cacheKey
+	role AssumeRole
+	// the STS client assuming the role is configured with these
+	stsEndpointURL string
+	retry          Retry
+}
+
+// roleCache holds the credentials of the roles already assumed by this process, so that a long-lived
+// process only calls STS again once they expire.
+var roleCache = struct {
+	sync.Mutex
+	m map[roleKey]aws.CredentialsProvider
+}{m: map[roleKey]aws.CredentialsProvider{}}
+
+// DefaultRoleSessionName names the sessions of assumed roles unless AssumeRole.SessionName is set.
+const DefaultRoleSessionName = "aws-iatk"
+
+// Options customize the config returned by GetAWSConfig.
+type Options struct {
+	// EndpointURL is the URL clients send requests to instead of the AWS endpoints, e.g. the one of a
+	// local emulator.
+	EndpointURL string
+	// EndpointURLs are the URLs the clients of some services send requests to, keyed by service ID, e.g.
+	// SQS or EventBridge, case and spaces ignored. They take precedence over EndpointURL.
+	EndpointURLs map[string]string
+	// AssumeRole, if its RoleARN is set, is the role clients assume with the credentials of the profile.
+	// Roles are assumed through the STS endpoint in EndpointURLs, if any, else the AWS one, never through
+	// EndpointURL.
+	AssumeRole AssumeRole
+	// Retry customizes how clients retry failed calls.
+	Retry Retry
+}
+
+// AssumeRole describes a role to assume through STS, e.g. in the account under test.
+type AssumeRole struct {
+	// RoleARN is the ARN of the role.
+	RoleARN string
+	// ExternalID is the external ID the trust policy of the role may require.
+	ExternalID string
+	// SessionName names the session. Defaults to DefaultRoleSessionName.
+	SessionName string
+	// Duration is the duration of the session. Defaults to the one of stscreds, 15 minutes.
+	Duration time.Duration
+}
+
+func GetAWSConfig(ctx context.Context, region string, profile string, metadata *jsonrpc.Metadata, optFns ...func(*Options)) (aws.Config, error) {
+	var opts Options
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	resolver, err := endpointResolver(opts.EndpointURL, opts.EndpointURLs)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
 	uaVal := "unknown"
 	if metadata != nil {
 		uaVal = metadata.UserAgentValue()
 	}
-	cfg, err := config.LoadDefaultConfig(
+
+	c := cassette.Current()
+	if c != nil && c.Mode() == cassette.ModeReplay {
+		// the profile may only exist where the calls were recorded
+		profile = ""
+	}
+
+	base, err := loadConfig(ctx, region, profile)
+	if err != nil {
+		return base, err
+	}
+
+	cfg := withCallOptions(base, uaVal, opts.Retry, c)
+	if resolver != nil {
+		cfg.EndpointResolverWithOptions = resolver
+	}
+	if c != nil && c.Mode() == cassette.ModeReplay {
+		// replayed calls are not sent, so not signed either
+		cfg.Credentials = aws.AnonymousCredentials{}
+		return cfg, nil
+	}
+	if opts.AssumeRole.RoleARN != "" {
+		var stsEndpointURL string
+		for service, u := range opts.EndpointURLs {
+			if normalizeServiceID(service) == stsServiceID {
+				stsEndpointURL = u
+			}
+		}
+		// the credentials of a role are shared by the calls of every client, so the STS client does not
+		// identify any of them in its user agent
+		stsCfg := withCallOptions(base, "unknown", opts.Retry, c)
+		if stsEndpointURL != "" {
+			// validated along with the other endpoint URLs
+			stsCfg.EndpointResolverWithOptions, _ = endpointResolver("", map[string]string{stsServiceID: stsEndpointURL})
+		}
+		cfg.Credentials = assumeRoleCredentials(stsCfg, roleKey{
+			cacheKey:       cacheKey{region, profile},
+			role:           opts.AssumeRole,
+			stsEndpointURL: stsEndpointURL,
+			retry:          opts.Retry,
+		})
+	}
+
+	return cfg, nil
+}
+
+// stsServiceID is the normalized service ID of STS, see normalizeServiceID.
+const stsServiceID = "sts"
+
+// withCallOptions returns a copy of base whose clients identify iatk and its client with uaVal in their
+// user agent, retry following retry and go through c, if not nil.
+func withCallOptions(base aws.Config, uaVal string, retry Retry, c *cassette.Cassette) aws.Config {
+	cfg := base.Copy()
+	cfg.APIOptions = append([]func(*smithymiddleware.Stack) error{}, base.APIOptions...)
+	cfg.APIOptions = append(cfg.APIOptions, awsmiddleware.AddUserAgentKeyValue("aws-iatk", uaVal))
+	cfg.APIOptions = append(cfg.APIOptions, addAttempts)
+	if retry != (Retry{}) {
+		cfg.Retryer = retry.withDefaults(base).retryer()
+	}
+	if c != nil {
+		cfg.APIOptions = append(cfg.APIOptions, c.AddMiddleware)
+	}
+	return cfg
+}
+
+// loadConfig returns the config of region and profile, loading it once for concurrent calls. Loading may
+// take seconds, e.g. to get SSO credentials, so other regions and profiles are not held up meanwhile.
+func loadConfig(ctx context.Context, region string, profile string) (aws.Config, error) {
+	key := cacheKey{region, profile}
+
+	cache.Lock()
+	e, ok := cache.m[key]
+	if !ok {
+		e = &cacheEntry{done: make(chan struct{})}
+		cache.m[key] = e
+	}
+	cache.Unlock()
+
+	if ok {
+		select {
+		case <-e.done:
+			return e.cfg, e.err
+		case <-ctx.Done():
+			return aws.Config{}, ctx.Err()
+		}
+	}
+
+	e.cfg, e.err = loadDefaultConfig(
 		ctx,
 		config.WithSharedConfigProfile(profile),
 		config.WithRegion(region),
-		config.WithAPIOptions([]func(*smithymiddleware.Stack) error{
-			awsmiddleware.AddUserAgentKeyValue("aws-iatk", uaVal),
-		}),
 	)
+	if e.err != nil {
+		// the next call loads it again
+		cache.Lock()
+		delete(cache.m, key)
+		cache.Unlock()
+	}
+	close(e.done)
+
+	return e.cfg, e.err
+}
+
+// assumeRoleCredentials returns the credentials of the role of rk, assumed by an STS client of stsCfg,
+// cached until they expire.
+func assumeRoleCredentials(stsCfg aws.Config, rk roleKey) aws.CredentialsProvider {
+	if rk.role.SessionName == "" {
+		rk.role.SessionName = DefaultRoleSessionName
+	}
+	role := rk.role
+
+	roleCache.Lock()
+	defer roleCache.Unlock()
+	if provider, ok := roleCache.m[rk]; ok {
+		return provider
+	}
+
+	provider := aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(stsCfg), role.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = role.SessionName
+		if role.ExternalID != "" {
+			o.ExternalID = aws.String(role.ExternalID)
+		}
+		if role.Duration != 0 {
+			o.Duration = role.Duration
+		}
+	}))
+	roleCache.m[rk] = provider
+	return provider
+}
+
+// endpointResolver returns a resolver of the endpoints of services to endpointURL, or to the URL of their
+// service ID in perService, falling back to the AWS endpoints. It returns nil if there is no URL.
+func endpointResolver(endpointURL string, perService map[string]string) (aws.EndpointResolverWithOptions, error) {
+	if endpointURL == "" && len(perService) == 0 {
+		return nil, nil
+	}
+	if endpointURL != "" {
+		if err := ValidateEndpointURL(endpointURL); err != nil {
+			return nil, err
+		}
+	}
+	urls := make(map[string]string, len(perService))
+	for service, u := range perService {
+		if err := ValidateEndpointURL(u); err != nil {
+			return nil, fmt.Errorf("%w for service %q", err, service)
+		}
+		urls[normalizeServiceID(service)] = u
+	}
+	return aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+		u, ok := urls[normalizeServiceID(service)]
+		if !ok {
+			u = endpointURL
+		}
+		if u == "" {
+			// resolve the AWS endpoint
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		}
+		return aws.Endpoint{
+			URL:               u,
+			SigningRegion:     region,
+			Source:            aws.EndpointSourceCustom,
+			HostnameImmutable: true,
+		}, nil
+	}), nil
+}
+
+// ValidateEndpointURL returns an error if endpointURL is not an absolute URL.
+func ValidateEndpointURL(endpointURL string) error {
+	u, err := url.Parse(endpointURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid endpoint URL %q, expected an absolute URL such as http://localhost:4566", endpointURL)
+	}
+	return nil
+}
 
-	return cfg, err
+// normalizeServiceID turns service IDs such as "Resource Groups Tagging API" into a form such as
+// "resourcegroupstaggingapi", so that they can be given in any case, with or without spaces.
+func normalizeServiceID(service string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(service))
 }
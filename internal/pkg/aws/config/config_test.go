@@ -5,13 +5,21 @@ package config
 
 import (
 	"context"
+	"iatk/internal/pkg/aws/cassette"
 	"iatk/internal/pkg/jsonrpc"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,9 +54,194 @@ func TestConfigClientUserAgentIsAdded(t *testing.T) {
 	}
 	cfg, err := GetAWSConfig(context.TODO(), "us-west-2", "", metadata)
 	require.NoError(t, err)
-	assert.Len(t, cfg.APIOptions, 1)
+	assert.Len(t, cfg.APIOptions, 2)
 	expectFunc := awsmiddleware.AddUserAgentKeyValue("does not matter", "does not matter")
 	expectFuncName := runtime.FuncForPC(reflect.ValueOf(expectFunc).Pointer())
 	actualFuncName := runtime.FuncForPC(reflect.ValueOf(cfg.APIOptions[0]).Pointer())
 	assert.Equal(t, expectFuncName, actualFuncName)
 }
+
+func TestConfigIsReused(t *testing.T) {
+	first, err := GetAWSConfig(context.TODO(), "us-east-2", "", nil)
+	require.NoError(t, err)
+	second, err := GetAWSConfig(context.TODO(), "us-east-2", "", nil)
+	require.NoError(t, err)
+
+	// credentials cache should be shared while user agent options should not pile up
+	assert.Same(t, first.Credentials, second.Credentials)
+	assert.Len(t, first.APIOptions, 2)
+	assert.Len(t, second.APIOptions, 2)
+}
+
+func TestConfigCassette(t *testing.T) {
+	t.Setenv(cassette.PathEnv, filepath.Join(t.TempDir(), "cassette.jsonl"))
+	t.Setenv(cassette.ModeEnv, "record")
+	require.NoError(t, cassette.Init())
+	t.Cleanup(func() {
+		os.Unsetenv(cassette.PathEnv)
+		cassette.Init()
+	})
+
+	cfg, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil)
+	require.NoError(t, err)
+	assert.Len(t, cfg.APIOptions, 3)
+	assert.NotEqual(t, aws.AnonymousCredentials{}, cfg.Credentials)
+
+	require.NoError(t, os.WriteFile(os.Getenv(cassette.PathEnv), nil, 0o644))
+	t.Setenv(cassette.ModeEnv, "replay")
+	require.NoError(t, cassette.Init())
+
+	// replayed calls are not signed, with credentials of a profile that may not exist here
+	cfg, err = GetAWSConfig(context.TODO(), "us-west-2", "profile-of-the-recording", nil)
+	require.NoError(t, err)
+	assert.Len(t, cfg.APIOptions, 3)
+	assert.Equal(t, aws.AnonymousCredentials{}, cfg.Credentials)
+}
+
+func TestConfigEndpointURLs(t *testing.T) {
+	cfg, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg.EndpointResolverWithOptions)
+
+	cfg, err = GetAWSConfig(context.TODO(), "us-west-2", "", nil, func(o *Options) {
+		o.EndpointURL = "http://localhost:4566"
+		o.EndpointURLs = map[string]string{"xray": "http://localhost:2000", "Resource Groups Tagging API": "http://localhost:3000"}
+	})
+	require.NoError(t, err)
+	cases := map[string]string{
+		"SQS":                         "http://localhost:4566",
+		"XRay":                        "http://localhost:2000",
+		"Resource Groups Tagging API": "http://localhost:3000",
+	}
+	for service, expected := range cases {
+		endpoint, err := cfg.EndpointResolverWithOptions.ResolveEndpoint(service, "us-west-2")
+		require.NoError(t, err, service)
+		assert.Equal(t, expected, endpoint.URL, service)
+		assert.Equal(t, "us-west-2", endpoint.SigningRegion, service)
+		assert.True(t, endpoint.HostnameImmutable, service)
+	}
+
+	// services without an endpoint URL resolve the AWS ones
+	cfg, err = GetAWSConfig(context.TODO(), "us-west-2", "", nil, func(o *Options) {
+		o.EndpointURLs = map[string]string{"SQS": "http://localhost:9324"}
+	})
+	require.NoError(t, err)
+	_, err = cfg.EndpointResolverWithOptions.ResolveEndpoint("EventBridge", "us-west-2")
+	var errNotFound *aws.EndpointNotFoundError
+	assert.ErrorAs(t, err, &errNotFound)
+
+	_, err = GetAWSConfig(context.TODO(), "us-west-2", "", nil, func(o *Options) {
+		o.EndpointURL = "localhost"
+	})
+	assert.EqualError(t, err, `invalid endpoint URL "localhost", expected an absolute URL such as http://localhost:4566`)
+}
+
+func TestConfigAssumeRole(t *testing.T) {
+	role := func(o *Options) {
+		o.AssumeRole = AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/test", ExternalID: "ext"}
+	}
+	base, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil)
+	require.NoError(t, err)
+	first, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil, role)
+	require.NoError(t, err)
+	second, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil, role)
+	require.NoError(t, err)
+	other, err := GetAWSConfig(context.TODO(), "us-west-2", "", nil, func(o *Options) {
+		o.AssumeRole = AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/other"}
+	})
+	require.NoError(t, err)
+
+	assert.IsType(t, &aws.CredentialsCache{}, first.Credentials)
+	assert.NotSame(t, base.Credentials, first.Credentials)
+	// the credentials of a role are shared until they expire
+	assert.Same(t, first.Credentials, second.Credentials)
+	assert.NotSame(t, first.Credentials, other.Credentials)
+}
+
+func TestConfigAssumeRoleThroughSTSEndpoint(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(`<AssumeRoleResponse><AssumeRoleResult><Credentials><AccessKeyId>ASIA</AccessKeyId>` +
+			`<SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>` +
+			`<Expiration>2100-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`))
+	}))
+	defer server.Close()
+	options := func(endpointURL string, maxAttempts int) func(o *Options) {
+		return func(o *Options) {
+			// the endpoint of the emulator must not be used to assume the role
+			o.EndpointURL = endpointURL
+			o.EndpointURLs = map[string]string{"STS": server.URL}
+			o.AssumeRole = AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/test"}
+			o.Retry = Retry{MaxAttempts: maxAttempts}
+		}
+	}
+
+	first, err := GetAWSConfig(context.TODO(), "eu-north-1", "", nil, options("http://127.0.0.1:1", 1))
+	require.NoError(t, err)
+	creds, err := first.Credentials.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA", creds.AccessKeyID)
+	assert.EqualValues(t, 1, calls)
+
+	second, err := GetAWSConfig(context.TODO(), "eu-north-1", "", nil, options("http://127.0.0.1:2", 1))
+	require.NoError(t, err)
+	assert.Same(t, first.Credentials, second.Credentials)
+	// the STS client of a role is not shared by calls configuring it differently
+	other, err := GetAWSConfig(context.TODO(), "eu-north-1", "", nil, options("http://127.0.0.1:1", 2))
+	require.NoError(t, err)
+	assert.NotSame(t, first.Credentials, other.Credentials)
+}
+
+func TestLoadConfigConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	var slowLoads int32
+	loadDefaultConfig = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+		var o config.LoadOptions
+		for _, fn := range optFns {
+			require.NoError(t, fn(&o))
+		}
+		if o.Region == "slow-region" {
+			atomic.AddInt32(&slowLoads, 1)
+			<-release
+		}
+		return aws.Config{Region: o.Region}, nil
+	}
+	t.Cleanup(func() {
+		loadDefaultConfig = config.LoadDefaultConfig
+		cache.Lock()
+		delete(cache.m, cacheKey{"slow-region", ""})
+		delete(cache.m, cacheKey{"fast-region", ""})
+		cache.Unlock()
+	})
+
+	slow := make(chan aws.Config, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			cfg, err := loadConfig(context.TODO(), "slow-region", "")
+			assert.NoError(t, err)
+			slow <- cfg
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&slowLoads) == 1 }, time.Second, time.Millisecond)
+
+	// another region is not held up by the slow one
+	cfg, err := loadConfig(context.TODO(), "fast-region", "")
+	require.NoError(t, err)
+	assert.Equal(t, "fast-region", cfg.Region)
+
+	// a caller waiting for the slow one gives up with its context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = loadConfig(ctx, "slow-region", "")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	assert.Equal(t, "slow-region", (<-slow).Region)
+	assert.Equal(t, "slow-region", (<-slow).Region)
+	// loaded once for concurrent calls
+	assert.EqualValues(t, 1, slowLoads)
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Retry customizes how clients retry failed calls, e.g. throttled ones. Zero values keep the settings of
+// the environment or the shared config files, else the defaults of the AWS SDK.
+type Retry struct {
+	// Mode is standard or adaptive, which also slows down calls to throttled services ahead of retrying.
+	Mode aws.RetryMode
+	// MaxAttempts is the maximum number of attempts of a call, the first one included.
+	MaxAttempts int
+	// MaxBackoff is the maximum delay between two attempts.
+	MaxBackoff time.Duration
+}
+
+// withDefaults returns r whose unset fields are set as in cfg, from the environment or the shared config
+// files, e.g. AWS_RETRY_MODE, since the retryer of r replaces the one they configure.
+func (r Retry) withDefaults(cfg aws.Config) Retry {
+	if r.Mode == "" {
+		r.Mode = cfg.RetryMode
+	}
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = cfg.RetryMaxAttempts
+	}
+	return r
+}
+
+// retryer returns a function creating a retryer following r.
+func (r Retry) retryer() func() aws.Retryer {
+	return func() aws.Retryer {
+		standard := func(o *retry.StandardOptions) {
+			if r.MaxAttempts != 0 {
+				o.MaxAttempts = r.MaxAttempts
+			}
+			if r.MaxBackoff != 0 {
+				o.MaxBackoff = r.MaxBackoff
+			}
+		}
+		if r.Mode == aws.RetryModeAdaptive {
+			return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+				o.StandardOptions = append(o.StandardOptions, standard)
+			})
+		}
+		return retry.NewStandard(standard)
+	}
+}
+
+// attemptsError is the error of a call that failed after the given number of attempts.
+type attemptsError struct {
+	attempts int
+	err      error
+}
+
+// Error returns the message of the error of the last attempt, the number of attempts is only told by
+// Attempts.
+func (e *attemptsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attemptsError) Unwrap() error {
+	return e.err
+}
+
+// Attempts returns the number of attempts of the call.
+func (e *attemptsError) Attempts() int {
+	return e.attempts
+}
+
+// addAttempts adds to stack a middleware telling the number of attempts of failed calls through the
+// Attempts method of their errors.
+func addAttempts(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("IatkAttempts", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		middleware.InitializeOutput, middleware.Metadata, error,
+	) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		if err != nil {
+			if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+				err = &attemptsError{attempts: len(results.Results), err: err}
+			}
+		}
+		return out, metadata, err
+	}), middleware.Before)
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryer(t *testing.T) {
+	cases := map[string]struct {
+		retry       Retry
+		expectType  aws.Retryer
+		maxAttempts int
+	}{
+		"standard": {
+			retry:       Retry{MaxAttempts: 5},
+			expectType:  &retry.Standard{},
+			maxAttempts: 5,
+		},
+		"adaptive": {
+			retry:       Retry{Mode: aws.RetryModeAdaptive, MaxAttempts: 7, MaxBackoff: time.Second},
+			expectType:  &retry.AdaptiveMode{},
+			maxAttempts: 7,
+		},
+		"default max attempts": {
+			retry:       Retry{Mode: aws.RetryModeStandard},
+			expectType:  &retry.Standard{},
+			maxAttempts: retry.DefaultMaxAttempts,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := tt.retry.retryer()()
+			assert.IsType(t, tt.expectType, r)
+			assert.Equal(t, tt.maxAttempts, r.MaxAttempts())
+		})
+	}
+}
+
+func TestRetryWithDefaults(t *testing.T) {
+	cfg := aws.Config{RetryMode: aws.RetryModeAdaptive, RetryMaxAttempts: 8}
+	cases := map[string]struct {
+		retry  Retry
+		expect Retry
+	}{
+		"max attempts only": {
+			retry:  Retry{MaxAttempts: 5},
+			expect: Retry{Mode: aws.RetryModeAdaptive, MaxAttempts: 5},
+		},
+		"max backoff only": {
+			retry:  Retry{MaxBackoff: time.Second},
+			expect: Retry{Mode: aws.RetryModeAdaptive, MaxAttempts: 8, MaxBackoff: time.Second},
+		},
+		"mode only": {
+			retry:  Retry{Mode: aws.RetryModeStandard},
+			expect: Retry{Mode: aws.RetryModeStandard, MaxAttempts: 8},
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.retry.withDefaults(cfg))
+		})
+	}
+}
+
+func TestConfigRetryKeepsSharedConfig(t *testing.T) {
+	t.Setenv("AWS_RETRY_MODE", "adaptive")
+	t.Setenv("AWS_MAX_ATTEMPTS", "6")
+
+	cfg, err := GetAWSConfig(context.TODO(), "eu-south-1", "", nil, func(o *Options) {
+		o.Retry = Retry{MaxBackoff: time.Second}
+	})
+
+	require.NoError(t, err)
+	r := cfg.Retryer()
+	assert.IsType(t, &retry.AdaptiveMode{}, r)
+	assert.Equal(t, 6, r.MaxAttempts())
+}
+
+func TestRetryAttemptsInError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>1</RequestId></ErrorResponse>`))
+	}))
+	defer server.Close()
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	cfg, err := GetAWSConfig(context.TODO(), "eu-central-1", "", nil, func(o *Options) {
+		o.EndpointURL = server.URL
+		o.Retry = Retry{MaxAttempts: 3, MaxBackoff: time.Millisecond}
+	})
+	require.NoError(t, err)
+	_, err = sqs.NewFromConfig(cfg).GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("queue")})
+
+	require.Error(t, err)
+	assert.EqualValues(t, 3, calls)
+	var attemptsErr interface{ Attempts() int }
+	require.ErrorAs(t, err, &attemptsErr)
+	assert.Equal(t, 3, attemptsErr.Attempts())
+	var maxAttemptsErr *retry.MaxAttemptsError
+	assert.ErrorAs(t, err, &maxAttemptsErr)
+}
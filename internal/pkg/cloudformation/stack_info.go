@@ -22,8 +22,8 @@ type DescribeStacksAPI interface {
 	DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
 }
 
-func GetPhysicalId(stackName string, logicalID string, api DescribeStackResourceAPI) (string, error) {
-	output, err := api.DescribeStackResource(context.TODO(), &cloudformation.DescribeStackResourceInput{
+func GetPhysicalId(ctx context.Context, stackName string, logicalID string, api DescribeStackResourceAPI) (string, error) {
+	output, err := api.DescribeStackResource(ctx, &cloudformation.DescribeStackResourceInput{
 		LogicalResourceId: aws.String(logicalID),
 		StackName:         aws.String(stackName),
 	})
@@ -35,7 +35,7 @@ func GetPhysicalId(stackName string, logicalID string, api DescribeStackResource
 	return aws.ToString(output.StackResourceDetail.PhysicalResourceId), nil
 }
 
-func GetStackOuput(stackName string, outputKeys []string, api DescribeStacksAPI) (map[string]string, error) {
+func GetStackOuput(ctx context.Context, stackName string, outputKeys []string, api DescribeStacksAPI) (map[string]string, error) {
 	distinct := slice.Dedup(outputKeys)
 	p := cloudformation.NewDescribeStacksPaginator(api, &cloudformation.DescribeStacksInput{
 		StackName: aws.String(stackName),
@@ -44,7 +44,7 @@ func GetStackOuput(stackName string, outputKeys []string, api DescribeStacksAPI)
 	m := make(map[string]string)
 
 	for p.HasMorePages() {
-		resp, err := p.NextPage(context.TODO())
+		resp, err := p.NextPage(ctx)
 
 		if err != nil {
 			return nil, err
@@ -66,7 +66,7 @@ func TestGetPhysicalId(t *testing.T) {
 
 	for i, tt := range cases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			id, err := GetPhysicalId(tt.stackName, tt.logicalID, tt.client(t))
+			id, err := GetPhysicalId(context.TODO(), tt.stackName, tt.logicalID, tt.client(t))
 
 			assert.Nil(t, err, "Expected err to be nil, got %v", err)
 			assert.Equal(t, tt.expect, id)
@@ -109,7 +109,7 @@ func TestErrGetPhysicalId(t *testing.T) {
 
 	for i, tt := range cases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			_, err := GetPhysicalId(tt.stackName, tt.logicalID, tt.client(t))
+			_, err := GetPhysicalId(context.TODO(), tt.stackName, tt.logicalID, tt.client(t))
 
 			assert.Equal(t, tt.expect, err.Error())
 		})
@@ -232,7 +232,7 @@ func TestGetStackOutput(t *testing.T) {
 
 	for name, tt := range cases {
 		t.Run(name, func(t *testing.T) {
-			r, _ := GetStackOuput(tt.stackName, tt.outputKeys, tt.client(t))
+			r, _ := GetStackOuput(context.TODO(), tt.stackName, tt.outputKeys, tt.client(t))
 
 			assert.Equal(t, tt.expect, r)
 		})
@@ -299,7 +299,7 @@ func TestErrGetStackOutput(t *testing.T) {
 
 	for name, tt := range cases {
 		t.Run(name, func(t *testing.T) {
-			_, err := GetStackOuput(tt.stackName, tt.outputKeys, tt.client(t))
+			_, err := GetStackOuput(context.TODO(), tt.stackName, tt.outputKeys, tt.client(t))
 
 			assert.Equal(t, tt.expect, err.Error())
 		})
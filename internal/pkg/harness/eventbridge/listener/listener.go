@@ -8,11 +8,21 @@ import (
 	"errors"
 	"fmt"
 	"iatk/internal/pkg/harness"
+	"iatk/internal/pkg/harness/eventbridge/pattern"
 	"iatk/internal/pkg/harness/resource/eventrule"
+	"iatk/internal/pkg/harness/tags"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/parallel"
+	"iatk/internal/pkg/progress"
 	"iatk/internal/pkg/slice"
-	"log"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/rs/xid"
 )
 
@@ -21,29 +31,49 @@ const (
 	IDPrefix        = "iatk_eb_"
 )
 
-// Creates a Listener for an event bus resource
-func New(ctx context.Context, eventBusName, targetId, ruleName string, tags map[string]string, opts Options) (*Listener, error) {
+// Creates a Listener for an event bus resource. Its event pattern is eventPattern if given, else the one of
+// the rule of ruleName, AND-merged with the patternRefinements. If ruleName is empty, the Listener does not
+// copy any rule and eventPattern is required. A positive ttl is recorded as a tag, for gc to destroy the
+// Listener once it has expired.
+func New(ctx context.Context, eventBusName, targetId, ruleName, eventPattern string, patternRefinements []string, tags map[string]string, ttl time.Duration, opts Options) (*Listener, error) {
 	// validate if the event bus exists
 	eb, err := opts.getEventBus(ctx, opts.ebClient, eventBusName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource group: %v", err)
+		return nil, fmt.Errorf("failed to create resource group: %w", err)
 	}
 
-	r, err := opts.getRule(ctx, opts.ebClient, ruleName, eventBusName)
-	if err != nil {
-		return nil, fmt.Errorf("RuleName %q was provided but not found for eventbus %q failed: %v", ruleName, eventBusName, err)
+	var target *ebtypes.Target
+	if ruleName != "" {
+		r, err := opts.getRule(ctx, opts.ebClient, ruleName, eventBusName)
+		if err != nil {
+			return nil, fmt.Errorf("RuleName %q was provided but not found for eventbus %q failed: %w", ruleName, eventBusName, err)
+		}
+		if eventPattern == "" {
+			eventPattern = r.EventPattern
+		}
+
+		target, err = opts.listTargetsByRule(ctx, opts.ebClient, targetId, ruleName, eventBusName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resource group: %w", err)
+		}
+	}
+	if eventPattern == "" {
+		return nil, errors.New("an event pattern is required if no rule is given")
 	}
 
-	target, err := opts.listTargetsByRule(ctx, opts.ebClient, targetId, ruleName, eventBusName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create resource group: %v", err)
+	if len(patternRefinements) > 0 {
+		eventPattern, err = eventrule.MergeEventPatterns(append([]string{eventPattern}, patternRefinements...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refine event pattern: %w", err)
+		}
 	}
 
 	return &Listener{
 		id:           xid.New().String(),
-		eventPattern: r.EventPattern,
+		eventPattern: eventPattern,
 		target:       target,
 		customTags:   tags,
+		ttl:          ttl,
 		eventBus:     eb,
 		opts:         opts,
 	}, nil
@@ -65,25 +95,44 @@ func isValidID(id string) bool {
 	return true
 }
 
-// Gets an existing Listener
+// NotFoundError tells that a Listener does not exist, e.g. as it was destroyed already.
+type NotFoundError struct {
+	ID  string
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Gets an existing Listener. The error wraps a NotFoundError if it does not exist.
 func Get(ctx context.Context, id string, opts Options) (*Listener, error) {
 	// validate if a resource group is up already
 	if !isValidID(id) {
 		return nil, errors.New("invalid ID")
 	}
 	suffix := id[len(IDPrefix):]
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, id)
 
 	qname := queueName(id)
 	q, err := opts.getQueueWithName(ctx, opts.sqsClient, qname)
 	if err != nil {
-		log.Printf("cannot locate queue %q of eb listener %q: %v", qname, id, err)
+		logger.Warn("cannot locate queue of eb listener", "queue_name", qname, "error", err)
+		var errNoQueue *sqstypes.QueueDoesNotExist
+		if errors.As(err, &errNoQueue) {
+			err = &NotFoundError{ID: id, Err: err}
+		}
 		return nil, fmt.Errorf("faied to get eb listener %v: %w", id, err)
 	}
-	log.Printf("found queue %q", q.ARN)
+	logger.Debug("found queue", "arn", q.ARN.String())
 
 	eventBusName, err := opts.getEventBusNameFromQueue(ctx, opts.sqsClient, q.QueueURL)
 	if err != nil {
-		log.Printf("unable to find event bus name of listener %v: %v", id, err)
+		logger.Warn("unable to find event bus name of eb listener", "error", err)
 	}
 
 	var r *eventrule.Rule
@@ -91,10 +140,9 @@ func Get(ctx context.Context, id string, opts Options) (*Listener, error) {
 		rname := ruleName(id)
 		r, err = opts.getRule(ctx, opts.ebClient, rname, eventBusName)
 		if err != nil {
-			log.Printf("cannot locate rule %q of eb listener %q: %v", rname, id, err)
-			log.Printf("skipping destroy rule %q", rname)
+			logger.Warn("cannot locate rule of eb listener, skipping destroy rule", "rule_name", rname, "error", err)
 		} else {
-			log.Printf("found rule %q", r.ARN)
+			logger.Debug("found rule", "arn", r.ARN.String())
 		}
 	}
 
@@ -106,6 +154,90 @@ func Get(ctx context.Context, id string, opts Options) (*Listener, error) {
 	}, nil
 }
 
+// Recover gets the Listener of id from the ARNs of its resources, as found through their tags, see
+// tags.GetTestHarnesses. Unlike Get, it finds the rule of a Listener whose queue is gone. Resources that
+// no longer exist are left out. The error wraps a NotFoundError if none exists.
+func Recover(ctx context.Context, id string, resourceARNs []string, opts Options) (*Listener, error) {
+	if !isValidID(id) {
+		return nil, errors.New("invalid ID")
+	}
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, id)
+
+	lr := &Listener{
+		id:   id[len(IDPrefix):],
+		opts: opts,
+	}
+	for _, s := range resourceARNs {
+		a, err := arn.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover eb listener %v: %w", id, err)
+		}
+		switch {
+		case a.Service == "sqs":
+			q, err := opts.getQueueWithName(ctx, opts.sqsClient, a.Resource)
+			var errNoQueue *sqstypes.QueueDoesNotExist
+			if errors.As(err, &errNoQueue) {
+				logger.Debug("queue of eb listener already deleted", "arn", s)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover eb listener %v: %w", id, err)
+			}
+			lr.queue = q
+		case a.Service == "events" && strings.HasPrefix(a.Resource, "rule/"):
+			// rule/<rule name> on the default event bus, rule/<event bus name>/<rule name> otherwise
+			eventBusName, rname := "default", strings.TrimPrefix(a.Resource, "rule/")
+			if i := strings.LastIndex(rname, "/"); i >= 0 {
+				eventBusName, rname = rname[:i], rname[i+1:]
+			}
+			r, err := opts.getRule(ctx, opts.ebClient, rname, eventBusName)
+			var errNoRule *ebtypes.ResourceNotFoundException
+			if errors.As(err, &errNoRule) {
+				logger.Debug("rule of eb listener already deleted", "arn", s)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover eb listener %v: %w", id, err)
+			}
+			lr.rule = r
+		default:
+			logger.Warn("skipping unknown resource of eb listener", "arn", s)
+		}
+	}
+	if lr.queue == nil && lr.rule == nil {
+		return nil, fmt.Errorf("failed to recover eb listener %v: %w", id, &NotFoundError{
+			ID:  id,
+			Err: errors.New("no resource left"),
+		})
+	}
+	return lr, nil
+}
+
+// GetOrRecover returns a GetFunc that gets a Listener like Get and, if its queue is gone, recovers it
+// with Recover from the resources tagged with its ID, so that a rule left behind is still found.
+func GetOrRecover(api tags.GetResourcesAPI) GetFunc {
+	return func(ctx context.Context, id string, opts Options) (*Listener, error) {
+		lr, err := Get(ctx, id, opts)
+		var errNotFound *NotFoundError
+		if !errors.As(err, &errNotFound) {
+			return lr, err
+		}
+		harnesses, err := tags.GetTestHarnesses(ctx, api, []tagtypes.TagFilter{
+			{Key: aws.String(string(tags.TestHarnessID)), Values: []string{id}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover eb listener %v: %w", id, err)
+		}
+		var resourceARNs []string
+		for _, h := range harnesses {
+			if h.ID == id {
+				resourceARNs = h.ResourceARNs
+			}
+		}
+		return Recover(ctx, id, resourceARNs, opts)
+	}
+}
+
 func queueName(listenerID string) string {
 	return listenerID // https://aws.amazon.com/sqs/faqs/#Limits_and_restrictions
 }
@@ -116,72 +248,140 @@ func ruleName(listenerID string) string {
 
 // Create deploys a Listener and rollback if failed
 func Create(ctx context.Context, lr deployer) (*Output, error) {
-	log.Printf("creating eb listener %v", lr.ID())
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, lr.ID())
+	logger.Info("creating eb listener")
 	errDeploy := lr.Deploy(ctx)
 	if errDeploy != nil {
-		log.Printf("create failed: %v", errDeploy)
-		log.Printf("rolling back")
+		logger.Error("create failed, rolling back", "error", errDeploy)
 		if err := lr.Destroy(ctx); err != nil {
-			log.Printf("rollback failed: %v", err)
-			log.Printf(`please manually delete following resources: %v`, arns(lr.Components()))
+			logger.Error("rollback failed, please manually delete the remaining resources", "error", err, "arns", arns(lr.Components()))
 		}
 		return nil, fmt.Errorf("failed to create eb listener %v: %w", lr.ID(), errDeploy)
 	}
-	log.Printf("created eb listener %v", lr.ID())
+	logger.Info("created eb listener")
+	progress.Report(ctx, "eb listener created", map[string]interface{}{"listener_id": lr.ID()})
 	out := lr.JSON()
 	return &out, nil
 }
 
-func DestroyMultiple(ctx context.Context, ids []string, cfg aws.Config, opts destroyMultipleOptions) error {
-	distincts := slice.Dedup(ids)
-	errs := []errDestroySingle{}
+// DestroyStatus tells what happened to a Listener given to DestroyMultiple.
+type DestroyStatus string
 
-	// TODO(hawflau): use goroutines
-	log.Printf("collecting eb listeners from provided ids: %v", ids)
-	listeners := []*Listener{}
-	for _, id := range distincts {
-		lr, err := opts.Get(ctx, id, NewOptions(cfg))
-		if err != nil {
-			// TODO (hawflau): potentially an option to fail here if any Resource Group ID is not valid/supported.
-			log.Print(err.Error())
-			errs = append(errs, errDestroySingle{id, err})
-		} else {
-			listeners = append(listeners, lr)
-		}
-	}
+const (
+	// StatusDestroyed is the status of Listeners destroyed.
+	StatusDestroyed DestroyStatus = "Destroyed"
+	// StatusAlreadyAbsent is the status of Listeners that did not exist, e.g. as they were destroyed already.
+	StatusAlreadyAbsent DestroyStatus = "AlreadyAbsent"
+	// StatusFailed is the status of Listeners that could not be got or destroyed.
+	StatusFailed DestroyStatus = "Failed"
+	// StatusWouldDestroy is the status of Listeners found on a dry run, that would be destroyed otherwise.
+	StatusWouldDestroy DestroyStatus = "WouldDestroy"
+)
 
-	for _, lr := range listeners {
-		err := opts.destroySingle(ctx, lr)
-		if err != nil {
-			log.Print(err.Error())
-			errs = append(errs, errDestroySingle{lr.ID(), err})
+// DestroyResult is the outcome of destroying one of the Listeners given to DestroyMultiple.
+type DestroyResult struct {
+	ID     string
+	Status DestroyStatus
+	// Err is the reason the Listener could not be got or destroyed, if it failed.
+	Err error
+	// Leftovers are the components of the Listener that were not destroyed, if it failed.
+	Leftovers []harness.Resource
+	// Components are the components of the Listener that would be destroyed, on a dry run.
+	Components []harness.Resource
+}
+
+// DestroyReport holds a DestroyResult per distinct ID given to DestroyMultiple, in the order the IDs
+// were given.
+type DestroyReport []DestroyResult
+
+// Err returns an error listing the Listeners that could not be destroyed, or nil if none failed.
+func (r DestroyReport) Err() error {
+	var reasons []string
+	for _, result := range r {
+		if result.Status == StatusFailed {
+			reasons = append(reasons, errDestroySingle{result.ID, result.Err}.String())
 		}
 	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to destroy following listener(s): %v", strings.Join(reasons, ", "))
+}
 
-	if len(errs) > 0 {
-		var reasons string
-		for i, e := range errs {
-			reasons += fmt.Sprint(e.String())
-			if i != len(errs)-1 {
-				reasons += ", "
-			}
+// JSON returns the outcome of each Listener of r, as returned by RPC methods.
+func (r DestroyReport) JSON() []DestroyOutput {
+	out := make([]DestroyOutput, 0, len(r))
+	for _, result := range r {
+		o := DestroyOutput{
+			ID:     result.ID,
+			Status: string(result.Status),
 		}
-		return fmt.Errorf("failed to destroy following listener(s): %v", reasons)
+		if len(result.Leftovers) > 0 {
+			o.LeftoverARNs = arns(result.Leftovers)
+		}
+		if len(result.Components) > 0 {
+			o.ComponentARNs = arns(result.Components)
+		}
+		if result.Err != nil {
+			o.Error = result.Err.Error()
+		}
+		out = append(out, o)
 	}
+	return out
+}
 
-	return nil
+// DestroyMultiple gets and destroys the Listeners of ids, up to opts.maxConcurrency at once. It returns
+// the outcome for each of them, along with the error of the report if any failed. On a dry run, with
+// opts.DryRun, the Listeners are got but not destroyed.
+func DestroyMultiple(ctx context.Context, ids []string, cfg aws.Config, opts destroyMultipleOptions) (DestroyReport, error) {
+	distincts := slice.Dedup(ids)
+	logging.FromContext(ctx).Info("destroying eb listeners from provided ids", "ids", ids, "max_concurrency", opts.maxConcurrency, "dry_run", opts.DryRun)
+
+	listenerOpts := NewOptions(cfg)
+	report := make(DestroyReport, len(distincts))
+	parallel.ForEach(ctx, len(distincts), opts.maxConcurrency, func(i int) {
+		report[i] = destroyOne(ctx, distincts[i], listenerOpts, opts)
+	}, func(i int, err error) {
+		report[i] = DestroyResult{ID: distincts[i], Status: StatusFailed, Err: err}
+	})
 
+	return report, report.Err()
+}
+
+// destroyOne gets and destroys the Listener of id.
+func destroyOne(ctx context.Context, id string, listenerOpts Options, opts destroyMultipleOptions) DestroyResult {
+	lr, err := opts.Get(ctx, id, listenerOpts)
+	var errNotFound *NotFoundError
+	if errors.As(err, &errNotFound) {
+		logging.FromContext(ctx).Info("eb listener already absent", logging.ListenerIDKey, id)
+		return DestroyResult{ID: id, Status: StatusAlreadyAbsent}
+	}
+	if err != nil {
+		// TODO (hawflau): potentially an option to fail here if any Resource Group ID is not valid/supported.
+		logging.FromContext(ctx).Warn("cannot get eb listener", logging.ListenerIDKey, id, "error", err)
+		return DestroyResult{ID: id, Status: StatusFailed, Err: err}
+	}
+	if opts.DryRun {
+		logging.FromContext(ctx).Info("dry run, not destroying eb listener", logging.ListenerIDKey, id, "arns", arns(lr.Components()))
+		return DestroyResult{ID: id, Status: StatusWouldDestroy, Components: lr.Components()}
+	}
+	if err := opts.destroySingle(ctx, lr); err != nil {
+		logging.FromContext(ctx).Warn("cannot destroy eb listener", logging.ListenerIDKey, id, "error", err)
+		return DestroyResult{ID: id, Status: StatusFailed, Err: err, Leftovers: lr.Components()}
+	}
+	return DestroyResult{ID: id, Status: StatusDestroyed}
 }
 
 func destroySingle(ctx context.Context, lr destroyer) error {
-	log.Printf("destroying eb listener %q", lr.ID())
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, lr.ID())
+	logger.Info("destroying eb listener")
 
 	if err := lr.Destroy(ctx); err != nil {
-		log.Printf("destroy failed: %v", err)
-		log.Printf("please manually delete following resources: %v", arns(lr.Components()))
+		logger.Error("destroy failed, please manually delete the remaining resources", "error", err, "arns", arns(lr.Components()))
 		return fmt.Errorf("failed to destroy eb listener %q: %w", lr.ID(), err)
 	}
-	log.Printf("destroy success for eb listener %q", lr.ID())
+	logger.Info("destroyed eb listener")
+	progress.Report(ctx, "eb listener destroyed", map[string]interface{}{"listener_id": lr.ID()})
 	return nil
 }
 
@@ -194,7 +394,10 @@ func arns(resources []harness.Resource) []string {
 }
 
 type destroyMultipleOptions struct {
+	// maxConcurrency bounds the number of Listeners got and destroyed at once.
 	maxConcurrency int
+	// DryRun only gets the Listeners, to report what would be destroyed.
+	DryRun bool
 
 	// funcs
 	destroySingle destroySingleFunc
@@ -242,19 +445,40 @@ type destroySingleFunc func(ctx context.Context, lr destroyer) error
 type poller interface {
 	ReceiveEvents(ctx context.Context, waitTimeSeconds, maxNumberOfMessages int32) ([]Event, error)
 	DeleteEvents(ctx context.Context, receiptHandles []string) error
+	ReleaseEvents(ctx context.Context, receiptHandles []string) error
 }
 
-func PollEvents(ctx context.Context, lr poller, waitTimeSeconds, maxNumberOfMessages int32) ([]string, error) {
+// PollEvents receives events from the queue of lr, and returns and deletes those matching p, all of them
+// if p is nil. The events not matching p are left on the queue, for later polls with other patterns to
+// receive them.
+func PollEvents(ctx context.Context, lr poller, waitTimeSeconds, maxNumberOfMessages int32, p *pattern.Pattern) ([]string, error) {
 	events, err := lr.ReceiveEvents(ctx, waitTimeSeconds, maxNumberOfMessages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to poll events: %w", err)
 	}
 	handles := make([]string, 0, len(events))
+	unmatched := []string{}
 	ret := make([]string, 0, len(events))
 	for _, e := range events {
+		if p != nil {
+			match, err := p.Match(e.Body)
+			if err != nil {
+				logging.FromContext(ctx).Warn("cannot match event", "error", err)
+			}
+			if !match {
+				unmatched = append(unmatched, e.ReceiptHandle)
+				continue
+			}
+		}
 		handles = append(handles, e.ReceiptHandle)
 		ret = append(ret, e.Body)
 	}
+	if len(unmatched) > 0 {
+		// otherwise they become visible again once their visibility timeout expires
+		if err := lr.ReleaseEvents(ctx, unmatched); err != nil {
+			logging.FromContext(ctx).Warn("cannot release unmatched events", "error", err)
+		}
+	}
 	if len(handles) > 0 {
 		err = lr.DeleteEvents(ctx, handles)
 		if err != nil {
@@ -6,18 +6,26 @@ package listener
 import (
 	"context"
 	"errors"
+	"fmt"
 	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/harness"
+	"iatk/internal/pkg/harness/eventbridge/pattern"
 	"iatk/internal/pkg/harness/resource/eventbus"
 	"iatk/internal/pkg/harness/resource/eventrule"
 	"iatk/internal/pkg/harness/resource/queue"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -61,6 +69,32 @@ func testRuleARN() arn.ARN {
 }
 
 func TestNew(t *testing.T) {
+	foundEventBus := func(ctx context.Context, ebClient ebClient, eventBusName string, arn arn.ARN) *mockGetEventBusFunc {
+		mock := newMockGetEventBusFunc(t)
+		mock.EXPECT().Execute(ctx, ebClient, eventBusName).Return(&eventbus.EventBus{Name: eventBusName, ARN: arn}, nil)
+		return mock
+	}
+	foundRule := func(ctx context.Context, ebClient ebClient, ruleName, eventBusName string, ruleARN arn.ARN) *mockGetRuleFunc {
+		mock := newMockGetRuleFunc(t)
+		mock.EXPECT().Execute(ctx, ebClient, ruleName, eventBusName).Return(&eventrule.Rule{
+			Name:         ruleName,
+			EventBusName: eventBusName,
+			EventPattern: `{"source":["my.app"]}`,
+			ARN:          ruleARN,
+		}, nil)
+		return mock
+	}
+	foundTarget := func(ctx context.Context, ebClient ebClient, targetId, ruleName, eventBusName string) *mockListTargetsByRuleFunc {
+		mock := newMockListTargetsByRuleFunc(t)
+		mock.EXPECT().Execute(ctx, ebClient, targetId, ruleName, eventBusName).Return(&ebtypes.Target{Input: aws.String("input")}, nil)
+		return mock
+	}
+	noRule := func(ctx context.Context, ebClient ebClient, ruleName, eventBusName string, ruleARN arn.ARN) *mockGetRuleFunc {
+		return newMockGetRuleFunc(t)
+	}
+	noTarget := func(ctx context.Context, ebClient ebClient, targetId, ruleName, eventBusName string) *mockListTargetsByRuleFunc {
+		return newMockListTargetsByRuleFunc(t)
+	}
 	cases := map[string]struct {
 		mockGetEventBus       func(ctx context.Context, ebClient ebClient, eventBusName string, arn arn.ARN) *mockGetEventBusFunc
 		mockGetRule           func(ctx context.Context, ebClient ebClient, ruleName string, eventBusName string, ruleARN arn.ARN) *mockGetRuleFunc
@@ -69,7 +103,11 @@ func TestNew(t *testing.T) {
 		arn                   arn.ARN
 		ruleName              string
 		ruleARN               arn.ARN
+		eventPattern          string
+		patternRefinements    []string
 		tags                  map[string]string
+		expectEventPattern    string
+		expectTarget          bool
 		expectErr             error
 	}{
 		"success": {
@@ -94,6 +132,7 @@ func TestNew(t *testing.T) {
 				mock.EXPECT().Execute(ctx, ebClient, ruleName, eventBusName).Return(&eventrule.Rule{
 					Name:         ruleName,
 					EventBusName: eventBusName,
+					EventPattern: `{"source":["my.app"]}`,
 					ARN:          ruleARN,
 				}, nil)
 				return mock
@@ -107,6 +146,61 @@ func TestNew(t *testing.T) {
 					}, nil)
 				return mock
 			},
+			expectEventPattern: `{"source":["my.app"]}`,
+			expectTarget:       true,
+		},
+		"event pattern overrides the one of the rule": {
+			eventBusName:          testBusName,
+			arn:                   testBusARN(),
+			ruleName:              "InputRuleName",
+			ruleARN:               testRuleARN(),
+			eventPattern:          `{"source":["other.app"]}`,
+			mockGetEventBus:       foundEventBus,
+			mockGetRule:           foundRule,
+			mockListTargetsByRule: foundTarget,
+			expectEventPattern:    `{"source":["other.app"]}`,
+			expectTarget:          true,
+		},
+		"pattern refinements are merged with the one of the rule": {
+			eventBusName:          testBusName,
+			arn:                   testBusARN(),
+			ruleName:              "InputRuleName",
+			ruleARN:               testRuleARN(),
+			patternRefinements:    []string{`{"detail-type":["OrderPlaced"]}`},
+			mockGetEventBus:       foundEventBus,
+			mockGetRule:           foundRule,
+			mockListTargetsByRule: foundTarget,
+			expectEventPattern:    `{"detail-type":["OrderPlaced"],"source":["my.app"]}`,
+			expectTarget:          true,
+		},
+		"without rule": {
+			eventBusName:          testBusName,
+			arn:                   testBusARN(),
+			eventPattern:          `{"source":["my.app"]}`,
+			patternRefinements:    []string{`{"detail-type":["OrderPlaced"]}`},
+			mockGetEventBus:       foundEventBus,
+			mockGetRule:           noRule,
+			mockListTargetsByRule: noTarget,
+			expectEventPattern:    `{"detail-type":["OrderPlaced"],"source":["my.app"]}`,
+		},
+		"without rule nor event pattern": {
+			eventBusName:          testBusName,
+			arn:                   testBusARN(),
+			mockGetEventBus:       foundEventBus,
+			mockGetRule:           noRule,
+			mockListTargetsByRule: noTarget,
+			expectErr:             errors.New("an event pattern is required if no rule is given"),
+		},
+		"pattern refinements conflict": {
+			eventBusName:          testBusName,
+			arn:                   testBusARN(),
+			ruleName:              "InputRuleName",
+			ruleARN:               testRuleARN(),
+			patternRefinements:    []string{`{"source":["other.app"]}`},
+			mockGetEventBus:       foundEventBus,
+			mockGetRule:           foundRule,
+			mockListTargetsByRule: foundTarget,
+			expectErr:             errors.New(`failed to refine event pattern: cannot merge event patterns at "source": no value in common`),
 		},
 		"eventbus not found": {
 			eventBusName: testBusName,
@@ -205,12 +299,15 @@ func TestNew(t *testing.T) {
 				Name: tt.eventBusName,
 				ARN:  tt.arn,
 			}
-			listener, err := New(ctx, tt.eventBusName, "", tt.ruleName, tt.tags, opts)
+			listener, err := New(ctx, tt.eventBusName, "", tt.ruleName, tt.eventPattern, tt.patternRefinements, tt.tags, time.Hour, opts)
 			if tt.expectErr != nil {
 				assert.EqualError(t, err, tt.expectErr.Error())
 			} else {
 				assert.Equal(t, expectEventBus, listener.eventBus)
 				assert.Equal(t, tt.tags, listener.customTags)
+				assert.Equal(t, time.Hour, listener.ttl)
+				assert.JSONEq(t, tt.expectEventPattern, listener.eventPattern)
+				assert.Equal(t, tt.expectTarget, listener.target != nil)
 				assert.NotNil(t, listener.opts)
 			}
 		})
@@ -230,6 +327,7 @@ func TestGet(t *testing.T) {
 		eventBusName                 string
 		ruleARN                      arn.ARN
 		expectErr                    error
+		expectNotFound               bool
 	}{
 		"success": {
 			listenerID:   testListenerID,
@@ -302,6 +400,27 @@ func TestGet(t *testing.T) {
 				return mock
 			},
 		},
+		"failed as queue does not exist": {
+			listenerID:     testListenerID,
+			queueName:      testListenerID,
+			expectErr:      errors.New("faied to get eb listener iatk_eb_9m4e2mr0ui3e8a215n4g: AWS.SimpleQueueService.NonExistentQueue: no queue"),
+			expectNotFound: true,
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient, queueName, queueURL string, queueARN arn.ARN) *mockGetQueueWithNameFunc {
+				mock := newMockGetQueueWithNameFunc(t)
+				mock.EXPECT().
+					Execute(ctx, sqsClient, queueName).
+					Return(nil, &sqstypes.QueueDoesNotExist{Message: aws.String("no queue")})
+				return mock
+			},
+			mockGetEventBusNameFromQueue: func(ctx context.Context, sqsClient sqsClient, queueURL, eventBusName string) *mockGetEventBusNameFromQueueFunc {
+				mock := newMockGetEventBusNameFromQueueFunc(t)
+				return mock
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient, ruleName, eventBusName string, ruleARN arn.ARN) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				return mock
+			},
+		},
 		"cannot get event bus name from queue; succeed with nil rule": {
 			listenerID: testListenerID,
 			expectErr:  nil,
@@ -378,6 +497,8 @@ func TestGet(t *testing.T) {
 				getRule:                  mockGetRule.Execute,
 			}
 			lr, err := Get(ctx, tt.listenerID, opts)
+			var errNotFound *NotFoundError
+			assert.Equal(t, tt.expectNotFound, errors.As(err, &errNotFound))
 			if tt.expectErr != nil {
 				assert.EqualError(t, err, tt.expectErr.Error())
 			} else {
@@ -450,7 +571,11 @@ func TestDestroyMultiple(t *testing.T) {
 		mockDestroySingle func(ctx context.Context, lrs map[string]*Listener) *mockDestroySingleFunc
 		listeners         map[string]*Listener
 		listenerIDs       []string
+		dryRun            bool
 		expectErr         error
+		expectStatuses    map[string]DestroyStatus
+		expectLeftovers   map[string][]harness.Resource
+		expectComponents  map[string][]harness.Resource
 	}{
 		"success": {
 			listenerIDs: []string{
@@ -520,6 +645,11 @@ func TestDestroyMultiple(t *testing.T) {
 				"iatk_eb_listener-3": &Listener{},
 			},
 			expectErr: errors.New("failed to destroy following listener(s): {resource group id: iatk_eb_listener-2, reason: invalid ID}"),
+			expectStatuses: map[string]DestroyStatus{
+				"iatk_eb_listener-1": StatusDestroyed,
+				"iatk_eb_listener-2": StatusFailed,
+				"iatk_eb_listener-3": StatusDestroyed,
+			},
 			mockGet: func(ctx context.Context, lrs map[string]*Listener) *MockGetFunc {
 				f := NewMockGetFunc(t)
 				for id, lr := range lrs {
@@ -549,10 +679,19 @@ func TestDestroyMultiple(t *testing.T) {
 			},
 			listeners: map[string]*Listener{
 				"iatk_eb_listener-1": {id: "listener-1"},
-				"iatk_eb_listener-2": {id: "listener-2"},
+				"iatk_eb_listener-2": {id: "listener-2", queue: &queue.Queue{QueueURL: "https://sqs/queue", ARN: testQueueARN()}},
 				"iatk_eb_listener-3": {id: "listener-3"},
 			},
 			expectErr: errors.New("failed to destroy following listener(s): {resource group id: iatk_eb_listener-2, reason: api failed}, {resource group id: iatk_eb_listener-3, reason: api failed}"),
+			expectStatuses: map[string]DestroyStatus{
+				"iatk_eb_listener-1": StatusDestroyed,
+				"iatk_eb_listener-2": StatusFailed,
+				"iatk_eb_listener-3": StatusFailed,
+			},
+			expectLeftovers: map[string][]harness.Resource{
+				"iatk_eb_listener-2": {{Type: "AWS::SQS::Queue", PhysicalID: "https://sqs/queue", ARN: testQueueARN().String()}},
+				"iatk_eb_listener-3": {},
+			},
 			mockGet: func(ctx context.Context, lrs map[string]*Listener) *MockGetFunc {
 				f := NewMockGetFunc(t)
 				for id, lr := range lrs {
@@ -572,6 +711,63 @@ func TestDestroyMultiple(t *testing.T) {
 				return f
 			},
 		},
+		"one of provided listener is already absent": {
+			listenerIDs: []string{
+				"iatk_eb_listener-1",
+				"iatk_eb_listener-2",
+			},
+			listeners: map[string]*Listener{
+				"iatk_eb_listener-1": &Listener{},
+				"iatk_eb_listener-2": nil,
+			},
+			expectErr: nil,
+			expectStatuses: map[string]DestroyStatus{
+				"iatk_eb_listener-1": StatusDestroyed,
+				"iatk_eb_listener-2": StatusAlreadyAbsent,
+			},
+			mockGet: func(ctx context.Context, lrs map[string]*Listener) *MockGetFunc {
+				f := NewMockGetFunc(t)
+				f.EXPECT().Execute(ctx, "iatk_eb_listener-1", mock.AnythingOfType("Options")).Return(lrs["iatk_eb_listener-1"], nil)
+				f.EXPECT().Execute(ctx, "iatk_eb_listener-2", mock.AnythingOfType("Options")).Return(nil, fmt.Errorf("faied to get eb listener iatk_eb_listener-2: %w", &NotFoundError{
+					ID:  "iatk_eb_listener-2",
+					Err: &sqstypes.QueueDoesNotExist{},
+				}))
+				return f
+			},
+			mockDestroySingle: func(ctx context.Context, lrs map[string]*Listener) *mockDestroySingleFunc {
+				f := newMockDestroySingleFunc(t)
+				f.EXPECT().Execute(ctx, lrs["iatk_eb_listener-1"]).Return(nil)
+				return f
+			},
+		},
+		"dry run does not destroy": {
+			listenerIDs: []string{
+				"iatk_eb_listener-1",
+				"iatk_eb_listener-2",
+			},
+			listeners: map[string]*Listener{
+				"iatk_eb_listener-1": {id: "listener-1", queue: &queue.Queue{QueueURL: "https://sqs/queue", ARN: testQueueARN()}},
+				"iatk_eb_listener-2": nil,
+			},
+			dryRun:    true,
+			expectErr: errors.New("failed to destroy following listener(s): {resource group id: iatk_eb_listener-2, reason: invalid ID}"),
+			expectStatuses: map[string]DestroyStatus{
+				"iatk_eb_listener-1": StatusWouldDestroy,
+				"iatk_eb_listener-2": StatusFailed,
+			},
+			expectComponents: map[string][]harness.Resource{
+				"iatk_eb_listener-1": {{Type: "AWS::SQS::Queue", PhysicalID: "https://sqs/queue", ARN: testQueueARN().String()}},
+			},
+			mockGet: func(ctx context.Context, lrs map[string]*Listener) *MockGetFunc {
+				f := NewMockGetFunc(t)
+				f.EXPECT().Execute(ctx, "iatk_eb_listener-1", mock.AnythingOfType("Options")).Return(lrs["iatk_eb_listener-1"], nil)
+				f.EXPECT().Execute(ctx, "iatk_eb_listener-2", mock.AnythingOfType("Options")).Return(nil, errors.New("invalid ID"))
+				return f
+			},
+			mockDestroySingle: func(ctx context.Context, lrs map[string]*Listener) *mockDestroySingleFunc {
+				return newMockDestroySingleFunc(t)
+			},
+		},
 	}
 
 	for name, tt := range cases {
@@ -580,6 +776,7 @@ func TestDestroyMultiple(t *testing.T) {
 			mockGet := tt.mockGet(ctx, tt.listeners)
 			mockDestroySingle := tt.mockDestroySingle(ctx, tt.listeners)
 			opts := destroyMultipleOptions{
+				DryRun:        tt.dryRun,
 				destroySingle: mockDestroySingle.Execute,
 				Get:           mockGet.Execute,
 			}
@@ -587,17 +784,135 @@ func TestDestroyMultiple(t *testing.T) {
 			if err != nil {
 				t.Fatalf("error when loading AWS config: %v", err)
 			}
-			err = DestroyMultiple(ctx, tt.listenerIDs, cfg, opts)
+			report, err := DestroyMultiple(ctx, tt.listenerIDs, cfg, opts)
 			if tt.expectErr != nil {
 				assert.EqualError(t, err, tt.expectErr.Error())
 			} else {
 				assert.Nil(t, err)
 			}
+			// one result per distinct id, in order
+			require.Len(t, report, len(tt.listeners))
+			for i, result := range report {
+				if i > 0 {
+					assert.Less(t, report[i-1].ID, result.ID)
+				}
+				assert.Contains(t, tt.listeners, result.ID)
+				expectStatus, ok := tt.expectStatuses[result.ID]
+				if !ok {
+					expectStatus = StatusDestroyed
+				}
+				assert.Equal(t, expectStatus, result.Status, result.ID)
+				if expectStatus == StatusFailed {
+					assert.Error(t, result.Err, result.ID)
+				} else {
+					assert.NoError(t, result.Err, result.ID)
+				}
+				assert.Equal(t, tt.expectLeftovers[result.ID], result.Leftovers, result.ID)
+				assert.Equal(t, tt.expectComponents[result.ID], result.Components, result.ID)
+			}
 		})
 	}
 
 }
 
+func TestDestroyMultipleConcurrency(t *testing.T) {
+	var ids []string
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprintf("iatk_eb_listener-%02d", i))
+	}
+	var running, maxRunning int32
+	opts := destroyMultipleOptions{
+		maxConcurrency: 4,
+		Get: func(ctx context.Context, id string, opts Options) (*Listener, error) {
+			if id == "iatk_eb_listener-07" {
+				return nil, errors.New("not found")
+			}
+			return &Listener{id: id[len(IDPrefix):]}, nil
+		},
+		destroySingle: func(ctx context.Context, lr destroyer) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if lr.ID() == "iatk_eb_listener-13" {
+				return errors.New("api failed")
+			}
+			return nil
+		},
+	}
+	cfg, err := config.GetAWSConfig(context.TODO(), "us-west-2", "default", nil)
+	require.NoError(t, err)
+
+	report, err := DestroyMultiple(context.TODO(), ids, cfg, opts)
+
+	assert.EqualError(t, err, "failed to destroy following listener(s): {resource group id: iatk_eb_listener-07, reason: not found}, "+
+		"{resource group id: iatk_eb_listener-13, reason: api failed}")
+	require.Len(t, report, len(ids))
+	for i, result := range report {
+		assert.Equal(t, ids[i], result.ID)
+		assert.Equal(t, i == 7 || i == 13, result.Err != nil, result.ID)
+	}
+	assert.LessOrEqual(t, maxRunning, int32(4))
+	assert.Greater(t, maxRunning, int32(1))
+}
+
+func TestDestroyMultipleCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := destroyMultipleOptions{
+		maxConcurrency: 1,
+		Get: func(ctx context.Context, id string, opts Options) (*Listener, error) {
+			cancel()
+			return &Listener{id: id[len(IDPrefix):]}, nil
+		},
+		destroySingle: func(ctx context.Context, lr destroyer) error {
+			return nil
+		},
+	}
+	cfg, err := config.GetAWSConfig(context.TODO(), "us-west-2", "default", nil)
+	require.NoError(t, err)
+
+	report, err := DestroyMultiple(ctx, []string{"iatk_eb_listener-1", "iatk_eb_listener-2"}, cfg, opts)
+
+	require.Error(t, err)
+	require.Len(t, report, 2)
+	// the first listener was destroyed, the second not attempted
+	assert.NoError(t, report[0].Err)
+	assert.ErrorIs(t, report[1].Err, context.Canceled)
+}
+
+func TestDestroyMultiplePanic(t *testing.T) {
+	opts := destroyMultipleOptions{
+		maxConcurrency: 2,
+		Get: func(ctx context.Context, id string, opts Options) (*Listener, error) {
+			return &Listener{id: id[len(IDPrefix):]}, nil
+		},
+		destroySingle: func(ctx context.Context, lr destroyer) error {
+			if lr.ID() == "iatk_eb_listener-2" {
+				var q *queue.Queue
+				_ = q.QueueURL
+			}
+			return nil
+		},
+	}
+	cfg, err := config.GetAWSConfig(context.TODO(), "us-west-2", "default", nil)
+	require.NoError(t, err)
+
+	report, err := DestroyMultiple(context.TODO(), []string{"iatk_eb_listener-1", "iatk_eb_listener-2", "iatk_eb_listener-3"}, cfg, opts)
+
+	require.Error(t, err)
+	require.Len(t, report, 3)
+	// the panic fails its listener only
+	assert.Equal(t, StatusDestroyed, report[0].Status)
+	assert.Equal(t, StatusFailed, report[1].Status)
+	assert.EqualError(t, report[1].Err, "panic: runtime error: invalid memory address or nil pointer dereference")
+	assert.Equal(t, StatusDestroyed, report[2].Status)
+}
+
 func TestCreate(t *testing.T) {
 	cases := map[string]struct {
 		mockDeployer func(ctx context.Context, expectOutput Output) *mockDeployer
@@ -670,6 +985,135 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestRecover(t *testing.T) {
+	ruleARN := arn.ARN{
+		Partition: testPartition,
+		Service:   "events",
+		Region:    testRegion,
+		AccountID: testAccountID,
+		Resource:  "rule/" + testBusName + "/" + testListenerID,
+	}
+	testQueue := &queue.Queue{Name: testListenerID, QueueURL: testQueueURL, ARN: testQueueARN()}
+	testRule := &eventrule.Rule{Name: testListenerID, EventBusName: testBusName, ARN: ruleARN}
+	cases := map[string]struct {
+		mockGetQueueWithName func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc
+		mockGetRule          func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc
+		listenerID           string
+		resourceARNs         []string
+		expectQueue          *queue.Queue
+		expectRule           *eventrule.Rule
+		expectErr            error
+		expectNotFound       bool
+	}{
+		"success": {
+			listenerID:   testListenerID,
+			resourceARNs: []string{testQueueARN().String(), ruleARN.String()},
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				mock := newMockGetQueueWithNameFunc(t)
+				mock.EXPECT().Execute(ctx, sqsClient, testListenerID).Return(testQueue, nil)
+				return mock
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, testBusName).Return(testRule, nil)
+				return mock
+			},
+			expectQueue: testQueue,
+			expectRule:  testRule,
+		},
+		"rule without queue": {
+			listenerID:   testListenerID,
+			resourceARNs: []string{testQueueARN().String(), ruleARN.String()},
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				mock := newMockGetQueueWithNameFunc(t)
+				mock.EXPECT().Execute(ctx, sqsClient, testListenerID).Return(nil, &sqstypes.QueueDoesNotExist{})
+				return mock
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, testBusName).Return(testRule, nil)
+				return mock
+			},
+			expectRule: testRule,
+		},
+		"rule on default event bus": {
+			listenerID:   testListenerID,
+			resourceARNs: []string{"arn:aws:events:us-west-2:123456789012:rule/" + testListenerID},
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				return newMockGetQueueWithNameFunc(t)
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, "default").Return(testRule, nil)
+				return mock
+			},
+			expectRule: testRule,
+		},
+		"nothing left": {
+			listenerID:   testListenerID,
+			resourceARNs: []string{testQueueARN().String(), ruleARN.String()},
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				mock := newMockGetQueueWithNameFunc(t)
+				mock.EXPECT().Execute(ctx, sqsClient, testListenerID).Return(nil, &sqstypes.QueueDoesNotExist{})
+				return mock
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, testBusName).Return(nil, fmt.Errorf("failed to describe rule: %w", &ebtypes.ResourceNotFoundException{}))
+				return mock
+			},
+			expectErr:      errors.New("failed to recover eb listener iatk_eb_9m4e2mr0ui3e8a215n4g: no resource left"),
+			expectNotFound: true,
+		},
+		"failed to get rule": {
+			listenerID:   testListenerID,
+			resourceARNs: []string{ruleARN.String()},
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				return newMockGetQueueWithNameFunc(t)
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, testBusName).Return(nil, errors.New("access denied"))
+				return mock
+			},
+			expectErr: errors.New("failed to recover eb listener iatk_eb_9m4e2mr0ui3e8a215n4g: access denied"),
+		},
+		"invalid id": {
+			listenerID: "eb_iatk_9m4e2mr0ui3e8a215n4g",
+			mockGetQueueWithName: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueWithNameFunc {
+				return newMockGetQueueWithNameFunc(t)
+			},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				return newMockGetRuleFunc(t)
+			},
+			expectErr: errors.New("invalid ID"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			opts := Options{
+				sqsClient: newMockSqsClient(t),
+				ebClient:  newMockEbClient(t),
+			}
+			opts.getQueueWithName = tt.mockGetQueueWithName(ctx, opts.sqsClient).Execute
+			opts.getRule = tt.mockGetRule(ctx, opts.ebClient).Execute
+			lr, err := Recover(ctx, tt.listenerID, tt.resourceARNs, opts)
+			var errNotFound *NotFoundError
+			assert.Equal(t, tt.expectNotFound, errors.As(err, &errNotFound))
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.listenerID, lr.ID())
+			assert.Equal(t, tt.expectQueue, lr.queue)
+			assert.Equal(t, tt.expectRule, lr.rule)
+		})
+	}
+}
+
 func Test_isValidId(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -694,6 +1138,7 @@ func TestPollEvents(t *testing.T) {
 	cases := map[string]struct {
 		waitTimeSeconds     int32
 		maxNumberOfMessages int32
+		eventPattern        string
 		mock                func(ctx context.Context, waitTimeSeconds, maxNumberOfMessages int32) *mockPoller
 		expect              []string
 		expectErr           error
@@ -718,6 +1163,47 @@ func TestPollEvents(t *testing.T) {
 			expect:    []string{"{}", "{}", `{"foo":"bar"}`},
 			expectErr: nil,
 		},
+		"should only return and delete events matching the pattern": {
+			waitTimeSeconds:     5,
+			maxNumberOfMessages: 5,
+			eventPattern:        `{"foo":["bar"]}`,
+			mock: func(ctx context.Context, waitTimeSeconds, maxNumberOfMessages int32) *mockPoller {
+				m := newMockPoller(t)
+				m.EXPECT().
+					ReceiveEvents(ctx, waitTimeSeconds, maxNumberOfMessages).
+					Return([]Event{
+						{Body: "{}", ReceiptHandle: "123"},
+						{Body: "not an event", ReceiptHandle: "456"},
+						{Body: `{"foo":"bar"}`, ReceiptHandle: "789"},
+					}, nil)
+				m.EXPECT().
+					ReleaseEvents(ctx, []string{"123", "456"}).
+					Return(nil)
+				m.EXPECT().
+					DeleteEvents(ctx, []string{"789"}).
+					Return(nil)
+				return m
+			},
+			expect: []string{`{"foo":"bar"}`},
+		},
+		"should succeed if no event matches and releasing them fails": {
+			waitTimeSeconds:     5,
+			maxNumberOfMessages: 5,
+			eventPattern:        `{"foo":["bar"]}`,
+			mock: func(ctx context.Context, waitTimeSeconds, maxNumberOfMessages int32) *mockPoller {
+				m := newMockPoller(t)
+				m.EXPECT().
+					ReceiveEvents(ctx, waitTimeSeconds, maxNumberOfMessages).
+					Return([]Event{
+						{Body: "{}", ReceiptHandle: "123"},
+					}, nil)
+				m.EXPECT().
+					ReleaseEvents(ctx, []string{"123"}).
+					Return(errors.New("release events failed"))
+				return m
+			},
+			expect: []string{},
+		},
 		"should succeed and not calling delete events if not event is received": {
 			waitTimeSeconds:     5,
 			maxNumberOfMessages: 5,
@@ -770,7 +1256,13 @@ func TestPollEvents(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			ctx := context.TODO()
 			listener := tt.mock(ctx, tt.waitTimeSeconds, tt.maxNumberOfMessages)
-			actual, err := PollEvents(ctx, listener, tt.waitTimeSeconds, tt.maxNumberOfMessages)
+			var p *pattern.Pattern
+			if tt.eventPattern != "" {
+				var err error
+				p, err = pattern.Parse(tt.eventPattern)
+				require.NoError(t, err)
+			}
+			actual, err := PollEvents(ctx, listener, tt.waitTimeSeconds, tt.maxNumberOfMessages, p)
 			if tt.expectErr != nil {
 				assert.EqualError(t, err, tt.expectErr.Error())
 			} else {
@@ -779,3 +1271,108 @@ func TestPollEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestDestroyReport(t *testing.T) {
+	report := DestroyReport{
+		{ID: "iatk_eb_listener-1", Status: StatusDestroyed},
+		{ID: "iatk_eb_listener-2", Status: StatusAlreadyAbsent},
+		{ID: "iatk_eb_listener-4", Status: StatusWouldDestroy, Components: []harness.Resource{
+			{Type: "AWS::SQS::Queue", PhysicalID: "my-queue", ARN: "queue-arn"},
+		}},
+		{ID: "iatk_eb_listener-3", Status: StatusFailed, Err: errors.New("api failed"), Leftovers: []harness.Resource{
+			{Type: "AWS::SQS::Queue", PhysicalID: "my-queue", ARN: "queue-arn"},
+			{Type: "AWS::Events::Rule", PhysicalID: "my-rule", ARN: "rule-arn"},
+		}},
+	}
+	assert.EqualError(t, report.Err(), "failed to destroy following listener(s): {resource group id: iatk_eb_listener-3, reason: api failed}")
+	assert.Equal(t, []DestroyOutput{
+		{ID: "iatk_eb_listener-1", Status: "Destroyed"},
+		{ID: "iatk_eb_listener-2", Status: "AlreadyAbsent"},
+		{ID: "iatk_eb_listener-4", Status: "WouldDestroy", ComponentARNs: []string{"queue-arn"}},
+		{ID: "iatk_eb_listener-3", Status: "Failed", Error: "api failed", LeftoverARNs: []string{"queue-arn", "rule-arn"}},
+	}, report.JSON())
+
+	assert.NoError(t, report[:3].Err())
+}
+
+// fakeGetResourcesAPI returns resources tagged with their test harness ID, or err.
+type fakeGetResourcesAPI struct {
+	resourceARNs []string
+	err          error
+}
+
+func (f fakeGetResourcesAPI) GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := &resourcegroupstaggingapi.GetResourcesOutput{}
+	for _, s := range f.resourceARNs {
+		out.ResourceTagMappingList = append(out.ResourceTagMappingList, tagtypes.ResourceTagMapping{
+			ResourceARN: aws.String(s),
+			Tags:        []tagtypes.Tag{{Key: aws.String("iatk:TestHarness:ID"), Value: aws.String(testListenerID)}},
+		})
+	}
+	return out, nil
+}
+
+func TestGetOrRecover(t *testing.T) {
+	ruleARN := "arn:aws:events:us-west-2:123456789012:rule/" + testBusName + "/" + testListenerID
+	testRule := &eventrule.Rule{Name: testListenerID, EventBusName: testBusName}
+	cases := map[string]struct {
+		api            fakeGetResourcesAPI
+		mockGetRule    func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc
+		expectRule     *eventrule.Rule
+		expectErr      error
+		expectNotFound bool
+	}{
+		"rule left behind": {
+			api: fakeGetResourcesAPI{resourceARNs: []string{testQueueARN().String(), ruleARN}},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				mock := newMockGetRuleFunc(t)
+				mock.EXPECT().Execute(ctx, ebClient, testListenerID, testBusName).Return(testRule, nil)
+				return mock
+			},
+			expectRule: testRule,
+		},
+		"nothing left": {
+			api: fakeGetResourcesAPI{},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				return newMockGetRuleFunc(t)
+			},
+			expectErr:      errors.New("failed to recover eb listener iatk_eb_9m4e2mr0ui3e8a215n4g: no resource left"),
+			expectNotFound: true,
+		},
+		"failed to get resources": {
+			api: fakeGetResourcesAPI{err: errors.New("access denied")},
+			mockGetRule: func(ctx context.Context, ebClient ebClient) *mockGetRuleFunc {
+				return newMockGetRuleFunc(t)
+			},
+			expectErr: errors.New("failed to recover eb listener iatk_eb_9m4e2mr0ui3e8a215n4g: failed to get resources: access denied"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			opts := Options{
+				sqsClient: newMockSqsClient(t),
+				ebClient:  newMockEbClient(t),
+			}
+			mockGetQueueWithName := newMockGetQueueWithNameFunc(t)
+			mockGetQueueWithName.EXPECT().Execute(ctx, opts.sqsClient, testListenerID).Return(nil, &sqstypes.QueueDoesNotExist{})
+			opts.getQueueWithName = mockGetQueueWithName.Execute
+			opts.getRule = tt.mockGetRule(ctx, opts.ebClient).Execute
+
+			lr, err := GetOrRecover(tt.api)(ctx, testListenerID, opts)
+			var errNotFound *NotFoundError
+			assert.Equal(t, tt.expectNotFound, errors.As(err, &errNotFound))
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, lr.queue)
+			assert.Equal(t, tt.expectRule, lr.rule)
+		})
+	}
+}
@@ -12,7 +12,8 @@ import (
 	"iatk/internal/pkg/harness/resource/eventrule"
 	"iatk/internal/pkg/harness/resource/queue"
 	"iatk/internal/pkg/harness/tags"
-	"log"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/progress"
 	"strconv"
 	"time"
 
@@ -25,9 +26,18 @@ import (
 )
 
 type Output struct {
-	ID         string             `json:"Id"`
-	TestTarget harness.Resource   `json:"TargetUnderTest"`
-	Components []harness.Resource `json:"Components"`
+	ID         string             `json:"Id" required:"true"`
+	TestTarget harness.Resource   `json:"TargetUnderTest" required:"true"`
+	Components []harness.Resource `json:"Components" required:"true"`
+}
+
+// DestroyOutput is the outcome of destroying a Listener, see DestroyResult.
+type DestroyOutput struct {
+	ID            string   `json:"Id" required:"true"`
+	Status        string   `json:"Status" enum:"Destroyed,AlreadyAbsent,Failed,WouldDestroy" required:"true"`
+	Error         string   `json:"Error,omitempty" description:"Reason the Listener could not be removed, if it failed"`
+	LeftoverARNs  []string `json:"LeftoverArns,omitempty" description:"ARNs of the components of the Listener left behind, if it failed"`
+	ComponentARNs []string `json:"ComponentArns,omitempty" description:"ARNs of the components of the Listener that would be removed, on a dry run"`
 }
 
 // Options for configuring dependency clients/funcs for Listener
@@ -48,6 +58,7 @@ type Options struct {
 	getQueueWithName         getQueueWithNameFunc
 	getRule                  getRuleFunc
 	getEventBusNameFromQueue getEventBusNameFromQueueFunc
+	getQueueDepth            getQueueDepthFunc
 }
 
 func NewOptions(cfg aws.Config) Options {
@@ -65,6 +76,7 @@ func NewOptions(cfg aws.Config) Options {
 		getQueueWithName:         queue.GetWithName,
 		getRule:                  eventrule.Get,
 		getEventBusNameFromQueue: queue.GetEventBusNameFromQueue,
+		getQueueDepth:            queue.GetDepth,
 	}
 }
 
@@ -73,6 +85,7 @@ type Listener struct {
 	id           string
 	eventPattern string
 	customTags   map[string]string
+	ttl          time.Duration
 
 	target *ebtypes.Target
 
@@ -90,16 +103,19 @@ func (lr *Listener) ID() string {
 }
 
 func (lr *Listener) tags(ts time.Time) map[string]string {
-	tags := map[string]string{
+	m := map[string]string{
 		string(tags.TestHarnessID):      lr.ID(),
 		string(tags.TestHarnessType):    TestHarnessType,
 		string(tags.TestHarnessTarget):  lr.eventBus.ARN.String(),
 		string(tags.TestHarnessCreated): ts.Format(time.RFC3339),
 	}
+	if lr.ttl > 0 {
+		m[string(tags.TestHarnessTTL)] = strconv.Itoa(int(lr.ttl / time.Second))
+	}
 	for key, val := range lr.customTags {
-		tags[key] = val
+		m[key] = val
 	}
-	return tags
+	return m
 }
 
 func (lr *Listener) String() string {
@@ -118,7 +134,8 @@ func (lr *Listener) Components() []harness.Resource {
 }
 
 func (lr *Listener) Deploy(ctx context.Context) error {
-	log.Printf("start deploy eb listener %v", lr.ID())
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, lr.ID())
+	logger.Debug("start deploy eb listener")
 	ts := time.Now()
 	tags := lr.tags(ts)
 	partition := lr.eventBus.ARN.Partition
@@ -132,6 +149,7 @@ func (lr *Listener) Deploy(ctx context.Context) error {
 		return fmt.Errorf("failed to deploy eb listener %v: %w", lr.ID(), err)
 	}
 	lr.rule = r
+	progress.Report(ctx, "rule created", map[string]interface{}{"listener_id": lr.ID(), "arn": lr.rule.Resource().ARN})
 
 	qn := queueName(lr.ID())
 	qpolicy := queuePolicy{
@@ -154,27 +172,31 @@ func (lr *Listener) Deploy(ctx context.Context) error {
 		return fmt.Errorf("failed to deploy eb listener %v: %w", lr.ID(), err)
 	}
 	lr.queue = q
+	progress.Report(ctx, "queue created", map[string]interface{}{"listener_id": lr.ID(), "arn": lr.queue.Resource().ARN})
 
 	err = lr.opts.putQueueTarget(ctx, lr.opts.ebClient, lr.ID(), lr.queue, lr.rule, lr.target)
 	if err != nil {
 		return fmt.Errorf("failed to deploy eb listener %v: %w", lr.ID(), err)
 	}
+	progress.Report(ctx, "queue target put", map[string]interface{}{"listener_id": lr.ID()})
 
-	log.Printf("complete deploy eb listener %v", lr.ID())
+	logger.Debug("complete deploy eb listener")
 	return nil
 }
 
 func (lr *Listener) Destroy(ctx context.Context) error {
+	logger := logging.FromContext(ctx).With(logging.ListenerIDKey, lr.ID())
 	if lr.rule == nil && lr.queue == nil {
-		log.Printf("nothing to destroy for eb listener %v", lr.ID())
+		logger.Info("nothing to destroy for eb listener")
 		return nil
 	}
 
-	log.Printf("destroy start (%v)", lr.String())
+	logger.Debug("start destroy eb listener", "listener", lr.String())
 	if lr.rule != nil {
 		if err := lr.opts.deleteRule(ctx, lr.opts.ebClient, lr.rule.EventBusName, lr.rule.Name); err != nil {
 			return fmt.Errorf("failed to destroy eb listener %v: %w", lr.ID(), err)
 		}
+		progress.Report(ctx, "rule deleted", map[string]interface{}{"listener_id": lr.ID(), "arn": lr.rule.Resource().ARN})
 	}
 	lr.rule = nil
 
@@ -182,10 +204,11 @@ func (lr *Listener) Destroy(ctx context.Context) error {
 		if err := lr.opts.deleteQueue(ctx, lr.opts.sqsClient, lr.queue.QueueURL); err != nil {
 			return fmt.Errorf("failed to destroy eb listener %v: %w", lr.ID(), err)
 		}
+		progress.Report(ctx, "queue deleted", map[string]interface{}{"listener_id": lr.ID(), "arn": lr.queue.Resource().ARN})
 	}
 	lr.queue = nil
 
-	log.Printf("complete destroy eb listener %v", lr.ID())
+	logger.Debug("complete destroy eb listener")
 	return nil
 }
 
@@ -197,6 +220,18 @@ func (lr *Listener) JSON() Output {
 	}
 }
 
+// ApproximateDepth returns the approximate number of events waiting in the queue of lr.
+func (lr *Listener) ApproximateDepth(ctx context.Context) (int, error) {
+	if lr.queue == nil {
+		return 0, fmt.Errorf("eb listener %v has no queue", lr.ID())
+	}
+	depth, err := lr.opts.getQueueDepth(ctx, lr.opts.sqsClient, lr.queue.QueueURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get depth of eb listener %v: %w", lr.ID(), err)
+	}
+	return depth, nil
+}
+
 func (lr *Listener) ReceiveEvents(ctx context.Context, waitTimeSeconds, maxNumberOfMessages int32) ([]Event, error) {
 	messages, err := lr.opts.sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
 		QueueUrl:            aws.String(lr.queue.QueueURL),
@@ -236,6 +271,30 @@ func (lr *Listener) DeleteEvents(ctx context.Context, receiptHandles []string) e
 	return nil
 }
 
+// ReleaseEvents makes the events of receiptHandles visible again on the queue of lr, for the next poll
+// to receive them.
+func (lr *Listener) ReleaseEvents(ctx context.Context, receiptHandles []string) error {
+	if len(receiptHandles) == 0 {
+		return errors.New("receiptHandles must have at least one item")
+	}
+	entries := make([]sqstypes.ChangeMessageVisibilityBatchRequestEntry, 0, len(receiptHandles))
+	for i, h := range receiptHandles {
+		entries = append(entries, sqstypes.ChangeMessageVisibilityBatchRequestEntry{
+			Id:                aws.String(strconv.Itoa(i)),
+			ReceiptHandle:     aws.String(h),
+			VisibilityTimeout: 0,
+		})
+	}
+	_, err := lr.opts.sqsClient.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: aws.String(lr.queue.QueueURL),
+		Entries:  entries,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release events: %w", err)
+	}
+	return nil
+}
+
 type Event struct {
 	Body          string `json:"Body"`
 	ReceiptHandle string `json:"ReceiptHandle"`
@@ -279,6 +338,8 @@ type sqsClient interface {
 	queue.ListQueueTagsAPI
 	queue.ReceiveMessageAPI
 	queue.DeleteMessageBatchAPI
+	queue.ChangeMessageVisibilityBatchAPI
+	queue.GetQueueAttributesAPI
 }
 
 //go:generate mockery --name getEventBusFunc
@@ -310,3 +371,6 @@ type getRuleFunc func(ctx context.Context, api eventrule.EbDescribeRuleAPI, rule
 
 //go:generate mockery --name getEventBusNameFromQueueFunc
 type getEventBusNameFromQueueFunc func(ctx context.Context, api queue.ListQueueTagsAPI, queueURL string) (string, error)
+
+//go:generate mockery --name getQueueDepthFunc
+type getQueueDepthFunc func(ctx context.Context, api queue.GetQueueAttributesAPI, queueURL string) (int, error)
@@ -56,6 +56,11 @@ func TestListener_tags(t *testing.T) {
 		"bax":                      "baz",
 	}
 	assert.Equal(t, expect, actual)
+
+	// with a ttl
+	lr.ttl = 90 * time.Minute
+	expect["iatk:TestHarness:TTL"] = "5400"
+	assert.Equal(t, expect, lr.tags(ts))
 }
 
 func TestListener_String(t *testing.T) {
@@ -455,6 +460,62 @@ func TestListener_JSON(t *testing.T) {
 	assert.Equal(t, expect, actual)
 }
 
+func TestListener_ApproximateDepth(t *testing.T) {
+	cases := map[string]struct {
+		queue     *queue.Queue
+		mockDepth func(ctx context.Context, sqsClient sqsClient) *mockGetQueueDepthFunc
+		expect    int
+		expectErr error
+	}{
+		"success": {
+			queue: &queue.Queue{Name: testListenerID, QueueURL: testQueueURL},
+			mockDepth: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueDepthFunc {
+				mock := newMockGetQueueDepthFunc(t)
+				mock.EXPECT().Execute(ctx, sqsClient, testQueueURL).Return(4, nil)
+				return mock
+			},
+			expect: 4,
+		},
+		"failed to get depth": {
+			queue: &queue.Queue{Name: testListenerID, QueueURL: testQueueURL},
+			mockDepth: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueDepthFunc {
+				mock := newMockGetQueueDepthFunc(t)
+				mock.EXPECT().Execute(ctx, sqsClient, testQueueURL).Return(0, errors.New("api failed"))
+				return mock
+			},
+			expectErr: fmt.Errorf("failed to get depth of eb listener %v: api failed", testListenerID),
+		},
+		"no queue": {
+			mockDepth: func(ctx context.Context, sqsClient sqsClient) *mockGetQueueDepthFunc {
+				return newMockGetQueueDepthFunc(t)
+			},
+			expectErr: fmt.Errorf("eb listener %v has no queue", testListenerID),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			sqsClient := newMockSqsClient(t)
+			lr := &Listener{
+				id:    testListenerID[len(IDPrefix):],
+				queue: tt.queue,
+				opts: Options{
+					sqsClient:     sqsClient,
+					getQueueDepth: tt.mockDepth(ctx, sqsClient).Execute,
+				},
+			}
+			depth, err := lr.ApproximateDepth(ctx)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, depth)
+			}
+		})
+	}
+}
+
 func TestListner_ReceiveEvents(t *testing.T) {
 	cases := map[string]struct {
 		listener            *Listener
@@ -621,6 +682,66 @@ func TestListener_DeleteEvents(t *testing.T) {
 	}
 }
 
+func TestListener_ReleaseEvents(t *testing.T) {
+	cases := map[string]struct {
+		receiptHandles []string
+		mock           func(ctx context.Context, lr *Listener) *mockSqsClient
+		expectErr      error
+	}{
+		"should succeed": {
+			receiptHandles: []string{"123", "456"},
+			mock: func(ctx context.Context, lr *Listener) *mockSqsClient {
+				client := newMockSqsClient(t)
+				client.EXPECT().
+					ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+						QueueUrl: aws.String(lr.queue.QueueURL),
+						Entries: []sqstypes.ChangeMessageVisibilityBatchRequestEntry{
+							{Id: aws.String("0"), ReceiptHandle: aws.String("123"), VisibilityTimeout: 0},
+							{Id: aws.String("1"), ReceiptHandle: aws.String("456"), VisibilityTimeout: 0},
+						},
+					}).
+					Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil)
+				return client
+			},
+		},
+		"should return error due to api failure": {
+			receiptHandles: []string{"123"},
+			mock: func(ctx context.Context, lr *Listener) *mockSqsClient {
+				client := newMockSqsClient(t)
+				client.EXPECT().
+					ChangeMessageVisibilityBatch(ctx, mock.Anything).
+					Return(nil, errors.New("api failure"))
+				return client
+			},
+			expectErr: errors.New("failed to release events: api failure"),
+		},
+		"should return error due to empty receipt handles": {
+			receiptHandles: []string{},
+			mock: func(ctx context.Context, lr *Listener) *mockSqsClient {
+				return newMockSqsClient(t)
+			},
+			expectErr: errors.New("receiptHandles must have at least one item"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			lr := &Listener{
+				id:    xid.New().String(),
+				queue: &queue.Queue{QueueURL: "queue-url"},
+			}
+			lr.opts.sqsClient = tt.mock(ctx, lr)
+			err := lr.ReleaseEvents(ctx, tt.receiptHandles)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_queuePolicy(t *testing.T) {
 	qp := queuePolicy{
 		queueARN: testQueueARN(),
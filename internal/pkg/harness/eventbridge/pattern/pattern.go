@@ -0,0 +1,540 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pattern matches events against EventBridge event patterns locally, without calling AWS.
+//
+// It supports exact values, prefix, suffix, anything-but, numeric, exists, cidr, equals-ignore-case,
+// wildcard and $or, see https://docs.aws.amazon.com/eventbridge/latest/userguide/eb-event-patterns.html.
+package pattern
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Pattern is a parsed event pattern.
+type Pattern struct {
+	raw  string
+	root map[string]interface{}
+}
+
+// Parse parses and validates an event pattern.
+func Parse(pattern string) (*Pattern, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(pattern), &root); err != nil {
+		return nil, fmt.Errorf("invalid event pattern: %w", err)
+	}
+	if len(root) == 0 {
+		return nil, errors.New("invalid event pattern: empty pattern")
+	}
+	if err := validateObject(root, ""); err != nil {
+		return nil, fmt.Errorf("invalid event pattern: %w", err)
+	}
+	return &Pattern{raw: pattern, root: root}, nil
+}
+
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Match tells whether event, a JSON object, matches p.
+func (p *Pattern) Match(event string) (bool, error) {
+	var e map[string]interface{}
+	if err := json.Unmarshal([]byte(event), &e); err != nil {
+		return false, fmt.Errorf("invalid event: %w", err)
+	}
+	return matchObject(p.root, e), nil
+}
+
+// Result is the outcome of matching an event, as returned by RPC methods.
+type Result struct {
+	Match bool   `json:"Match" required:"true"`
+	Error string `json:"Error,omitempty" description:"Reason the event could not be matched, e.g. it is not a JSON object"`
+}
+
+// TestOutput is the outcome of matching events against an event pattern, as returned by RPC methods.
+type TestOutput struct {
+	EventPattern string   `json:"EventPattern" description:"Event pattern the events were matched against" required:"true"`
+	Results      []Result `json:"Results" description:"Outcome of matching each event, in order" required:"true"`
+}
+
+// Test matches each of events against p.
+func Test(p *Pattern, events []string) TestOutput {
+	out := TestOutput{EventPattern: p.String(), Results: make([]Result, 0, len(events))}
+	for _, e := range events {
+		match, err := p.Match(e)
+		r := Result{Match: match}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		out.Results = append(out.Results, r)
+	}
+	return out
+}
+
+func validateObject(obj map[string]interface{}, path string) error {
+	for key, v := range obj {
+		fieldPath := join(path, key)
+		if key == "$or" {
+			alternatives, ok := v.([]interface{})
+			if !ok || len(alternatives) == 0 {
+				return fmt.Errorf("value of %q must be a non-empty array of objects", fieldPath)
+			}
+			for _, a := range alternatives {
+				alt, ok := a.(map[string]interface{})
+				if !ok || len(alt) == 0 {
+					return fmt.Errorf("value of %q must be a non-empty array of objects", fieldPath)
+				}
+				if err := validateObject(alt, path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				return fmt.Errorf("value of %q must not be an empty object", fieldPath)
+			}
+			if err := validateObject(v, fieldPath); err != nil {
+				return err
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				return fmt.Errorf("value of %q must not be an empty array", fieldPath)
+			}
+			for _, m := range v {
+				if err := validateMatcher(m, fieldPath); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("value of %q must be an object or an array", fieldPath)
+		}
+	}
+	return nil
+}
+
+func validateMatcher(m interface{}, path string) error {
+	switch m := m.(type) {
+	case nil, string, float64, bool:
+		return nil
+	case map[string]interface{}:
+		if len(m) != 1 {
+			return fmt.Errorf("matcher of %q must have exactly one operator", path)
+		}
+		for op, v := range m {
+			if err := validateOperator(op, v, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("matcher of %q must be a value or an object", path)
+	}
+}
+
+func validateOperator(op string, v interface{}, path string) error {
+	invalid := func(expect string) error {
+		return fmt.Errorf("value of %q operator of %q must be %v", op, path, expect)
+	}
+	switch op {
+	case "prefix", "suffix":
+		if _, ok := v.(string); ok {
+			return nil
+		}
+		if s, ok := singleOperand(v, "equals-ignore-case").(string); ok && s != "" {
+			return nil
+		}
+		return invalid(`a string, or an object with an "equals-ignore-case" string`)
+	case "equals-ignore-case":
+		if _, ok := v.(string); !ok {
+			return invalid("a string")
+		}
+	case "wildcard":
+		s, ok := v.(string)
+		if !ok {
+			return invalid("a string")
+		}
+		if !validWildcard(s) {
+			return invalid("a string without consecutive wildcards")
+		}
+	case "anything-but":
+		switch v := v.(type) {
+		case string, float64:
+		case []interface{}:
+			if len(v) == 0 {
+				return invalid("a non-empty array")
+			}
+			for _, x := range v {
+				if !isStringOrNumber(x) {
+					return invalid("a string, a number, an array of them, or an object")
+				}
+			}
+		case map[string]interface{}:
+			if len(v) != 1 {
+				return invalid(`an object with one of "prefix", "suffix", "equals-ignore-case" or "wildcard"`)
+			}
+			for k, x := range v {
+				switch k {
+				case "prefix", "suffix":
+					if _, ok := x.(string); !ok {
+						return invalid(fmt.Sprintf("an object with a %q string", k))
+					}
+				case "equals-ignore-case", "wildcard":
+					if !isStringOrStrings(x) {
+						return invalid(fmt.Sprintf("an object with a %q string or array of strings", k))
+					}
+					if k == "wildcard" {
+						for _, w := range asList(x) {
+							if !validWildcard(w.(string)) {
+								return invalid(`an object with a "wildcard" without consecutive wildcards`)
+							}
+						}
+					}
+				default:
+					return invalid(`an object with one of "prefix", "suffix", "equals-ignore-case" or "wildcard"`)
+				}
+			}
+		default:
+			return invalid("a string, a number, an array of them, or an object")
+		}
+	case "numeric":
+		conds, ok := v.([]interface{})
+		if !ok || (len(conds) != 2 && len(conds) != 4) {
+			return invalid("an array of one or two comparisons")
+		}
+		for i := 0; i < len(conds); i += 2 {
+			cmp, _ := conds[i].(string)
+			switch cmp {
+			case "=", "<", "<=", ">", ">=":
+			default:
+				return invalid(`an array of comparisons with "=", "<", "<=", ">" or ">="`)
+			}
+			if _, ok := conds[i+1].(float64); !ok {
+				return invalid("an array of comparisons with numbers")
+			}
+		}
+	case "exists":
+		if _, ok := v.(bool); !ok {
+			return invalid("a boolean")
+		}
+	case "cidr":
+		s, ok := v.(string)
+		if !ok {
+			return invalid("a string")
+		}
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return invalid("a CIDR block")
+		}
+	default:
+		return fmt.Errorf("unknown operator %q of %q", op, path)
+	}
+	return nil
+}
+
+// matchObject tells whether event matches the fields of the pattern obj, and at least one of their $or
+// alternatives.
+func matchObject(obj, event map[string]interface{}) bool {
+	for key, v := range obj {
+		if key == "$or" {
+			matched := false
+			for _, alt := range v.([]interface{}) {
+				if matchObject(alt.(map[string]interface{}), event) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+		ev, present := event[key]
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if !matchNested(v, ev, present) {
+				return false
+			}
+		case []interface{}:
+			if !matchLeaf(v, ev, present) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchNested tells whether the event field ev, if present, matches the pattern obj. As in EventBridge, an
+// array matches if one of its elements does.
+func matchNested(obj map[string]interface{}, ev interface{}, present bool) bool {
+	if !present {
+		// a missing object only matches fields that must not exist
+		return matchObject(obj, map[string]interface{}{})
+	}
+	switch ev := ev.(type) {
+	case map[string]interface{}:
+		return matchObject(obj, ev)
+	case []interface{}:
+		for _, e := range ev {
+			if m, ok := e.(map[string]interface{}); ok && matchObject(obj, m) {
+				return true
+			}
+		}
+		return false
+	default:
+		// a present value is not an object, whatever the pattern expects of its fields
+		return false
+	}
+}
+
+// matchLeaf tells whether the event field ev matches one of the matchers. As in EventBridge, an array
+// matches if one of its elements does.
+func matchLeaf(matchers []interface{}, ev interface{}, present bool) bool {
+	_, isObject := ev.(map[string]interface{})
+	values := []interface{}{ev}
+	if l, ok := ev.([]interface{}); ok {
+		values = l
+	}
+	for _, m := range matchers {
+		if op, ok := m.(map[string]interface{}); ok {
+			if exists, ok := op["exists"]; ok {
+				if exists.(bool) == (present && !isObject) {
+					return true
+				}
+				continue
+			}
+		}
+		if !present {
+			continue
+		}
+		for _, v := range values {
+			if matchValue(m, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchValue(m, v interface{}) bool {
+	op, ok := m.(map[string]interface{})
+	if !ok {
+		return m == v
+	}
+	s, isString := v.(string)
+	for name, operand := range op {
+		switch name {
+		case "prefix":
+			return isString && matchAffix(operand, s, strings.HasPrefix)
+		case "suffix":
+			return isString && matchAffix(operand, s, strings.HasSuffix)
+		case "equals-ignore-case":
+			return isString && strings.EqualFold(s, operand.(string))
+		case "wildcard":
+			return isString && matchWildcard(operand.(string), s)
+		case "anything-but":
+			return matchAnythingBut(operand, v)
+		case "numeric":
+			return matchNumeric(operand.([]interface{}), v)
+		case "cidr":
+			return isString && matchCIDR(operand.(string), s)
+		}
+	}
+	return false
+}
+
+func matchAffix(operand interface{}, s string, has func(s, affix string) bool) bool {
+	if affix, ok := operand.(string); ok {
+		return has(s, affix)
+	}
+	affix := singleOperand(operand, "equals-ignore-case").(string)
+	return has(strings.ToLower(s), strings.ToLower(affix))
+}
+
+func matchAnythingBut(operand, v interface{}) bool {
+	op, ok := operand.(map[string]interface{})
+	if !ok {
+		for _, x := range asList(operand) {
+			if x == v {
+				return false
+			}
+		}
+		return true
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	for name, x := range op {
+		switch name {
+		case "prefix":
+			return !strings.HasPrefix(s, x.(string))
+		case "suffix":
+			return !strings.HasSuffix(s, x.(string))
+		case "equals-ignore-case":
+			for _, y := range asList(x) {
+				if strings.EqualFold(s, y.(string)) {
+					return false
+				}
+			}
+			return true
+		case "wildcard":
+			for _, y := range asList(x) {
+				if matchWildcard(y.(string), s) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func matchNumeric(conds []interface{}, v interface{}) bool {
+	n, ok := v.(float64)
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(conds); i += 2 {
+		bound := conds[i+1].(float64)
+		var ok bool
+		switch conds[i].(string) {
+		case "=":
+			ok = n == bound
+		case "<":
+			ok = n < bound
+		case "<=":
+			ok = n <= bound
+		case ">":
+			ok = n > bound
+		case ">=":
+			ok = n >= bound
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCIDR(cidr, s string) bool {
+	_, network, _ := net.ParseCIDR(cidr)
+	ip := net.ParseIP(s)
+	return ip != nil && network.Contains(ip)
+}
+
+type wildcardToken struct {
+	r    rune
+	star bool
+}
+
+// wildcardTokens splits pattern into characters and wildcards, where * is a wildcard and \* a literal *.
+func wildcardTokens(pattern string) []wildcardToken {
+	var tokens []wildcardToken
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			tokens = append(tokens, wildcardToken{r: r})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*':
+			tokens = append(tokens, wildcardToken{star: true})
+		default:
+			tokens = append(tokens, wildcardToken{r: r})
+		}
+	}
+	return tokens
+}
+
+// validWildcard tells whether pattern is free of consecutive wildcards, which EventBridge rejects.
+func validWildcard(pattern string) bool {
+	tokens := wildcardTokens(pattern)
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i].star && tokens[i-1].star {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWildcard tells whether s matches pattern, where * matches any sequence of characters and \* a
+// literal *.
+func matchWildcard(pattern, s string) bool {
+	tokens := wildcardTokens(pattern)
+	text := []rune(s)
+	ti, pi := 0, 0
+	star, mark := -1, 0
+	for ti < len(text) {
+		switch {
+		case pi < len(tokens) && !tokens[pi].star && tokens[pi].r == text[ti]:
+			ti++
+			pi++
+		case pi < len(tokens) && tokens[pi].star:
+			star, mark = pi, ti
+			pi++
+		case star != -1:
+			// let the last star match one more character
+			mark++
+			pi, ti = star+1, mark
+		default:
+			return false
+		}
+	}
+	for pi < len(tokens) && tokens[pi].star {
+		pi++
+	}
+	return pi == len(tokens)
+}
+
+// singleOperand returns the operand of op in v, if v is an object with op only.
+func singleOperand(v interface{}, op string) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return nil
+	}
+	return m[op]
+}
+
+func asList(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{v}
+}
+
+func isStringOrNumber(v interface{}) bool {
+	switch v.(type) {
+	case string, float64:
+		return true
+	}
+	return false
+}
+
+func isStringOrStrings(v interface{}) bool {
+	if _, ok := v.(string); ok {
+		return true
+	}
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return false
+	}
+	for _, x := range l {
+		if _, ok := x.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
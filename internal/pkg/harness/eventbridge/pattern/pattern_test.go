@@ -0,0 +1,225 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pattern
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEvent = `{
+	"version": "0",
+	"id": "6a7e8feb-b491-4cf7-a9f1-bf3703467718",
+	"detail-type": "OrderPlaced",
+	"source": "com.example.orders",
+	"account": "123456789012",
+	"time": "2023-09-01T10:00:00Z",
+	"region": "us-west-2",
+	"resources": ["arn:aws:s3:::my-bucket/orders/42.json"],
+	"detail": {
+		"status": "Paid",
+		"amount": 120.5,
+		"items": [{"sku": "A-1", "quantity": 2}, {"sku": "B-2", "quantity": 1}],
+		"tags": ["priority", "gift"],
+		"sourceIp": "10.0.0.12",
+		"coupon": null,
+		"customer": {"id": "c-1"}
+	}
+}`
+
+func TestPattern_Match(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		expect  bool
+	}{
+		"exact value":                       {`{"source":["com.example.orders"]}`, true},
+		"exact value mismatch":              {`{"source":["com.example.payments"]}`, false},
+		"one of values":                     {`{"detail-type":["OrderShipped","OrderPlaced"]}`, true},
+		"all fields must match":             {`{"source":["com.example.orders"],"detail-type":["OrderShipped"]}`, false},
+		"nested field":                      {`{"detail":{"customer":{"id":["c-1"]}}}`, true},
+		"number":                            {`{"detail":{"amount":[120.5]}}`, true},
+		"number is not a string":            {`{"detail":{"amount":["120.5"]}}`, false},
+		"null":                              {`{"detail":{"coupon":[null]}}`, true},
+		"missing field":                     {`{"detail":{"discount":["none"]}}`, false},
+		"array element":                     {`{"detail":{"tags":["gift"]}}`, true},
+		"array of objects":                  {`{"detail":{"items":{"sku":["B-2"]}}}`, true},
+		"array of objects mismatch":         {`{"detail":{"items":{"sku":["C-3"]}}}`, false},
+		"prefix":                            {`{"source":[{"prefix":"com.example."}]}`, true},
+		"prefix mismatch":                   {`{"source":[{"prefix":"org.example."}]}`, false},
+		"prefix ignoring case":              {`{"source":[{"prefix":{"equals-ignore-case":"COM.EXAMPLE."}}]}`, true},
+		"suffix":                            {`{"resources":[{"suffix":".json"}]}`, true},
+		"suffix ignoring case":              {`{"resources":[{"suffix":{"equals-ignore-case":".JSON"}}]}`, true},
+		"suffix mismatch":                   {`{"resources":[{"suffix":".csv"}]}`, false},
+		"equals ignoring case":              {`{"detail":{"status":[{"equals-ignore-case":"paid"}]}}`, true},
+		"anything but":                      {`{"detail":{"status":[{"anything-but":"Cancelled"}]}}`, true},
+		"anything but mismatch":             {`{"detail":{"status":[{"anything-but":["Paid","Cancelled"]}]}}`, false},
+		"anything but number":               {`{"detail":{"amount":[{"anything-but":100}]}}`, true},
+		"anything but prefix":               {`{"source":[{"anything-but":{"prefix":"com."}}]}`, false},
+		"anything but suffix":               {`{"source":[{"anything-but":{"suffix":".payments"}}]}`, true},
+		"anything but ignoring case":        {`{"detail":{"status":[{"anything-but":{"equals-ignore-case":["PAID"]}}]}}`, false},
+		"anything but wildcard":             {`{"source":[{"anything-but":{"wildcard":"*.payments"}}]}`, true},
+		"anything but missing field":        {`{"detail":{"discount":[{"anything-but":"none"}]}}`, false},
+		"numeric range":                     {`{"detail":{"amount":[{"numeric":[">",100,"<=",200]}]}}`, true},
+		"numeric range mismatch":            {`{"detail":{"amount":[{"numeric":[">=",200]}]}}`, false},
+		"numeric equals":                    {`{"detail":{"amount":[{"numeric":["=",120.5]}]}}`, true},
+		"numeric of a string":               {`{"detail":{"status":[{"numeric":[">",0]}]}}`, false},
+		"numeric of array elements":         {`{"detail":{"items":{"quantity":[{"numeric":[">",1]}]}}}`, true},
+		"exists":                            {`{"detail":{"status":[{"exists":true}]}}`, true},
+		"exists of a missing field":         {`{"detail":{"discount":[{"exists":true}]}}`, false},
+		"not exists":                        {`{"detail":{"discount":[{"exists":false}]}}`, true},
+		"not exists of a field":             {`{"detail":{"status":[{"exists":false}]}}`, false},
+		"not exists of a missing object":    {`{"metadata":{"trace":[{"exists":false}]}}`, true},
+		"exists of an object":               {`{"detail":{"customer":[{"exists":true}]}}`, false},
+		"cidr":                              {`{"detail":{"sourceIp":[{"cidr":"10.0.0.0/24"}]}}`, true},
+		"cidr mismatch":                     {`{"detail":{"sourceIp":[{"cidr":"10.0.1.0/24"}]}}`, false},
+		"wildcard":                          {`{"resources":[{"wildcard":"arn:aws:s3:::my-bucket/*.json"}]}`, true},
+		"wildcard with several stars":       {`{"source":[{"wildcard":"com.*.ord*"}]}`, true},
+		"wildcard mismatch":                 {`{"resources":[{"wildcard":"arn:aws:s3:::other-bucket/*"}]}`, false},
+		"wildcard with escaped star":        {`{"source":[{"wildcard":"com.example\\*"}]}`, false},
+		"or":                                {`{"$or":[{"source":["com.example.payments"]},{"detail-type":["OrderPlaced"]}]}`, true},
+		"or mismatch":                       {`{"$or":[{"source":["com.example.payments"]},{"detail-type":["OrderShipped"]}]}`, false},
+		"or with other fields":              {`{"source":["com.example.orders"],"$or":[{"detail":{"amount":[{"numeric":[">",100]}]}},{"detail":{"tags":["priority"]}}]}`, true},
+		"nested or":                         {`{"detail":{"$or":[{"status":["Cancelled"]},{"tags":["priority"]}]}}`, true},
+		"value or matcher":                  {`{"detail":{"status":["Cancelled",{"prefix":"Pa"}]}}`, true},
+		"object pattern on a value field":   {`{"source":{"name":["orders"]}}`, false},
+		"object pattern on a missing field": {`{"missing":{"name":[{"exists":false}]}}`, true},
+		"object pattern on a present value": {`{"source":{"name":[{"exists":false}]}}`, false},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := Parse(tt.pattern)
+			require.NoError(t, err)
+			actual, err := p.Match(testEvent)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestPattern_MatchInvalidEvent(t *testing.T) {
+	p, err := Parse(`{"source":["com.example.orders"]}`)
+	require.NoError(t, err)
+	_, err = p.Match(`["not", "an", "object"]`)
+	assert.EqualError(t, err, "invalid event: json: cannot unmarshal array into Go value of type map[string]interface {}")
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		pattern   string
+		expectErr error
+	}{
+		"valid": {
+			pattern: `{"source":["a",{"prefix":"b"}],"detail":{"n":[{"numeric":[">",0,"<",1]}]},"$or":[{"x":[1]},{"y":[true]}]}`,
+		},
+		"not json": {
+			pattern:   `{"source":`,
+			expectErr: errors.New("invalid event pattern: unexpected end of JSON input"),
+		},
+		"empty": {
+			pattern:   `{}`,
+			expectErr: errors.New("invalid event pattern: empty pattern"),
+		},
+		"value is not an array": {
+			pattern:   `{"source":"a"}`,
+			expectErr: errors.New(`invalid event pattern: value of "source" must be an object or an array`),
+		},
+		"empty array": {
+			pattern:   `{"detail":{"status":[]}}`,
+			expectErr: errors.New(`invalid event pattern: value of "detail.status" must not be an empty array`),
+		},
+		"empty object": {
+			pattern:   `{"detail":{}}`,
+			expectErr: errors.New(`invalid event pattern: value of "detail" must not be an empty object`),
+		},
+		"nested array": {
+			pattern:   `{"source":[["a"]]}`,
+			expectErr: errors.New(`invalid event pattern: matcher of "source" must be a value or an object`),
+		},
+		"several operators": {
+			pattern:   `{"source":[{"prefix":"a","suffix":"b"}]}`,
+			expectErr: errors.New(`invalid event pattern: matcher of "source" must have exactly one operator`),
+		},
+		"unknown operator": {
+			pattern:   `{"source":[{"contains":"a"}]}`,
+			expectErr: errors.New(`invalid event pattern: unknown operator "contains" of "source"`),
+		},
+		"invalid prefix": {
+			pattern:   `{"source":[{"prefix":1}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "prefix" operator of "source" must be a string, or an object with an "equals-ignore-case" string`),
+		},
+		"invalid numeric operator": {
+			pattern:   `{"n":[{"numeric":["!=",1]}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "numeric" operator of "n" must be an array of comparisons with "=", "<", "<=", ">" or ">="`),
+		},
+		"invalid numeric bound": {
+			pattern:   `{"n":[{"numeric":[">","1"]}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "numeric" operator of "n" must be an array of comparisons with numbers`),
+		},
+		"invalid numeric length": {
+			pattern:   `{"n":[{"numeric":[">",1,"<"]}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "numeric" operator of "n" must be an array of one or two comparisons`),
+		},
+		"invalid exists": {
+			pattern:   `{"n":[{"exists":"yes"}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "exists" operator of "n" must be a boolean`),
+		},
+		"invalid cidr": {
+			pattern:   `{"ip":[{"cidr":"10.0.0.0"}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "cidr" operator of "ip" must be a CIDR block`),
+		},
+		"invalid anything but": {
+			pattern:   `{"s":[{"anything-but":{"exists":true}}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "anything-but" operator of "s" must be an object with one of "prefix", "suffix", "equals-ignore-case" or "wildcard"`),
+		},
+		"empty anything but": {
+			pattern:   `{"s":[{"anything-but":[]}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "anything-but" operator of "s" must be a non-empty array`),
+		},
+		"consecutive wildcards": {
+			pattern:   `{"s":[{"wildcard":"com.**.orders"}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "wildcard" operator of "s" must be a string without consecutive wildcards`),
+		},
+		"consecutive wildcards in anything but": {
+			pattern:   `{"s":[{"anything-but":{"wildcard":["*.orders","com.**"]}}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "anything-but" operator of "s" must be an object with a "wildcard" without consecutive wildcards`),
+		},
+		"invalid or": {
+			pattern:   `{"$or":{"source":["a"]}}`,
+			expectErr: errors.New(`invalid event pattern: value of "$or" must be a non-empty array of objects`),
+		},
+		"invalid or alternative": {
+			pattern:   `{"$or":[{"source":"a"},{"source":["b"]}]}`,
+			expectErr: errors.New(`invalid event pattern: value of "source" must be an object or an array`),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := Parse(tt.pattern)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.pattern, p.String())
+			}
+		})
+	}
+}
+
+func TestTest(t *testing.T) {
+	p, err := Parse(`{"source":["com.example.orders"]}`)
+	require.NoError(t, err)
+	assert.Equal(t, TestOutput{
+		EventPattern: `{"source":["com.example.orders"]}`,
+		Results: []Result{
+			{Match: true},
+			{Match: false},
+			{Error: "invalid event: unexpected end of JSON input"},
+		},
+	}, Test(p, []string{testEvent, `{"source":"com.example.payments"}`, `{"source":`}))
+}
@@ -0,0 +1,183 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gc destroys the test harnesses left behind, e.g. by test runs that crashed before removing
+// them, as found through the system tags of their resources.
+package gc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"iatk/internal/pkg/harness/eventbridge/listener"
+	"iatk/internal/pkg/harness/tags"
+	"iatk/internal/pkg/logging"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+// OrphanGracePeriod is the age under which incomplete test harnesses are not collected as orphans, as
+// they may still be being created.
+const OrphanGracePeriod = 5 * time.Minute
+
+// Reason tells why a test harness is collected.
+type Reason string
+
+const (
+	// ReasonExpired is the reason of test harnesses older than the maximum age.
+	ReasonExpired Reason = "Expired"
+	// ReasonTTLExpired is the reason of test harnesses whose time-to-live has passed.
+	ReasonTTLExpired Reason = "TTLExpired"
+	// ReasonOrphaned is the reason of test harnesses missing some of their resources, e.g. a rule
+	// without queue, as left by a failed destroy.
+	ReasonOrphaned Reason = "Orphaned"
+)
+
+// Result is the outcome of collecting a test harness.
+type Result struct {
+	Harness tags.TestHarness
+	Reason  Reason
+	listener.DestroyResult
+}
+
+// Output is the outcome of collecting a test harness, as returned by RPC methods.
+type Output struct {
+	ID            string   `json:"Id" required:"true"`
+	Type          string   `json:"Type" required:"true"`
+	Created       string   `json:"Created" description:"Creation time of the test harness, in RFC3339 format" required:"true"`
+	Reason        string   `json:"Reason" enum:"Expired,TTLExpired,Orphaned" required:"true"`
+	Status        string   `json:"Status" enum:"Destroyed,AlreadyAbsent,Failed,WouldDestroy" required:"true"`
+	Error         string   `json:"Error,omitempty" description:"Reason the test harness could not be destroyed, if it failed"`
+	LeftoverARNs  []string `json:"LeftoverArns,omitempty" description:"ARNs of the resources of the test harness left behind, if it failed"`
+	ComponentARNs []string `json:"ComponentArns,omitempty" description:"ARNs of the resources of the test harness that would be destroyed, on a dry run"`
+}
+
+// JSON returns the outcome of collecting the test harness of r, as returned by RPC methods.
+func (r Result) JSON() Output {
+	d := listener.DestroyReport{r.DestroyResult}.JSON()[0]
+	return Output{
+		ID:            r.Harness.ID,
+		Type:          r.Harness.Type,
+		Created:       r.Harness.Created.Format(time.RFC3339),
+		Reason:        string(r.Reason),
+		Status:        d.Status,
+		Error:         d.Error,
+		LeftoverARNs:  d.LeftoverARNs,
+		ComponentARNs: d.ComponentARNs,
+	}
+}
+
+// Options for configuring dependency clients/funcs for Collect
+type Options struct {
+	tagsAPI tags.GetResourcesAPI
+
+	// funcs
+	now              func() time.Time
+	getTestHarnesses getTestHarnessesFunc
+	destroyListeners destroyListenersFunc
+}
+
+func NewOptions(cfg aws.Config) Options {
+	return Options{
+		tagsAPI: resourcegroupstaggingapi.NewFromConfig(cfg),
+
+		now:              time.Now,
+		getTestHarnesses: tags.GetTestHarnesses,
+		destroyListeners: destroyListeners(cfg),
+	}
+}
+
+// Collect destroys the test harnesses matching tagFilters that are older than maxAge, if positive, whose
+// TTL has passed, or that are orphaned and older than OrphanGracePeriod, and returns the outcome for each
+// of them, along with an error if some could not be destroyed. On a dry run, the test harnesses are found
+// but not destroyed.
+//
+// Test harnesses are found through the tagging API, which may still return resources deleted recently,
+// so orphans may only be collected by a later run.
+func Collect(ctx context.Context, tagFilters []tagtypes.TagFilter, maxAge time.Duration, dryRun bool, opts Options) ([]Result, error) {
+	logger := logging.FromContext(ctx)
+	harnesses, err := opts.getTestHarnesses(ctx, opts.tagsAPI, tagFilters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find test harnesses: %w", err)
+	}
+
+	now := opts.now()
+	results := []Result{}
+	ids := []string{}
+	resourceARNs := map[string][]string{}
+	for _, h := range harnesses {
+		if h.Type != listener.TestHarnessType {
+			logger.Warn("skipping test harness of unknown type", "id", h.ID, "type", h.Type)
+			continue
+		}
+		if h.Created.IsZero() {
+			logger.Warn("skipping test harness without creation time", "id", h.ID)
+			continue
+		}
+		age := now.Sub(h.Created)
+		var reason Reason
+		switch {
+		case h.Expired(now):
+			reason = ReasonTTLExpired
+		case maxAge > 0 && age >= maxAge:
+			reason = ReasonExpired
+		case age >= OrphanGracePeriod && isOrphan(h):
+			reason = ReasonOrphaned
+		default:
+			continue
+		}
+		logger.Info("collecting test harness", "id", h.ID, "reason", reason, "created", h.Created)
+		results = append(results, Result{Harness: h, Reason: reason})
+		ids = append(ids, h.ID)
+		resourceARNs[h.ID] = h.ResourceARNs
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	// one result per distinct id, in order
+	report, err := opts.destroyListeners(ctx, ids, resourceARNs, dryRun)
+	for i := range results {
+		results[i].DestroyResult = report[i]
+	}
+	return results, err
+}
+
+// isOrphan tells whether the listener h misses its queue or its rule.
+func isOrphan(h tags.TestHarness) bool {
+	var hasQueue, hasRule bool
+	for _, s := range h.ResourceARNs {
+		a, err := arn.Parse(s)
+		if err != nil {
+			continue
+		}
+		switch a.Service {
+		case "sqs":
+			hasQueue = true
+		case "events":
+			hasRule = true
+		}
+	}
+	return hasQueue != hasRule
+}
+
+func destroyListeners(cfg aws.Config) destroyListenersFunc {
+	return func(ctx context.Context, ids []string, resourceARNs map[string][]string, dryRun bool) (listener.DestroyReport, error) {
+		opts := listener.NewDestroyOptions()
+		opts.DryRun = dryRun
+		opts.Get = func(ctx context.Context, id string, listenerOpts listener.Options) (*listener.Listener, error) {
+			return listener.Recover(ctx, id, resourceARNs[id], listenerOpts)
+		}
+		return listener.DestroyMultiple(ctx, ids, cfg, opts)
+	}
+}
+
+//go:generate mockery --name getTestHarnessesFunc
+type getTestHarnessesFunc func(ctx context.Context, api tags.GetResourcesAPI, tagFilters []tagtypes.TagFilter) ([]tags.TestHarness, error)
+
+//go:generate mockery --name destroyListenersFunc
+type destroyListenersFunc func(ctx context.Context, ids []string, resourceARNs map[string][]string, dryRun bool) (listener.DestroyReport, error)
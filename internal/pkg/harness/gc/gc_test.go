@@ -0,0 +1,175 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"iatk/internal/pkg/harness/eventbridge/listener"
+	"iatk/internal/pkg/harness/tags"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testQueueARN = "arn:aws:sqs:us-west-2:123456789012:iatk_eb_listener"
+	testRuleARN  = "arn:aws:events:us-west-2:123456789012:rule/my-event-bus/iatk_eb_listener"
+)
+
+func TestCollect(t *testing.T) {
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+	harness := func(id string, age time.Duration, arns ...string) tags.TestHarness {
+		return tags.TestHarness{
+			ID:           id,
+			Type:         listener.TestHarnessType,
+			Created:      now.Add(-age),
+			ResourceARNs: arns,
+		}
+	}
+	withTTL := func(h tags.TestHarness, ttl time.Duration) tags.TestHarness {
+		h.TTL = ttl
+		return h
+	}
+	tagFilters := []tagtypes.TagFilter{{Key: aws.String("foo"), Values: []string{"bar"}}}
+	cases := map[string]struct {
+		harnesses      []tags.TestHarness
+		maxAge         time.Duration
+		getErr         error
+		dryRun         bool
+		expectIDs      []string
+		expectReasons  []Reason
+		expectStatuses []listener.DestroyStatus
+		expectErr      error
+	}{
+		"collects expired and orphaned listeners": {
+			harnesses: []tags.TestHarness{
+				harness("expired", 2*time.Hour, testQueueARN, testRuleARN),
+				harness("recent", 10*time.Minute, testQueueARN, testRuleARN),
+				harness("queue-without-rule", 10*time.Minute, testQueueARN),
+				harness("rule-without-queue", 10*time.Minute, testRuleARN),
+				harness("being-created", time.Minute, testRuleARN),
+				{ID: "unknown-type", Type: "Other", Created: now.Add(-2 * time.Hour)},
+				{ID: "no-creation-time", Type: listener.TestHarnessType},
+			},
+			maxAge:         time.Hour,
+			expectIDs:      []string{"expired", "queue-without-rule", "rule-without-queue"},
+			expectReasons:  []Reason{ReasonExpired, ReasonOrphaned, ReasonOrphaned},
+			expectStatuses: []listener.DestroyStatus{listener.StatusDestroyed, listener.StatusDestroyed, listener.StatusDestroyed},
+		},
+		"dry run": {
+			harnesses: []tags.TestHarness{
+				harness("expired", 2*time.Hour, testQueueARN, testRuleARN),
+			},
+			maxAge:         time.Hour,
+			dryRun:         true,
+			expectIDs:      []string{"expired"},
+			expectReasons:  []Reason{ReasonExpired},
+			expectStatuses: []listener.DestroyStatus{listener.StatusWouldDestroy},
+		},
+		"collects listeners past their ttl": {
+			harnesses: []tags.TestHarness{
+				withTTL(harness("ttl-passed", 20*time.Minute, testQueueARN, testRuleARN), 15*time.Minute),
+				withTTL(harness("ttl-not-passed", 20*time.Minute, testQueueARN, testRuleARN), time.Hour),
+				harness("old-without-ttl", 24*time.Hour, testQueueARN, testRuleARN),
+			},
+			expectIDs:      []string{"ttl-passed"},
+			expectReasons:  []Reason{ReasonTTLExpired},
+			expectStatuses: []listener.DestroyStatus{listener.StatusDestroyed},
+		},
+		"nothing to collect": {
+			harnesses: []tags.TestHarness{
+				harness("recent", 10*time.Minute, testQueueARN, testRuleARN),
+			},
+			maxAge: time.Hour,
+		},
+		"failed to destroy": {
+			harnesses: []tags.TestHarness{
+				harness("expired", 2*time.Hour, testQueueARN, testRuleARN),
+				harness("also-expired", 2*time.Hour, testQueueARN, testRuleARN),
+			},
+			maxAge:         time.Hour,
+			expectIDs:      []string{"expired", "also-expired"},
+			expectReasons:  []Reason{ReasonExpired, ReasonExpired},
+			expectStatuses: []listener.DestroyStatus{listener.StatusDestroyed, listener.StatusFailed},
+			expectErr:      errors.New("failed to destroy following listener(s): {resource group id: also-expired, reason: access denied}"),
+		},
+		"failed to find test harnesses": {
+			getErr:    errors.New("api failed"),
+			expectErr: errors.New("failed to find test harnesses: api failed"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			getTestHarnesses := newMockGetTestHarnessesFunc(t)
+			getTestHarnesses.EXPECT().Execute(ctx, nil, tagFilters).Return(tt.harnesses, tt.getErr)
+			destroyListeners := newMockDestroyListenersFunc(t)
+			if len(tt.expectIDs) > 0 {
+				destroyListeners.EXPECT().
+					Execute(ctx, tt.expectIDs, mock.AnythingOfType("map[string][]string"), tt.dryRun).
+					RunAndReturn(func(ctx context.Context, ids []string, resourceARNs map[string][]string, dryRun bool) (listener.DestroyReport, error) {
+						report := listener.DestroyReport{}
+						for i, id := range ids {
+							assert.NotEmpty(t, resourceARNs[id])
+							result := listener.DestroyResult{ID: id, Status: tt.expectStatuses[i]}
+							if result.Status == listener.StatusFailed {
+								result.Err = errors.New("access denied")
+							}
+							report = append(report, result)
+						}
+						return report, report.Err()
+					})
+			}
+			opts := Options{
+				now:              func() time.Time { return now },
+				getTestHarnesses: getTestHarnesses.Execute,
+				destroyListeners: destroyListeners.Execute,
+			}
+
+			results, err := Collect(ctx, tagFilters, tt.maxAge, tt.dryRun, opts)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, results, len(tt.expectIDs))
+			for i, result := range results {
+				assert.Equal(t, tt.expectIDs[i], result.Harness.ID)
+				assert.Equal(t, tt.expectReasons[i], result.Reason)
+				assert.Equal(t, tt.expectStatuses[i], result.Status)
+			}
+		})
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	result := Result{
+		Harness: tags.TestHarness{
+			ID:      "iatk_eb_listener",
+			Type:    listener.TestHarnessType,
+			Created: time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC),
+		},
+		Reason: ReasonOrphaned,
+		DestroyResult: listener.DestroyResult{
+			ID:     "iatk_eb_listener",
+			Status: listener.StatusFailed,
+			Err:    errors.New("api failed"),
+		},
+	}
+	assert.Equal(t, Output{
+		ID:      "iatk_eb_listener",
+		Type:    "EventBridge.Listener",
+		Created: "2023-09-01T10:00:00Z",
+		Reason:  "Orphaned",
+		Status:  "Failed",
+		Error:   "api failed",
+	}, result.JSON())
+}
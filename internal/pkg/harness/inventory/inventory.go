@@ -0,0 +1,193 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package inventory lists and describes the existing test harnesses, as found through the system tags
+// of their resources.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"iatk/internal/pkg/harness"
+	"iatk/internal/pkg/harness/eventbridge/listener"
+	"iatk/internal/pkg/harness/tags"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/parallel"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+// Description describes a test harness.
+type Description struct {
+	Harness tags.TestHarness
+	// Components are the resources of the test harness that exist.
+	Components []harness.Resource
+	// ApproximateQueueDepth is the approximate number of events waiting in the queue of a Listener, nil
+	// for other test harnesses or if it has no queue.
+	ApproximateQueueDepth *int
+	// Err is the reason the test harness could not be described, if it failed.
+	Err error
+}
+
+// Output describes a test harness, as returned by RPC methods.
+type Output struct {
+	ID                    string             `json:"Id" required:"true"`
+	Type                  string             `json:"Type" required:"true"`
+	Target                string             `json:"Target" description:"ARN of the resource under test, e.g. the event bus of a Listener" required:"true"`
+	Created               string             `json:"Created,omitempty" description:"Creation time of the test harness, in RFC3339 format"`
+	Tags                  map[string]string  `json:"Tags" description:"Custom tags of the test harness" required:"true"`
+	Components            []harness.Resource `json:"Components" description:"Resources of the test harness that exist" required:"true"`
+	ApproximateQueueDepth *int               `json:"ApproximateQueueDepth,omitempty" description:"Approximate number of events waiting in the queue of a Listener"`
+	Error                 string             `json:"Error,omitempty" description:"Reason the test harness could not be described, if it failed"`
+}
+
+// JSON returns the description of d, as returned by RPC methods.
+func (d Description) JSON() Output {
+	out := Output{
+		ID:                    d.Harness.ID,
+		Type:                  d.Harness.Type,
+		Target:                d.Harness.Target,
+		Tags:                  d.Harness.Tags,
+		Components:            d.Components,
+		ApproximateQueueDepth: d.ApproximateQueueDepth,
+	}
+	if !d.Harness.Created.IsZero() {
+		out.Created = d.Harness.Created.Format(time.RFC3339)
+	}
+	if out.Tags == nil {
+		out.Tags = map[string]string{}
+	}
+	if out.Components == nil {
+		out.Components = []harness.Resource{}
+	}
+	if d.Err != nil {
+		out.Error = d.Err.Error()
+	}
+	return out
+}
+
+// NotFoundError tells that no test harness has the ID given to Describe.
+type NotFoundError struct {
+	ID string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("test harness %v not found", e.ID)
+}
+
+// NotFound tells RPC clients that the test harness was not found.
+func (e *NotFoundError) NotFound() bool {
+	return true
+}
+
+// Options for configuring dependency clients/funcs for List and Describe
+type Options struct {
+	tagsAPI tags.GetResourcesAPI
+	// maxConcurrency bounds the number of test harnesses described at once.
+	maxConcurrency int
+
+	// funcs
+	getTestHarnesses getTestHarnessesFunc
+	describeListener describeListenerFunc
+}
+
+func NewOptions(cfg aws.Config) Options {
+	return Options{
+		tagsAPI:        resourcegroupstaggingapi.NewFromConfig(cfg),
+		maxConcurrency: 5,
+
+		getTestHarnesses: tags.GetTestHarnesses,
+		describeListener: describeListener(listener.NewOptions(cfg)),
+	}
+}
+
+// List describes the test harnesses matching tagFilters, all iatk-created ones if there is none, in the
+// order the tagging API returns them. Test harnesses that cannot be described are listed with the error.
+func List(ctx context.Context, tagFilters []tagtypes.TagFilter, opts Options) ([]Description, error) {
+	harnesses, err := opts.getTestHarnesses(ctx, opts.tagsAPI, tagFilters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find test harnesses: %w", err)
+	}
+
+	descriptions := make([]Description, len(harnesses))
+	parallel.ForEach(ctx, len(harnesses), opts.maxConcurrency, func(i int) {
+		descriptions[i] = describe(ctx, harnesses[i], opts)
+	}, func(i int, err error) {
+		descriptions[i] = Description{Harness: harnesses[i], Err: err}
+	})
+
+	return descriptions, nil
+}
+
+// Describe describes the test harness of id. The error is a NotFoundError if there is none.
+func Describe(ctx context.Context, id string, opts Options) (*Description, error) {
+	harnesses, err := opts.getTestHarnesses(ctx, opts.tagsAPI, []tagtypes.TagFilter{
+		{Key: aws.String(string(tags.TestHarnessID)), Values: []string{id}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find test harness %v: %w", id, err)
+	}
+	if len(harnesses) == 0 {
+		return nil, &NotFoundError{ID: id}
+	}
+
+	d := describe(ctx, harnesses[0], opts)
+	if d.Err != nil {
+		return nil, d.Err
+	}
+	return &d, nil
+}
+
+func describe(ctx context.Context, h tags.TestHarness, opts Options) Description {
+	d := Description{Harness: h}
+	if h.Type != listener.TestHarnessType {
+		// only their ARNs are known
+		for _, a := range h.ResourceARNs {
+			d.Components = append(d.Components, harness.Resource{ARN: a})
+		}
+		return d
+	}
+	d.Components, d.ApproximateQueueDepth, d.Err = opts.describeListener(ctx, h.ID, h.ResourceARNs)
+	if d.Err != nil {
+		logging.FromContext(ctx).Warn("cannot describe eb listener", logging.ListenerIDKey, h.ID, "error", d.Err)
+	}
+	return d
+}
+
+func describeListener(listenerOpts listener.Options) describeListenerFunc {
+	return func(ctx context.Context, id string, resourceARNs []string) ([]harness.Resource, *int, error) {
+		lr, err := listener.Recover(ctx, id, resourceARNs, listenerOpts)
+		var errNotFound *listener.NotFoundError
+		if errors.As(err, &errNotFound) {
+			// the tagging API still returns resources deleted recently
+			return []harness.Resource{}, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		components := lr.Components()
+		for _, c := range components {
+			if c.Type != "AWS::SQS::Queue" {
+				continue
+			}
+			depth, err := lr.ApproximateDepth(ctx)
+			if err != nil {
+				return components, nil, err
+			}
+			return components, &depth, nil
+		}
+		return components, nil, nil
+	}
+}
+
+//go:generate mockery --name getTestHarnessesFunc
+type getTestHarnessesFunc func(ctx context.Context, api tags.GetResourcesAPI, tagFilters []tagtypes.TagFilter) ([]tags.TestHarness, error)
+
+//go:generate mockery --name describeListenerFunc
+type describeListenerFunc func(ctx context.Context, id string, resourceARNs []string) ([]harness.Resource, *int, error)
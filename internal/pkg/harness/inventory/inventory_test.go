@@ -0,0 +1,208 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"iatk/internal/pkg/harness"
+	"iatk/internal/pkg/harness/eventbridge/listener"
+	"iatk/internal/pkg/harness/tags"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testQueueARN = "arn:aws:sqs:us-west-2:123456789012:iatk_eb_listener"
+	testRuleARN  = "arn:aws:events:us-west-2:123456789012:rule/my-event-bus/iatk_eb_listener"
+)
+
+var testComponents = []harness.Resource{
+	{Type: "AWS::SQS::Queue", PhysicalID: "https://sqs/iatk_eb_listener", ARN: testQueueARN},
+	{Type: "AWS::Events::Rule", PhysicalID: "iatk_eb_listener", ARN: testRuleARN},
+}
+
+func testHarness(id string) tags.TestHarness {
+	return tags.TestHarness{
+		ID:           id,
+		Type:         listener.TestHarnessType,
+		Target:       "arn:aws:events:us-west-2:123456789012:event-bus/my-event-bus",
+		Created:      time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC),
+		Tags:         map[string]string{"foo": "bar"},
+		ResourceARNs: []string{testQueueARN, testRuleARN},
+	}
+}
+
+func TestList(t *testing.T) {
+	depth := 2
+	tagFilters := []tagtypes.TagFilter{{Key: aws.String("foo"), Values: []string{"bar"}}}
+	cases := map[string]struct {
+		harnesses            []tags.TestHarness
+		getErr               error
+		mockDescribeListener func(ctx context.Context) *mockDescribeListenerFunc
+		expect               []Description
+		expectErr            error
+	}{
+		"success": {
+			harnesses: []tags.TestHarness{
+				testHarness("listener-1"),
+				testHarness("listener-2"),
+				{ID: "other", Type: "Other", ResourceARNs: []string{"arn-1"}},
+			},
+			mockDescribeListener: func(ctx context.Context) *mockDescribeListenerFunc {
+				mock := newMockDescribeListenerFunc(t)
+				mock.EXPECT().Execute(ctx, "listener-1", []string{testQueueARN, testRuleARN}).Return(testComponents, &depth, nil)
+				mock.EXPECT().Execute(ctx, "listener-2", []string{testQueueARN, testRuleARN}).Return(nil, nil, errors.New("access denied"))
+				return mock
+			},
+			expect: []Description{
+				{Harness: testHarness("listener-1"), Components: testComponents, ApproximateQueueDepth: &depth},
+				{Harness: testHarness("listener-2"), Err: errors.New("access denied")},
+				{
+					Harness:    tags.TestHarness{ID: "other", Type: "Other", ResourceARNs: []string{"arn-1"}},
+					Components: []harness.Resource{{ARN: "arn-1"}},
+				},
+			},
+		},
+		"failed to find test harnesses": {
+			getErr: errors.New("api failed"),
+			mockDescribeListener: func(ctx context.Context) *mockDescribeListenerFunc {
+				return newMockDescribeListenerFunc(t)
+			},
+			expectErr: errors.New("failed to find test harnesses: api failed"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			getTestHarnesses := newMockGetTestHarnessesFunc(t)
+			getTestHarnesses.EXPECT().Execute(ctx, nil, tagFilters).Return(tt.harnesses, tt.getErr)
+			opts := Options{
+				maxConcurrency:   2,
+				getTestHarnesses: getTestHarnesses.Execute,
+				describeListener: tt.mockDescribeListener(ctx).Execute,
+			}
+
+			actual, err := List(ctx, tagFilters, opts)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestListCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	harnesses := []tags.TestHarness{testHarness("listener-1"), testHarness("listener-2")}
+	opts := Options{
+		maxConcurrency: 1,
+		getTestHarnesses: func(ctx context.Context, api tags.GetResourcesAPI, tagFilters []tagtypes.TagFilter) ([]tags.TestHarness, error) {
+			return harnesses, nil
+		},
+		describeListener: func(ctx context.Context, id string, resourceARNs []string) ([]harness.Resource, *int, error) {
+			cancel()
+			return testComponents, nil, nil
+		},
+	}
+
+	actual, err := List(ctx, nil, opts)
+
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+	// the first test harness was described, the second not attempted
+	assert.Equal(t, Description{Harness: harnesses[0], Components: testComponents}, actual[0])
+	assert.Equal(t, harnesses[1], actual[1].Harness)
+	assert.ErrorIs(t, actual[1].Err, context.Canceled)
+}
+
+func TestDescribe(t *testing.T) {
+	depth := 0
+	cases := map[string]struct {
+		harnesses            []tags.TestHarness
+		mockDescribeListener func(ctx context.Context) *mockDescribeListenerFunc
+		expect               *Description
+		expectErr            error
+	}{
+		"success": {
+			harnesses: []tags.TestHarness{testHarness("listener-1")},
+			mockDescribeListener: func(ctx context.Context) *mockDescribeListenerFunc {
+				mock := newMockDescribeListenerFunc(t)
+				mock.EXPECT().Execute(ctx, "listener-1", []string{testQueueARN, testRuleARN}).Return(testComponents, &depth, nil)
+				return mock
+			},
+			expect: &Description{Harness: testHarness("listener-1"), Components: testComponents, ApproximateQueueDepth: &depth},
+		},
+		"not found": {
+			harnesses: []tags.TestHarness{},
+			mockDescribeListener: func(ctx context.Context) *mockDescribeListenerFunc {
+				return newMockDescribeListenerFunc(t)
+			},
+			expectErr: &NotFoundError{ID: "listener-1"},
+		},
+		"failed to describe": {
+			harnesses: []tags.TestHarness{testHarness("listener-1")},
+			mockDescribeListener: func(ctx context.Context) *mockDescribeListenerFunc {
+				mock := newMockDescribeListenerFunc(t)
+				mock.EXPECT().Execute(ctx, "listener-1", []string{testQueueARN, testRuleARN}).Return(nil, nil, errors.New("access denied"))
+				return mock
+			},
+			expectErr: errors.New("access denied"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			getTestHarnesses := newMockGetTestHarnessesFunc(t)
+			getTestHarnesses.EXPECT().
+				Execute(ctx, nil, []tagtypes.TagFilter{{Key: aws.String("iatk:TestHarness:ID"), Values: []string{"listener-1"}}}).
+				Return(tt.harnesses, nil)
+			opts := Options{
+				getTestHarnesses: getTestHarnesses.Execute,
+				describeListener: tt.mockDescribeListener(ctx).Execute,
+			}
+
+			actual, err := Describe(ctx, "listener-1", opts)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestDescriptionJSON(t *testing.T) {
+	depth := 3
+	d := Description{Harness: testHarness("listener-1"), Components: testComponents, ApproximateQueueDepth: &depth}
+	assert.Equal(t, Output{
+		ID:                    "listener-1",
+		Type:                  "EventBridge.Listener",
+		Target:                "arn:aws:events:us-west-2:123456789012:event-bus/my-event-bus",
+		Created:               "2023-09-01T10:00:00Z",
+		Tags:                  map[string]string{"foo": "bar"},
+		Components:            testComponents,
+		ApproximateQueueDepth: &depth,
+	}, d.JSON())
+
+	// empty fields are still listed, and failures reported
+	d = Description{Harness: tags.TestHarness{ID: "listener-2"}, Err: errors.New("access denied")}
+	assert.Equal(t, Output{
+		ID:         "listener-2",
+		Tags:       map[string]string{},
+		Components: []harness.Resource{},
+		Error:      "access denied",
+	}, d.JSON())
+}
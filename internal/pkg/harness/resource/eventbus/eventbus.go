@@ -35,7 +35,7 @@ func Get(ctx context.Context, api DescribeEventBusAPI, name string) (*EventBus,
 		Name: aws.String(name),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("cannot get event bus %q: %v", name, err)
+		return nil, fmt.Errorf("cannot get event bus %q: %w", name, err)
 	}
 
 	busARN, _ := arn.Parse(aws.ToString(output.Arn))
@@ -5,15 +5,19 @@ package eventrule
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"iatk/internal/pkg/harness"
 	"iatk/internal/pkg/harness/resource/queue"
-	"log"
+	"iatk/internal/pkg/logging"
+	"reflect"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type InputTransformer struct {
@@ -51,7 +55,7 @@ func ListTargetsByRule(ctx context.Context, api EbListTargetsByRuleAPI, targetId
 		output, err := api.ListTargetsByRule(ctx, &input)
 
 		if err != nil {
-			return nil, fmt.Errorf("ListTargetsByRule for Rule: %q failed: %v", ruleName, err)
+			return nil, fmt.Errorf("ListTargetsByRule for Rule: %q failed: %w", ruleName, err)
 		}
 
 		targetLen := len(output.Targets)
@@ -76,7 +80,7 @@ func ListTargetsByRule(ctx context.Context, api EbListTargetsByRuleAPI, targetId
 
 func Create(ctx context.Context, api EbPutRuleAPI, ruleName, eventBusName, eventPattern, description string, tags map[string]string) (*Rule, error) {
 
-	log.Printf("creating event rule %q", ruleName)
+	logging.FromContext(ctx).Info("creating event rule", "rule_name", ruleName)
 	output, err := api.PutRule(ctx, &eventbridge.PutRuleInput{
 		Name:         aws.String(ruleName),
 		Description:  aws.String(description),
@@ -86,11 +90,11 @@ func Create(ctx context.Context, api EbPutRuleAPI, ruleName, eventBusName, event
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("put rule %q failed: %v", ruleName, err)
+		return nil, fmt.Errorf("put rule %q failed: %w", ruleName, err)
 	}
 
 	arn, _ := arn.Parse(aws.ToString(output.RuleArn))
-	log.Printf("created event rule %q", arn)
+	logging.FromContext(ctx).Info("created event rule", "arn", arn.String())
 	return &Rule{
 		Name:         ruleName,
 		EventBusName: eventBusName,
@@ -111,7 +115,7 @@ func PutQueueTarget(ctx context.Context, api EbPutTargetsAPI, listenerID string,
 		targetOverride.InputTransformer = target.InputTransformer
 	}
 
-	log.Printf("put sqs queue %q as target", qu.QueueURL)
+	logging.FromContext(ctx).Info("putting sqs queue as rule target", "queue_url", qu.QueueURL, "rule_name", ru.Name)
 	_, err := api.PutTargets(ctx, &eventbridge.PutTargetsInput{
 		Rule:         aws.String(ru.Name),
 		EventBusName: aws.String(ru.EventBusName),
@@ -122,7 +126,7 @@ func PutQueueTarget(ctx context.Context, api EbPutTargetsAPI, listenerID string,
 		return fmt.Errorf("put rule target failed: %w", err)
 	}
 
-	log.Printf("put rule target complete")
+	logging.FromContext(ctx).Info("put rule target complete", "rule_name", ru.Name)
 	return nil
 }
 
@@ -132,7 +136,7 @@ func Delete(ctx context.Context, api EbDeleteRuleAPI, eventBusName, ruleName str
 		EventBusName: aws.String(eventBusName),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete rule %q: %v", ruleName, err)
+		return fmt.Errorf("failed to delete rule %q: %w", ruleName, err)
 	}
 
 	var ids []string
@@ -146,21 +150,21 @@ func Delete(ctx context.Context, api EbDeleteRuleAPI, eventBusName, ruleName str
 			EventBusName: aws.String(eventBusName),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to delete rule %q: %v", ruleName, err)
+			return fmt.Errorf("failed to delete rule %q: %w", ruleName, err)
 		}
 	}
 
-	log.Printf("deleting rule %q of event bus %q", ruleName, eventBusName)
+	logging.FromContext(ctx).Info("deleting rule", "rule_name", ruleName, "event_bus_name", eventBusName)
 	_, err = api.DeleteRule(ctx, &eventbridge.DeleteRuleInput{
 		Name:         aws.String(ruleName),
 		EventBusName: aws.String(eventBusName),
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to delete rule %q: %v", ruleName, err)
+		return fmt.Errorf("failed to delete rule %q: %w", ruleName, err)
 	}
 
-	log.Printf("deleted rule %q", ruleName)
+	logging.FromContext(ctx).Info("deleted rule", "rule_name", ruleName)
 	return nil
 }
 
@@ -170,7 +174,7 @@ func Get(ctx context.Context, api EbDescribeRuleAPI, ruleName, eventBusName stri
 		EventBusName: aws.String(eventBusName),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe rule %q of event bus %q: %v", ruleName, eventBusName, err)
+		return nil, fmt.Errorf("failed to describe rule %q of event bus %q: %w", ruleName, eventBusName, err)
 	}
 	arn, _ := arn.Parse(aws.ToString(output.Arn))
 	return &Rule{
@@ -181,12 +185,15 @@ func Get(ctx context.Context, api EbDescribeRuleAPI, ruleName, eventBusName stri
 	}, nil
 }
 
+// tagsToEBTags returns tags sorted by key, so that the same tags always make the same request.
 func tagsToEBTags(tags map[string]string) []ebtypes.Tag {
+	keys := maps.Keys(tags)
+	slices.Sort(keys)
 	var out []ebtypes.Tag
-	for key, val := range tags {
+	for _, key := range keys {
 		out = append(out, ebtypes.Tag{
 			Key:   aws.String(key),
-			Value: aws.String(val),
+			Value: aws.String(tags[key]),
 		})
 	}
 	return out
@@ -218,3 +225,85 @@ type EbDeleteRuleAPI interface {
 type EbPutTargetsAPI interface {
 	PutTargets(ctx context.Context, params *eventbridge.PutTargetsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutTargetsOutput, error)
 }
+
+// MergeEventPatterns merges event patterns into one that matches the events matched by all of them.
+// Fields found in several patterns are merged recursively, and lists of values are intersected. Lists
+// holding content filters, e.g. {"prefix": "foo"}, cannot be merged unless they are identical.
+func MergeEventPatterns(patterns ...string) (string, error) {
+	merged := map[string]interface{}{}
+	for _, p := range patterns {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(p), &m); err != nil {
+			return "", fmt.Errorf("invalid event pattern %q: %w", p, err)
+		}
+		if err := mergeEventPattern(merged, m, ""); err != nil {
+			return "", err
+		}
+	}
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event pattern: %w", err)
+	}
+	return string(b), nil
+}
+
+func mergeEventPattern(dst, src map[string]interface{}, path string) error {
+	for key, sv := range src {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		dv, ok := dst[key]
+		if !ok {
+			dst[key] = sv
+			continue
+		}
+		switch d := dv.(type) {
+		case map[string]interface{}:
+			s, ok := sv.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("cannot merge event patterns at %q: mismatched types", fieldPath)
+			}
+			if err := mergeEventPattern(d, s, fieldPath); err != nil {
+				return err
+			}
+		case []interface{}:
+			s, ok := sv.([]interface{})
+			if !ok {
+				return fmt.Errorf("cannot merge event patterns at %q: mismatched types", fieldPath)
+			}
+			values, err := intersectValues(d, s, fieldPath)
+			if err != nil {
+				return err
+			}
+			dst[key] = values
+		default:
+			return fmt.Errorf("cannot merge event patterns at %q: mismatched types", fieldPath)
+		}
+	}
+	return nil
+}
+
+func intersectValues(a, b []interface{}, path string) ([]interface{}, error) {
+	if reflect.DeepEqual(a, b) {
+		return a, nil
+	}
+	for _, v := range append(append([]interface{}{}, a...), b...) {
+		if _, ok := v.(map[string]interface{}); ok {
+			return nil, fmt.Errorf("cannot merge event patterns at %q: content filters differ", path)
+		}
+	}
+	values := []interface{}{}
+	for _, v := range a {
+		for _, w := range b {
+			if v == w {
+				values = append(values, v)
+				break
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("cannot merge event patterns at %q: no value in common", path)
+	}
+	return values, nil
+}
@@ -611,3 +611,91 @@ func TestRule_Resource(t *testing.T) {
 	}
 	assert.Equal(t, rule.Resource(), harness.Resource{Type: "AWS::Events::Rule", PhysicalID: testRuleName, ARN: rule.ARN.String()})
 }
+
+func TestMergeEventPatterns(t *testing.T) {
+	cases := map[string]struct {
+		patterns  []string
+		expect    string
+		expectErr error
+	}{
+		"single pattern": {
+			patterns: []string{`{"source":["my.app"]}`},
+			expect:   `{"source":["my.app"]}`,
+		},
+		"adds fields": {
+			patterns: []string{
+				`{"source":["my.app"]}`,
+				`{"detail-type":["OrderPlaced"],"detail":{"status":["paid"]}}`,
+			},
+			expect: `{"detail":{"status":["paid"]},"detail-type":["OrderPlaced"],"source":["my.app"]}`,
+		},
+		"merges nested fields": {
+			patterns: []string{
+				`{"detail":{"status":["paid"]}}`,
+				`{"detail":{"amount":[{"numeric":[">",100]}]}}`,
+			},
+			expect: `{"detail":{"amount":[{"numeric":[">",100]}],"status":["paid"]}}`,
+		},
+		"intersects values": {
+			patterns: []string{
+				`{"source":["my.app","other.app"]}`,
+				`{"source":["my.app","third.app"]}`,
+			},
+			expect: `{"source":["my.app"]}`,
+		},
+		"identical content filters": {
+			patterns: []string{
+				`{"source":[{"prefix":"my."}]}`,
+				`{"source":[{"prefix":"my."}]}`,
+			},
+			expect: `{"source":[{"prefix":"my."}]}`,
+		},
+		"no value in common": {
+			patterns: []string{
+				`{"source":["my.app"]}`,
+				`{"source":["other.app"]}`,
+			},
+			expectErr: errors.New(`cannot merge event patterns at "source": no value in common`),
+		},
+		"different content filters": {
+			patterns: []string{
+				`{"detail":{"status":[{"prefix":"p"}]}}`,
+				`{"detail":{"status":["paid"]}}`,
+			},
+			expectErr: errors.New(`cannot merge event patterns at "detail.status": content filters differ`),
+		},
+		"mismatched types": {
+			patterns: []string{
+				`{"detail":{"status":["paid"]}}`,
+				`{"detail":["paid"]}`,
+			},
+			expectErr: errors.New(`cannot merge event patterns at "detail": mismatched types`),
+		},
+		"invalid pattern": {
+			patterns:  []string{`["my.app"]`},
+			expectErr: errors.New(`invalid event pattern "[\"my.app\"]": json: cannot unmarshal array into Go value of type map[string]interface {}`),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := MergeEventPatterns(tt.patterns...)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.expect, actual)
+			}
+		})
+	}
+}
+
+func TestTagsToEBTags(t *testing.T) {
+	tags := tagsToEBTags(map[string]string{"b": "2", "c": "3", "a": "1"})
+
+	assert.Equal(t, []ebtypes.Tag{
+		{Key: aws.String("a"), Value: aws.String("1")},
+		{Key: aws.String("b"), Value: aws.String("2")},
+		{Key: aws.String("c"), Value: aws.String("3")},
+	}, tags)
+}
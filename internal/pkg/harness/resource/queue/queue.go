@@ -8,7 +8,8 @@ import (
 	"fmt"
 	"iatk/internal/pkg/harness"
 	"iatk/internal/pkg/harness/tags"
-	"log"
+	"iatk/internal/pkg/logging"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
@@ -37,7 +38,7 @@ type Options struct {
 }
 
 func Create(ctx context.Context, api CreateQueueAPI, name string, tags map[string]string, opts Options) (*Queue, error) {
-	log.Printf("start create queue %q", name)
+	logging.FromContext(ctx).Info("creating queue", "queue_name", name)
 	output, err := api.CreateQueue(ctx, &sqs.CreateQueueInput{
 		QueueName: aws.String(name),
 		Tags:      tags,
@@ -48,7 +49,7 @@ func Create(ctx context.Context, api CreateQueueAPI, name string, tags map[strin
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("create queue %q failed: %v", name, err)
+		return nil, fmt.Errorf("create queue %q failed: %w", name, err)
 	}
 
 	attrs, err := api.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
@@ -58,12 +59,12 @@ func Create(ctx context.Context, api CreateQueueAPI, name string, tags map[strin
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("get queue attributes failed: %v", err)
+		return nil, fmt.Errorf("get queue attributes failed: %w", err)
 	}
 	queueARN, _ := arn.Parse(attrs.Attributes["QueueArn"])
 
 	queueURL := aws.ToString(output.QueueUrl)
-	log.Printf("created queue %q", queueURL)
+	logging.FromContext(ctx).Info("created queue", "queue_url", queueURL)
 	return &Queue{
 		Name:     name,
 		QueueURL: queueURL,
@@ -83,16 +84,16 @@ type DeleteQueueAPI interface {
 }
 
 func Delete(ctx context.Context, api DeleteQueueAPI, queueURL string) error {
-	log.Printf("deleting queue %q", queueURL)
+	logging.FromContext(ctx).Info("deleting queue", "queue_url", queueURL)
 	_, err := api.DeleteQueue(ctx, &sqs.DeleteQueueInput{
 		QueueUrl: aws.String(queueURL),
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to delete queue %q: %v", queueURL, err)
+		return fmt.Errorf("failed to delete queue %q: %w", queueURL, err)
 	}
 
-	log.Printf("deleted queue %q", queueURL)
+	logging.FromContext(ctx).Info("deleted queue", "queue_url", queueURL)
 	return nil
 }
 
@@ -107,7 +108,7 @@ func GetWithName(ctx context.Context, api GetQueueUrlAPI, name string) (*Queue,
 		QueueName: aws.String(name),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get queue with name %q: %v", name, err)
+		return nil, fmt.Errorf("failed to get queue with name %q: %w", name, err)
 	}
 
 	attrs, err := api.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
@@ -117,7 +118,7 @@ func GetWithName(ctx context.Context, api GetQueueUrlAPI, name string) (*Queue,
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get queue attributes: %v", err)
+		return nil, fmt.Errorf("failed to get queue attributes: %w", err)
 	}
 	queueARN, _ := arn.Parse(attrs.Attributes["QueueArn"])
 
@@ -128,6 +129,29 @@ func GetWithName(ctx context.Context, api GetQueueUrlAPI, name string) (*Queue,
 	}, nil
 }
 
+//go:generate mockery --name GetQueueAttributesAPI
+type GetQueueAttributesAPI interface {
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+}
+
+// GetDepth returns the approximate number of messages available for retrieval from the queue.
+func GetDepth(ctx context.Context, api GetQueueAttributesAPI, queueURL string) (int, error) {
+	attrs, err := api.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []sqstypes.QueueAttributeName{
+			sqstypes.QueueAttributeNameApproximateNumberOfMessages,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue attributes: %w", err)
+	}
+	depth, err := strconv.Atoi(attrs.Attributes[string(sqstypes.QueueAttributeNameApproximateNumberOfMessages)])
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse approximate number of messages of queue %q: %w", queueURL, err)
+	}
+	return depth, nil
+}
+
 //go:generate mockery --name ListQueueTagsAPI
 type ListQueueTagsAPI interface {
 	ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error)
@@ -138,7 +162,7 @@ func GetEventBusNameFromQueue(ctx context.Context, api ListQueueTagsAPI, queueUR
 		QueueUrl: aws.String(queueURL),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to list queue tags: %v", err)
+		return "", fmt.Errorf("failed to list queue tags: %w", err)
 	}
 	if val, ok := output.Tags[string(tags.TestHarnessTarget)]; ok {
 		return val, nil
@@ -155,3 +179,8 @@ type ReceiveMessageAPI interface {
 type DeleteMessageBatchAPI interface {
 	DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
 }
+
+//go:generate mockery --name ChangeMessageVisibilityBatchAPI
+type ChangeMessageVisibilityBatchAPI interface {
+	ChangeMessageVisibilityBatch(ctx context.Context, params *sqs.ChangeMessageVisibilityBatchInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityBatchOutput, error)
+}
@@ -350,6 +350,59 @@ func TestGetEventBusNameFromQueue(t *testing.T) {
 	}
 }
 
+func TestGetDepth(t *testing.T) {
+	cases := map[string]struct {
+		mockAPI   func(ctx context.Context) *MockGetQueueAttributesAPI
+		expect    int
+		expectErr error
+	}{
+		"success": {
+			expect: 3,
+			mockAPI: func(ctx context.Context) *MockGetQueueAttributesAPI {
+				api := NewMockGetQueueAttributesAPI(t)
+				api.EXPECT().GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+					QueueUrl: aws.String(testQueueURL),
+					AttributeNames: []sqstypes.QueueAttributeName{
+						sqstypes.QueueAttributeNameApproximateNumberOfMessages,
+					},
+				}).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: map[string]string{"ApproximateNumberOfMessages": "3"},
+				}, nil)
+				return api
+			},
+		},
+		"should return error if GetQueueAttributes failed": {
+			expectErr: errors.New("failed to get queue attributes: something failed"),
+			mockAPI: func(ctx context.Context) *MockGetQueueAttributesAPI {
+				api := NewMockGetQueueAttributesAPI(t)
+				api.EXPECT().GetQueueAttributes(ctx, mock.AnythingOfType("*sqs.GetQueueAttributesInput")).Return(nil, errors.New("something failed"))
+				return api
+			},
+		},
+		"should return error if attribute is missing": {
+			expectErr: errors.New(`failed to parse approximate number of messages of queue "` + testQueueURL + `": strconv.Atoi: parsing "": invalid syntax`),
+			mockAPI: func(ctx context.Context) *MockGetQueueAttributesAPI {
+				api := NewMockGetQueueAttributesAPI(t)
+				api.EXPECT().GetQueueAttributes(ctx, mock.AnythingOfType("*sqs.GetQueueAttributesInput")).Return(&sqs.GetQueueAttributesOutput{}, nil)
+				return api
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			depth, err := GetDepth(ctx, tt.mockAPI(ctx), testQueueURL)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, depth)
+			}
+		})
+	}
+}
+
 func TestQueue_Resource(t *testing.T) {
 	queue := &Queue{
 		Name:     testQueueName,
@@ -6,12 +6,15 @@ package tags
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"iatk/internal/pkg/slice"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"golang.org/x/exp/slices"
 )
 
 type SystemTagKey string
@@ -21,11 +24,13 @@ const (
 	TestHarnessType    SystemTagKey = "iatk:TestHarness:Type"
 	TestHarnessTarget  SystemTagKey = "iatk:TestHarness:Target"
 	TestHarnessCreated SystemTagKey = "iatk:TestHarness:Created"
+	// TestHarnessTTL is the time-to-live of a test harness in seconds, after which gc destroys it.
+	TestHarnessTTL SystemTagKey = "iatk:TestHarness:TTL"
 )
 
 // Validates if a given tags contains any reserved key
 func ValidateTags(tags map[string]string) error {
-	for _, key := range []SystemTagKey{TestHarnessID, TestHarnessType, TestHarnessTarget, TestHarnessCreated} {
+	for _, key := range []SystemTagKey{TestHarnessID, TestHarnessType, TestHarnessTarget, TestHarnessCreated, TestHarnessTTL} {
 		if _, ok := tags[string(key)]; ok {
 			return fmt.Errorf("reserved tag key %q found in provided tags", key)
 		}
@@ -46,7 +51,7 @@ func GetTargetByTestHarnessID(ctx context.Context, api GetResourcesAPI, id strin
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
-			return "", fmt.Errorf("failed to get resources: %v", err)
+			return "", fmt.Errorf("failed to get resources: %w", err)
 		}
 
 		resources = append(resources, output.ResourceTagMappingList...)
@@ -83,7 +88,7 @@ func GetTargetByTestHarnessID(ctx context.Context, api GetResourcesAPI, id strin
 func GetTestHarnessIDsWithTagFilters(ctx context.Context, api GetResourcesAPI, tagFilters []tagtypes.TagFilter) ([]string, error) {
 	if !hasOneSystemTagKey(tagFilters) {
 		// NOTE (hawflau): to make sure only iatk-created resources will be found
-		tagFilters = append(tagFilters, tagtypes.TagFilter{Key: aws.String(string(TestHarnessID))})
+		tagFilters = append(slices.Clone(tagFilters), tagtypes.TagFilter{Key: aws.String(string(TestHarnessID))})
 	}
 
 	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(api, &resourcegroupstaggingapi.GetResourcesInput{
@@ -94,7 +99,7 @@ func GetTestHarnessIDsWithTagFilters(ctx context.Context, api GetResourcesAPI, t
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get resources: %v", err)
+			return nil, fmt.Errorf("failed to get resources: %w", err)
 		}
 		for _, r := range output.ResourceTagMappingList {
 			ids = append(ids, extractTestHarnessIDFromTags(r.Tags)...)
@@ -104,6 +109,85 @@ func GetTestHarnessIDsWithTagFilters(ctx context.Context, api GetResourcesAPI, t
 	return slice.Dedup(ids), nil
 }
 
+// TestHarness is a test harness found through the system tags of its resources.
+type TestHarness struct {
+	ID     string
+	Type   string
+	Target string
+	// Created is the creation time of the test harness, zero if its tag is missing or invalid.
+	Created time.Time
+	// TTL is the time-to-live of the test harness, zero if it has none or its tag is invalid.
+	TTL time.Duration
+	// Tags are the custom tags of the test harness, without the system ones.
+	Tags map[string]string
+	// ResourceARNs are the ARNs of the resources of the test harness. The tagging API may still return
+	// resources deleted recently.
+	ResourceARNs []string
+}
+
+// Find all test harnesses with resources matching the provided tag filters, in the order the tagging API
+// returns their first resource
+func GetTestHarnesses(ctx context.Context, api GetResourcesAPI, tagFilters []tagtypes.TagFilter) ([]TestHarness, error) {
+	if !hasOneSystemTagKey(tagFilters) {
+		// only iatk-created resources
+		tagFilters = append(slices.Clone(tagFilters), tagtypes.TagFilter{Key: aws.String(string(TestHarnessID))})
+	}
+
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(api, &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: tagFilters,
+	})
+
+	harnesses := []TestHarness{}
+	index := map[string]int{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resources: %w", err)
+		}
+		for _, r := range output.ResourceTagMappingList {
+			ids := extractTestHarnessIDFromTags(r.Tags)
+			if len(ids) == 0 {
+				continue
+			}
+			i, ok := index[ids[0]]
+			if !ok {
+				i = len(harnesses)
+				index[ids[0]] = i
+				harnesses = append(harnesses, TestHarness{ID: ids[0], Tags: map[string]string{}})
+			}
+			h := &harnesses[i]
+			h.ResourceARNs = append(h.ResourceARNs, aws.ToString(r.ResourceARN))
+			for _, tag := range r.Tags {
+				key, value := aws.ToString(tag.Key), aws.ToString(tag.Value)
+				switch key {
+				case string(TestHarnessID):
+				case string(TestHarnessType):
+					h.Type = value
+				case string(TestHarnessTarget):
+					h.Target = value
+				case string(TestHarnessCreated):
+					if created, err := time.Parse(time.RFC3339, value); err == nil {
+						h.Created = created
+					}
+				case string(TestHarnessTTL):
+					if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+						h.TTL = time.Duration(seconds) * time.Second
+					}
+				default:
+					h.Tags[key] = value
+				}
+			}
+		}
+	}
+
+	return harnesses, nil
+}
+
+// Expired tells whether the test harness h has a TTL that has passed at now.
+func (h TestHarness) Expired(now time.Time) bool {
+	return h.TTL > 0 && !h.Created.IsZero() && !now.Before(h.Created.Add(h.TTL))
+}
+
 //go:generate mockery --name GetResourcesAPI
 type GetResourcesAPI interface {
 	GetResources(context.Context, *resourcegroupstaggingapi.GetResourcesInput, ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
@@ -113,7 +197,7 @@ func hasOneSystemTagKey(l []tagtypes.TagFilter) bool {
 	for _, f := range l {
 		key := aws.ToString(f.Key)
 		switch key {
-		case string(TestHarnessID), string(TestHarnessType), string(TestHarnessTarget), string(TestHarnessCreated):
+		case string(TestHarnessID), string(TestHarnessType), string(TestHarnessTarget), string(TestHarnessCreated), string(TestHarnessTTL):
 			return true
 		}
 	}
@@ -8,11 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestValidateTags(t *testing.T) {
@@ -40,6 +42,10 @@ func TestValidateTags(t *testing.T) {
 			input:     map[string]string{"iatk:TestHarness:Created": "123"},
 			expectErr: errors.New(`reserved tag key "iatk:TestHarness:Created" found in provided tags`),
 		},
+		"should return error if iatk:TestHarness:TTL is one of the provided keys": {
+			input:     map[string]string{"iatk:TestHarness:TTL": "3600"},
+			expectErr: errors.New(`reserved tag key "iatk:TestHarness:TTL" found in provided tags`),
+		},
 	}
 
 	for name, tt := range cases {
@@ -378,6 +384,12 @@ func Test_hasOneSystemTagKey(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			input: []tagtypes.TagFilter{
+				{Key: aws.String("iatk:TestHarness:TTL"), Values: []string{"val1", "val2"}},
+			},
+			expect: true,
+		},
 		{
 			input: []tagtypes.TagFilter{
 				{Key: aws.String("iatk:TestHarness:ID"), Values: []string{"val1", "val2"}},
@@ -426,3 +438,158 @@ func Test_extractTestHarnessIDFromTags(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTestHarnesses(t *testing.T) {
+	created := time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC)
+	systemTags := func(id string, created string) []tagtypes.Tag {
+		return []tagtypes.Tag{
+			{Key: aws.String(string(TestHarnessID)), Value: aws.String(id)},
+			{Key: aws.String(string(TestHarnessTarget)), Value: aws.String("my-event-bus")},
+			{Key: aws.String(string(TestHarnessType)), Value: aws.String("EventBridge.Listener")},
+			{Key: aws.String(string(TestHarnessCreated)), Value: aws.String(created)},
+			{Key: aws.String("foo"), Value: aws.String("bar")},
+		}
+	}
+	cases := map[string]struct {
+		mockGetResourcesAPI func(ctx context.Context) *MockGetResourcesAPI
+		tagFilters          []tagtypes.TagFilter
+		expect              []TestHarness
+		expectErr           error
+	}{
+		"groups resources by test harness": {
+			tagFilters: []tagtypes.TagFilter{
+				{Key: aws.String("foo"), Values: []string{"bar"}},
+			},
+			mockGetResourcesAPI: func(ctx context.Context) *MockGetResourcesAPI {
+				api := NewMockGetResourcesAPI(t)
+				api.EXPECT().
+					GetResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+						TagFilters: []tagtypes.TagFilter{
+							{Key: aws.String("foo"), Values: []string{"bar"}},
+							{Key: aws.String(string(TestHarnessID))},
+						},
+					}).
+					Return(&resourcegroupstaggingapi.GetResourcesOutput{
+						ResourceTagMappingList: []tagtypes.ResourceTagMapping{
+							{ResourceARN: aws.String("queue-arn-1"), Tags: systemTags("test-harness-id-1", created.Format(time.RFC3339))},
+							{ResourceARN: aws.String("rule-arn-2"), Tags: systemTags("test-harness-id-2", "not a time")},
+							{ResourceARN: aws.String("rule-arn-1"), Tags: systemTags("test-harness-id-1", created.Format(time.RFC3339))},
+							{
+								ResourceARN: aws.String("rule-arn-3"),
+								Tags: append(systemTags("test-harness-id-3", created.Format(time.RFC3339)),
+									tagtypes.Tag{Key: aws.String(string(TestHarnessTTL)), Value: aws.String("3600")}),
+							},
+						},
+					}, nil)
+				return api
+			},
+			expect: []TestHarness{
+				{
+					ID:           "test-harness-id-1",
+					Type:         "EventBridge.Listener",
+					Target:       "my-event-bus",
+					Created:      created,
+					Tags:         map[string]string{"foo": "bar"},
+					ResourceARNs: []string{"queue-arn-1", "rule-arn-1"},
+				},
+				{
+					ID:           "test-harness-id-2",
+					Type:         "EventBridge.Listener",
+					Target:       "my-event-bus",
+					Tags:         map[string]string{"foo": "bar"},
+					ResourceARNs: []string{"rule-arn-2"},
+				},
+				{
+					ID:           "test-harness-id-3",
+					Type:         "EventBridge.Listener",
+					Target:       "my-event-bus",
+					Created:      created,
+					TTL:          time.Hour,
+					Tags:         map[string]string{"foo": "bar"},
+					ResourceARNs: []string{"rule-arn-3"},
+				},
+			},
+		},
+		"api failed": {
+			mockGetResourcesAPI: func(ctx context.Context) *MockGetResourcesAPI {
+				api := NewMockGetResourcesAPI(t)
+				api.EXPECT().
+					GetResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+						TagFilters: []tagtypes.TagFilter{
+							{Key: aws.String(string(TestHarnessID))},
+						},
+					}).
+					Return(nil, errors.New("api failed"))
+				return api
+			},
+			expectErr: errors.New("failed to get resources: api failed"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			actual, err := GetTestHarnesses(ctx, tt.mockGetResourcesAPI(ctx), tt.tagFilters)
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, actual)
+			}
+		})
+	}
+}
+
+func TestTestHarness_Expired(t *testing.T) {
+	created := time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		harness TestHarness
+		now     time.Time
+		expect  bool
+	}{
+		"ttl passed": {
+			harness: TestHarness{Created: created, TTL: time.Hour},
+			now:     created.Add(time.Hour),
+			expect:  true,
+		},
+		"ttl not passed": {
+			harness: TestHarness{Created: created, TTL: time.Hour},
+			now:     created.Add(59 * time.Minute),
+			expect:  false,
+		},
+		"no ttl": {
+			harness: TestHarness{Created: created},
+			now:     created.Add(24 * time.Hour),
+			expect:  false,
+		},
+		"no creation time": {
+			harness: TestHarness{TTL: time.Hour},
+			now:     created,
+			expect:  false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.harness.Expired(tt.now))
+		})
+	}
+}
+
+func TestTagFiltersNotModified(t *testing.T) {
+	ctx := context.TODO()
+	api := NewMockGetResourcesAPI(t)
+	api.EXPECT().GetResources(ctx, mock.Anything).Return(&resourcegroupstaggingapi.GetResourcesOutput{}, nil)
+	// spare capacity, that appending to the filters would write into
+	backing := make([]tagtypes.TagFilter, 2)
+	backing[1] = tagtypes.TagFilter{Key: aws.String("bar")}
+	tagFilters := backing[:1]
+	tagFilters[0] = tagtypes.TagFilter{Key: aws.String("foo"), Values: []string{"bar"}}
+
+	_, err := GetTestHarnessIDsWithTagFilters(ctx, api, tagFilters)
+	assert.NoError(t, err)
+	_, err = GetTestHarnesses(ctx, api, tagFilters)
+	assert.NoError(t, err)
+
+	assert.Equal(t, tagtypes.TagFilter{Key: aws.String("bar")}, backing[1])
+}
@@ -4,7 +4,7 @@
 package harness
 
 type Resource struct {
-	Type       string `json:"Type"`
-	PhysicalID string `json:"PhysicalID"`
-	ARN        string `json:"ARN"`
+	Type       string `json:"Type" required:"true"`
+	PhysicalID string `json:"PhysicalID" required:"true"`
+	ARN        string `json:"ARN" required:"true"`
 }
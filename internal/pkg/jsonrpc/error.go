@@ -1,5 +1,17 @@
 package jsonrpc
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+)
+
 func ParseError(id *string) Response {
 	return Response{
 		JSONRPC: "2.0",
@@ -11,6 +23,17 @@ func ParseError(id *string) Response {
 	}
 }
 
+func InvalidRequestError(id *string) Response {
+	return Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: &ErrIatk{
+			Code:    -32600,
+			Message: "Invalid Request",
+		},
+	}
+}
+
 func NoMethodFoundError(id *string) Response {
 	return Response{
 		JSONRPC: "2.0",
@@ -43,3 +66,137 @@ func InvalidParamsError(id *string) Response {
 		},
 	}
 }
+
+// Error codes for failures of a method call. 10 predates the rest and is kept for any failure
+// that does not fall in one of the more specific categories.
+const (
+	ErrCodeIatk             = 10
+	ErrCodeThrottling       = 11
+	ErrCodeResourceNotFound = 12
+	ErrCodeAccessDenied     = 13
+	ErrCodeValidation       = 14
+	ErrCodeTimeout          = 15
+	ErrCodeCancelled        = 16
+)
+
+var (
+	accessDeniedErrorCodes = map[string]struct{}{
+		"AccessDenied":                {},
+		"AccessDeniedException":       {},
+		"AuthFailure":                 {},
+		"AuthorizationError":          {},
+		"ExpiredToken":                {},
+		"ExpiredTokenException":       {},
+		"InvalidClientTokenId":        {},
+		"InvalidSignatureException":   {},
+		"MissingAuthenticationToken":  {},
+		"SignatureDoesNotMatch":       {},
+		"UnauthorizedOperation":       {},
+		"UnrecognizedClientException": {},
+	}
+
+	notFoundErrorCodes = map[string]struct{}{
+		"AWS.SimpleQueueService.NonExistentQueue": {},
+		"NotFoundException":                       {},
+		"QueueDoesNotExist":                       {},
+		"ResourceNotFoundException":               {},
+	}
+
+	validationErrorCodes = map[string]struct{}{
+		"InvalidParameterCombination": {},
+		"InvalidParameterException":   {},
+		"InvalidParameterValue":       {},
+		"MissingParameter":            {},
+		"ValidationError":             {},
+		"ValidationException":         {},
+	}
+)
+
+// ServiceError describes err, returned by a method call, as a response with the matching error code.
+// Details of the AWS call that failed, if any, are added as data.
+func ServiceError(id *string, err error) Response {
+	message := err.Error()
+	var oe *smithy.OperationError
+	if errors.As(err, &oe) {
+		message = fmt.Sprintf("failed to call service: %s, operation: %s, error: %v", oe.Service(), oe.Operation(), oe.Unwrap())
+	}
+
+	return Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: &ErrIatk{
+			Code:    errorCode(err),
+			Message: message,
+			Data:    errorData(err),
+		},
+	}
+}
+
+func errorCode(err error) int {
+	if errors.Is(err, context.Canceled) {
+		return ErrCodeCancelled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrCodeTimeout
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return ErrCodeTimeout
+	}
+
+	var notFoundErr interface{ NotFound() bool }
+	if errors.As(err, &notFoundErr) && notFoundErr.NotFound() {
+		return ErrCodeResourceNotFound
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return ErrCodeIatk
+	}
+	code := apiErr.ErrorCode()
+	if _, ok := retry.DefaultThrottleErrorCodes[code]; ok {
+		return ErrCodeThrottling
+	}
+	if _, ok := accessDeniedErrorCodes[code]; ok {
+		return ErrCodeAccessDenied
+	}
+	if _, ok := notFoundErrorCodes[code]; ok {
+		return ErrCodeResourceNotFound
+	}
+	if _, ok := validationErrorCodes[code]; ok {
+		// NOTE: CloudFormation reports missing stacks as a ValidationError
+		if strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return ErrCodeResourceNotFound
+		}
+		return ErrCodeValidation
+	}
+	return ErrCodeIatk
+}
+
+func errorData(err error) *ErrData {
+	var oe *smithy.OperationError
+	if !errors.As(err, &oe) {
+		return nil
+	}
+
+	data := &ErrData{
+		Service:   oe.Service(),
+		Operation: oe.Operation(),
+		Retryable: retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary,
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		data.ErrorCode = apiErr.ErrorCode()
+	}
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		data.RequestID = respErr.ServiceRequestID()
+		data.HTTPStatusCode = respErr.HTTPStatusCode()
+	}
+	// errors of calls made with a config of iatk tell their number of attempts
+	var attemptsErr interface{ Attempts() int }
+	if errors.As(err, &attemptsErr) {
+		data.Attempts = attemptsErr.Attempts()
+	}
+	return data
+}
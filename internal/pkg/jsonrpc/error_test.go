@@ -1,8 +1,15 @@
 package jsonrpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,6 +22,15 @@ func TestParseError(t *testing.T) {
 	assert.Equal(t, "Parse error", errResponse.Error.Message, `ParseError.Error.Message should be "Parse error"`)
 }
 
+func TestInvalidRequestError(t *testing.T) {
+	id := "1"
+	errResponse := InvalidRequestError(&id)
+
+	assert.Equal(t, "1", *errResponse.ID, "InvalidRequestError.ID should match value passed in")
+	assert.Equal(t, -32600, errResponse.Error.Code, "InvalidRequestError.Error.Code should match -32600")
+	assert.Equal(t, "Invalid Request", errResponse.Error.Message, `InvalidRequestError.Error.Message should be "Invalid Request"`)
+}
+
 func TestNoMethodFound(t *testing.T) {
 	id := "1"
 	errResponse := NoMethodFoundError(&id)
@@ -41,3 +57,157 @@ func TestInvalidParamsError(t *testing.T) {
 	assert.Equal(t, -32602, errResponse.Error.Code, "InternalServiceError.Error.Code should match -32602")
 	assert.Equal(t, "Invalid params", errResponse.Error.Message, `InternalServiceError.Error.Message should be "Invalid params"`)
 }
+
+func apiOperationError(code, message string, statusCode int) error {
+	return &smithy.OperationError{
+		ServiceID:     "SQS",
+		OperationName: "GetQueueUrl",
+		Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: statusCode}},
+				Err:      &smithy.GenericAPIError{Code: code, Message: message},
+			},
+			RequestID: "request-id",
+		},
+	}
+}
+
+// attemptsError tells the number of attempts of a call, like the errors of calls made with a config of iatk.
+type attemptsError struct {
+	error
+	attempts int
+}
+
+func (e *attemptsError) Unwrap() error {
+	return e.error
+}
+
+func (e *attemptsError) Attempts() int {
+	return e.attempts
+}
+
+func TestServiceError(t *testing.T) {
+	cases := map[string]struct {
+		err        error
+		expectCode int
+		expectMsg  string
+		expectData *ErrData
+	}{
+		"not found": {
+			err:        fmt.Errorf("failed to get queue: %w", apiOperationError("AWS.SimpleQueueService.NonExistentQueue", "queue does not exist", 400)),
+			expectCode: ErrCodeResourceNotFound,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 400, RequestID: request-id, api error AWS.SimpleQueueService.NonExistentQueue: queue does not exist",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "AWS.SimpleQueueService.NonExistentQueue",
+				RequestID:      "request-id",
+				HTTPStatusCode: 400,
+			},
+		},
+		"operation error is not wrapped": {
+			err:        apiOperationError("ExpiredToken", "token expired", 403),
+			expectCode: ErrCodeAccessDenied,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 403, RequestID: request-id, api error ExpiredToken: token expired",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "ExpiredToken",
+				RequestID:      "request-id",
+				HTTPStatusCode: 403,
+			},
+		},
+		"throttling": {
+			err:        apiOperationError("ThrottlingException", "slow down", 400),
+			expectCode: ErrCodeThrottling,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 400, RequestID: request-id, api error ThrottlingException: slow down",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "ThrottlingException",
+				RequestID:      "request-id",
+				HTTPStatusCode: 400,
+				Retryable:      true,
+			},
+		},
+		"throttling after retries": {
+			err: func() error {
+				oe := apiOperationError("ThrottlingException", "slow down", 400).(*smithy.OperationError)
+				oe.Err = &attemptsError{error: oe.Err, attempts: 3}
+				return oe
+			}(),
+			expectCode: ErrCodeThrottling,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 400, RequestID: request-id, api error ThrottlingException: slow down",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "ThrottlingException",
+				RequestID:      "request-id",
+				HTTPStatusCode: 400,
+				Retryable:      true,
+				Attempts:       3,
+			},
+		},
+		"validation": {
+			err:        apiOperationError("ValidationException", "bad input", 400),
+			expectCode: ErrCodeValidation,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 400, RequestID: request-id, api error ValidationException: bad input",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "ValidationException",
+				RequestID:      "request-id",
+				HTTPStatusCode: 400,
+			},
+		},
+		"missing stack": {
+			err:        apiOperationError("ValidationError", "Stack 'my-stack' does not exist", 400),
+			expectCode: ErrCodeResourceNotFound,
+			expectMsg:  "failed to call service: SQS, operation: GetQueueUrl, error: https response error StatusCode: 400, RequestID: request-id, api error ValidationError: Stack 'my-stack' does not exist",
+			expectData: &ErrData{
+				Service:        "SQS",
+				Operation:      "GetQueueUrl",
+				ErrorCode:      "ValidationError",
+				RequestID:      "request-id",
+				HTTPStatusCode: 400,
+			},
+		},
+		"timeout": {
+			err:        fmt.Errorf("failed to poll events: %w", context.DeadlineExceeded),
+			expectCode: ErrCodeTimeout,
+			expectMsg:  "failed to poll events: context deadline exceeded",
+		},
+		"cancelled": {
+			err:        fmt.Errorf("failed to poll events: %w", context.Canceled),
+			expectCode: ErrCodeCancelled,
+			expectMsg:  "failed to poll events: context canceled",
+		},
+		"not found error": {
+			err:        fmt.Errorf("failed to describe: %w", notFoundError{}),
+			expectCode: ErrCodeResourceNotFound,
+			expectMsg:  "failed to describe: test harness not found",
+		},
+		"other error": {
+			err:        errors.New("something went wrong"),
+			expectCode: ErrCodeIatk,
+			expectMsg:  "something went wrong",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			id := "1"
+			resp := ServiceError(&id, tt.err)
+
+			assert.Equal(t, "1", *resp.ID)
+			assert.Equal(t, tt.expectCode, resp.Error.Code)
+			assert.Equal(t, tt.expectMsg, resp.Error.Message)
+			assert.Equal(t, tt.expectData, resp.Error.Data)
+		})
+	}
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string  { return "test harness not found" }
+func (notFoundError) NotFound() bool { return true }
@@ -3,12 +3,12 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/json"
-	"log"
 	"regexp"
 	"strings"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
 )
 
 type Request struct {
@@ -31,32 +31,38 @@ type Metadata struct {
 	Caller        string `json:"caller"`
 	ClientVersion string `json:"client_version"`
 	DedupKey      string `json:"dedup_key"`
+	// Timeout limits how long the call may run, in seconds. The call is not limited if it is zero.
+	Timeout float64 `json:"timeout,omitempty"`
+	// Progress asks for notifications about the progress of the call to be sent ahead of its response.
+	Progress bool `json:"progress,omitempty"`
+	// LogLevel overrides the level of the log lines of the call: debug, info, warn or error.
+	LogLevel string `json:"log_level,omitempty"`
 }
 
 // Returns a string that serves as a user agent key, indicating the language, language version,client version and the caller method, e.g. python#3.10.9#0.0.3#retry_get_trace_tree_until
 func (m *Metadata) UserAgentValue() string {
-	supportedClients := []string{"python"}
+	supportedClients := []string{"python", "go"}
 	if !slices.Contains(supportedClients, strings.ToLower(m.Client)) {
-		log.Printf("unregonized client: %v", strings.ToLower(m.Client))
+		slog.Warn("unrecognized client", "client", strings.ToLower(m.Client))
 		return "unknown"
 	}
 
 	// NOTE: pattern from https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
 	match, err := regexp.Match(`^(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`, []byte(m.Version))
 	if err != nil || !match {
-		log.Printf("invalid version: %v", m.Version)
+		slog.Warn("invalid version", "version", m.Version)
 		return "unknown"
 	}
 
 	_, err = uuid.Parse(m.DedupKey)
 	if err != nil {
-		log.Printf("invalid request Id: %v", m.DedupKey)
+		slog.Warn("invalid dedup key", "dedup_key", m.DedupKey)
 		return "unknown"
 	}
 	// NOTE: limit caller to has max length of 100 characters, and limit to alphabetical characters and . and _ only
 	match, err = regexp.Match(`^[a-zA-Z_][a-zA-Z0-9_.]{1,99}$`, []byte(m.Caller))
 	if err != nil || !match {
-		log.Printf("invalid caller: %v", m.Caller)
+		slog.Warn("invalid caller", "caller", m.Caller)
 		return "unknown"
 	}
 	return strings.ToLower(m.Client) + "#" + m.ClientVersion + "#" + m.Version + "#" + m.Caller + "#" + m.DedupKey
@@ -74,9 +80,34 @@ type Response struct {
 }
 
 type ErrIatk struct {
-	Code    int    `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
-	// Data should be here but not sure about the type (jfuss)
+	Code    int      `json:"code,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Data    *ErrData `json:"data,omitempty"`
+}
+
+// ErrData details the failure behind an error: the AWS call that failed or, for an internal error, the
+// panic of the method.
+type ErrData struct {
+	// Service is the AWS service called, e.g. "SQS".
+	Service string `json:"service,omitempty"`
+	// Operation is the API operation called, e.g. "GetQueueUrl".
+	Operation string `json:"operation,omitempty"`
+	// ErrorCode is the error code returned by the service, e.g. "AWS.SimpleQueueService.NonExistentQueue".
+	ErrorCode string `json:"error_code,omitempty"`
+	// RequestID identifies the request to the service.
+	RequestID string `json:"request_id,omitempty"`
+	// HTTPStatusCode is the status code of the service response.
+	HTTPStatusCode int `json:"http_status_code,omitempty"`
+	// Retryable tells whether the call may succeed if made again.
+	Retryable bool `json:"retryable"`
+	// Attempts is the number of attempts of the call, retries included.
+	Attempts int `json:"attempts,omitempty"`
+	// Stack is the stack trace of the method that panicked.
+	Stack string `json:"stack,omitempty"`
+	// CrashReport is the path of the crash report written about the panic, if any.
+	CrashReport string `json:"crash_report,omitempty"`
+	// Report is the outcome for each test harness of a method that failed to destroy some of them.
+	Report interface{} `json:"report,omitempty"`
 }
 
 func (r Response) Encode() ([]byte, error) {
@@ -87,3 +118,34 @@ func (r Response) Encode() ([]byte, error) {
 	}
 	return buffer.Bytes(), nil
 }
+
+// BatchResponse answers a batch request, holding one Response per request in the same order.
+type BatchResponse []Response
+
+func (b BatchResponse) Encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	enc := json.NewEncoder(&buffer)
+	if err := enc.Encode(b); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Notification is a request that is not answered, sent by either side.
+type Notification struct {
+	// JSONRPC describes the version of the JSON RPC protocol. Defaults to `2.0`.
+	JSONRPC string `json:"jsonrpc"`
+	// Method describes the intention of the notification.
+	Method string `json:"method"`
+	// Params contains the payload of the notification.
+	Params interface{} `json:"params,omitempty"`
+}
+
+func (n Notification) Encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	enc := json.NewEncoder(&buffer)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -26,6 +26,17 @@ func TestMetadata_UserAgentValue(t *testing.T) {
 			},
 			expect: "python#3.10.9#0.0.1#poll_events#" + id,
 		},
+		{
+			name: "go client",
+			m: &Metadata{
+				Client:        "go",
+				ClientVersion: "1.19.13",
+				Version:       "0.0.0-dev",
+				Caller:        "test_harness.eventbridge.poll_events",
+				DedupKey:      id,
+			},
+			expect: "go#1.19.13#0.0.0-dev#test_harness.eventbridge.poll_events#" + id,
+		},
 		{
 			name: "invalid client",
 			m: &Metadata{
@@ -96,3 +107,25 @@ func TestMetadata_UserAgentValue(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchResponse_Encode(t *testing.T) {
+	id1, id2 := "1", "2"
+	batch := BatchResponse{
+		{JSONRPC: "2.0", ID: &id1, Result: "ok"},
+		NoMethodFoundError(&id2),
+	}
+
+	buffer, err := batch.Encode()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"jsonrpc":"2.0","id":"1","result":"ok"},{"jsonrpc":"2.0","id":"2","error":{"code":-32601,"message":"Method not found"}}]`+"\n", string(buffer))
+}
+
+func TestNotification_Encode(t *testing.T) {
+	n := Notification{JSONRPC: "2.0", Method: "$/progress", Params: map[string]string{"message": "queue created"}}
+
+	buffer, err := n.Encode()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc":"2.0","method":"$/progress","params":{"message":"queue created"}}`+"\n", string(buffer))
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logging sets up the leveled, structured logger of iatk and carries the logger of a call,
+// with the fields identifying it, in its context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// LevelEnv names the environment variable holding the minimum level logged: debug, info, warn or error.
+	// Defaults to info.
+	LevelEnv = "IATK_LOG_LEVEL"
+	// FormatEnv names the environment variable holding the format of log lines: text or json.
+	// Defaults to text.
+	FormatEnv = "IATK_LOG_FORMAT"
+)
+
+// Attribute keys shared by the log lines of iatk.
+const (
+	RequestIDKey  = "request_id"
+	MethodKey     = "method"
+	ListenerIDKey = "listener_id"
+)
+
+// Init sets the default logger to write to w at the level and in the format given by the environment.
+// Lines logged with the standard log package go through the default logger as well, at info level.
+func Init(w io.Writer) error {
+	level, err := ParseLevel(os.Getenv(LevelEnv))
+	if err != nil {
+		return err
+	}
+	handler, err := newHandler(w, os.Getenv(FormatEnv), level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// ParseLevel parses a level name, case insensitive. An empty name is info.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q, expected one of debug, info, warn or error", s)
+}
+
+func newHandler(w io.Writer, format string, level slog.Leveler) (slog.Handler, error) {
+	opts := slog.HandlerOptions{Level: level}
+	switch strings.ToLower(format) {
+	case "", "text":
+		return slog.NewTextHandler(w, &opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, &opts), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q, expected text or json", format)
+}
+
+// WithLevel returns a logger that logs like l but at level instead of the level l was set up with.
+func WithLevel(l *slog.Logger, level slog.Leveler) *slog.Logger {
+	h := l.Handler()
+	if lh, ok := h.(*levelHandler); ok {
+		h = lh.handler
+	}
+	return slog.New(&levelHandler{level: level, handler: h})
+}
+
+// levelHandler overrides the level of the handler it wraps.
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx that carries l.
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger adds args, as key-value pairs, to every line.
+func With(ctx context.Context, args ...interface{}) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]struct {
+		input     string
+		expect    slog.Level
+		expectErr string
+	}{
+		"empty":   {input: "", expect: slog.LevelInfo},
+		"debug":   {input: "debug", expect: slog.LevelDebug},
+		"upper":   {input: "WARN", expect: slog.LevelWarn},
+		"warning": {input: "warning", expect: slog.LevelWarn},
+		"error":   {input: "error", expect: slog.LevelError},
+		"invalid": {input: "verbose", expectErr: `invalid log level "verbose", expected one of debug, info, warn or error`},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseLevel(tt.input)
+			if tt.expectErr != "" {
+				assert.EqualError(t, err, tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	t.Setenv(LevelEnv, "warn")
+	t.Setenv(FormatEnv, "json")
+	var buffer bytes.Buffer
+
+	require.NoError(t, Init(&buffer))
+	ctx := With(context.Background(), RequestIDKey, "42", MethodKey, "get_physical_id")
+	FromContext(ctx).Info("dropped")
+	FromContext(ctx).Warn("kept", ListenerIDKey, "iatk_eb_abc")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "kept", line["msg"])
+	assert.Equal(t, "42", line[RequestIDKey])
+	assert.Equal(t, "get_physical_id", line[MethodKey])
+	assert.Equal(t, "iatk_eb_abc", line[ListenerIDKey])
+}
+
+func TestInitInvalidFormat(t *testing.T) {
+	t.Setenv(FormatEnv, "xml")
+
+	err := Init(&bytes.Buffer{})
+
+	assert.EqualError(t, err, `invalid log format "xml", expected text or json`)
+}
+
+func TestWithLevel(t *testing.T) {
+	var buffer bytes.Buffer
+	handler, err := newHandler(&buffer, "text", slog.LevelInfo)
+	require.NoError(t, err)
+	logger := slog.New(handler)
+
+	WithLevel(logger, slog.LevelDebug).Debug("debugging")
+	WithLevel(WithLevel(logger, slog.LevelDebug), slog.LevelError).Warn("dropped")
+
+	assert.Contains(t, buffer.String(), "msg=debugging")
+	assert.NotContains(t, buffer.String(), "dropped")
+}
+
+func TestFromContextDefault(t *testing.T) {
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+}
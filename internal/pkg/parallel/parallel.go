@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parallel runs calls for each item of a list concurrently, up to a bound.
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is the error of a call that panicked.
+type PanicError struct {
+	// Value is the value the call panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// ForEach calls do with each index below n, on up to concurrency goroutines at once, and returns once every
+// call has returned. Once ctx is done, the indices not started yet are passed to fail with the error of ctx
+// instead. A call that panics is passed to fail as well, with a PanicError.
+func ForEach(ctx context.Context, n int, concurrency int, do func(i int), fail func(i int, err error)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+			// ctx may be done too, the select picking either case then
+			if err := ctx.Err(); err != nil {
+				<-sem
+				fail(i, err)
+				continue
+			}
+		case <-ctx.Done():
+			fail(i, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				if v := recover(); v != nil {
+					fail(i, &PanicError{Value: v, Stack: debug.Stack()})
+				}
+				<-sem
+				wg.Done()
+			}()
+			do(i)
+		}(i)
+	}
+	wg.Wait()
+}
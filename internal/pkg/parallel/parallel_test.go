@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package parallel
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEach(t *testing.T) {
+	var running, maxRunning int32
+	done := make([]bool, 10)
+
+	ForEach(context.Background(), len(done), 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		done[i] = true
+	}, func(i int, err error) {
+		t.Errorf("unexpected failure of %d: %v", i, err)
+	})
+
+	assert.Equal(t, []bool{true, true, true, true, true, true, true, true, true, true}, done)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestForEachCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make([]error, 3)
+	started := make([]bool, 3)
+
+	ForEach(ctx, len(errs), 1, func(i int) {
+		started[i] = true
+		cancel()
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+
+	assert.Equal(t, []bool{true, false, false}, started)
+	assert.Equal(t, []error{nil, context.Canceled, context.Canceled}, errs)
+}
+
+func TestForEachPanic(t *testing.T) {
+	errs := make([]error, 3)
+
+	ForEach(context.Background(), len(errs), 2, func(i int) {
+		if i == 1 {
+			var m map[string]int
+			m["boom"]++
+		}
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[2])
+	var errPanic *PanicError
+	require.ErrorAs(t, errs[1], &errPanic)
+	assert.EqualError(t, errPanic, "panic: assignment to entry in nil map")
+	assert.Contains(t, string(errPanic.Stack), "TestForEachPanic")
+}
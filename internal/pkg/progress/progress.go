@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package progress lets long running operations report the steps they complete to whoever
+// started them, e.g. a client waiting for the response of an RPC method.
+package progress
+
+import "context"
+
+// Reporter receives the steps an operation completes. message describes the step, e.g. "queue created",
+// and data holds its details, e.g. the ARN of the queue.
+type Reporter func(message string, data map[string]interface{})
+
+type reporterKey struct{}
+
+// NewContext returns a copy of ctx that carries r, so that the steps reported with ctx reach r.
+func NewContext(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Report sends a step to the Reporter carried by ctx, if any.
+func Report(ctx context.Context, message string, data map[string]interface{}) {
+	if r, ok := ctx.Value(reporterKey{}).(Reporter); ok && r != nil {
+		r(message, data)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	var messages []string
+	ctx := NewContext(context.Background(), func(message string, data map[string]interface{}) {
+		messages = append(messages, message)
+		assert.Equal(t, map[string]interface{}{"depth": 1}, data)
+	})
+
+	Report(ctx, "linked traces fetched", map[string]interface{}{"depth": 1})
+
+	assert.Equal(t, []string{"linked traces fetched"}, messages)
+}
+
+func TestReportWithoutReporter(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Report(context.Background(), "queue created", nil)
+	})
+}
@@ -4,42 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/harness/eventbridge/listener"
 	"iatk/internal/pkg/harness/tags"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
 	"reflect"
+	"time"
 )
 
 type AddEbListenerParams struct {
-	EventBusName string
-	TargetId     string
-	RuleName     string
-	Tags         map[string]string
-	Profile      string
-	Region       string
-}
-
-func (p *AddEbListenerParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	err := p.validateParams()
-	if err != nil {
-		return nil, err
-	}
-
-	err = tags.ValidateTags(p.Tags)
-	if err != nil {
-		return nil, fmt.Errorf("invalid tags: %v", err)
-	}
+	EventBusName            string            `description:"Name of the AWS Event Bus" required:"true"`
+	TargetId                string            `json:"TargetId,omitempty" description:"Target Id on the given rule to replicate"`
+	RuleName                string            `json:"RuleName,omitempty" description:"Name of a Rule on the EventBus to replicate. Required if no EventPattern is supplied"`
+	EventPattern            string            `json:"EventPattern,omitempty" description:"Event pattern of the listener, overriding the one of the Rule if supplied"`
+	EventPatternRefinements []string          `json:"EventPatternRefinements,omitempty" description:"Event patterns AND-merged with the event pattern of the listener"`
+	Tags                    map[string]string `json:"Tags,omitempty" description:"A key-value pair associated EventBridge rule"`
+	TTLSeconds              int               `json:"TtlSeconds,omitempty" description:"Time-to-live of the listener in seconds, after which test_harness.gc destroys it"`
 
-	ctx := context.TODO()
-
-	cfg, err := config.GetAWSConfig(ctx, p.Region, p.Profile, metadata)
-	if err != nil {
-		return nil, fmt.Errorf("error loading AWS config: %v", err)
-	}
+	AWSParams
+}
 
-	lr, err := listener.New(ctx, p.EventBusName, p.TargetId, p.RuleName, p.Tags, listener.NewOptions(cfg))
+func (p *AddEbListenerParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	lr, err := listener.New(ctx, p.EventBusName, p.TargetId, p.RuleName, p.EventPattern, p.EventPatternRefinements, p.Tags, time.Duration(p.TTLSeconds)*time.Second, listener.NewOptions(p.cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to locate test target: %w", err)
 	}
@@ -64,8 +50,17 @@ func (p *AddEbListenerParams) validateParams() error {
 	if p.EventBusName == "" {
 		return errors.New(`missing required param "EventBusName"`)
 	}
-	if p.RuleName == "" {
-		return errors.New(`missing required param "RuleName"`)
+	if p.RuleName == "" && p.EventPattern == "" {
+		return errors.New(`missing required param "RuleName" or "EventPattern"`)
+	}
+	if p.RuleName == "" && p.TargetId != "" {
+		return errors.New(`"TargetId" requires "RuleName"`)
+	}
+	if p.TTLSeconds < 0 {
+		return errors.New(`"TtlSeconds" must be a positive integer`)
+	}
+	if err := tags.ValidateTags(p.Tags); err != nil {
+		return fmt.Errorf("invalid tags: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/aws/config"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// bounds of the duration of assumed role sessions, in seconds, see the AssumeRole API of STS
+const (
+	minDurationSeconds = 900
+	maxDurationSeconds = 43200
+)
+
+var roleSessionNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// AWSParams are the params shared by the methods calling AWS. Params embedding them get the AWS config
+// for their region, profile, endpoint URLs, role and retries loaded by LoadAWSConfig before the call runs.
+type AWSParams struct {
+	Profile string `json:"Profile,omitempty" description:"AWS Profile used to communicate with AWS resources"`
+	Region  string `json:"Region,omitempty" description:"AWS Region used to interact with AWS"`
+	// EndpointURL and EndpointURLs point the AWS clients at e.g. a local emulator.
+	EndpointURL  string            `json:"EndpointURL,omitempty" description:"URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator"`
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty" description:"URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL"`
+	// RoleArn and the params below it assume a role, e.g. in another account, with the credentials of
+	// the profile.
+	RoleArn         string `json:"RoleArn,omitempty" description:"ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account"`
+	ExternalId      string `json:"ExternalId,omitempty" description:"External ID the trust policy of the role to assume may require"`
+	RoleSessionName string `json:"RoleSessionName,omitempty" description:"Name of the session of the role to assume, aws-iatk by default"`
+	DurationSeconds int    `json:"DurationSeconds,omitempty" description:"Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900"`
+	// RetryMode and the params below it tune how throttled and other failed AWS calls are retried.
+	RetryMode         string `json:"RetryMode,omitempty" enum:"standard,adaptive" description:"How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard"`
+	MaxAttempts       int    `json:"MaxAttempts,omitempty" description:"Maximum number of attempts of each AWS call, the first one included. Defaults to 3"`
+	MaxBackoffSeconds int    `json:"MaxBackoffSeconds,omitempty" description:"Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20"`
+
+	cfg aws.Config
+}
+
+func (p *AWSParams) awsParams() *AWSParams {
+	return p
+}
+
+// validateAWSParams returns an error if the AWS params of p are invalid.
+func (p *AWSParams) validateAWSParams() error {
+	if p.EndpointURL != "" {
+		if err := config.ValidateEndpointURL(p.EndpointURL); err != nil {
+			return err
+		}
+	}
+	for service, u := range p.EndpointURLs {
+		if err := config.ValidateEndpointURL(u); err != nil {
+			return fmt.Errorf("%w for service %q", err, service)
+		}
+	}
+
+	switch aws.RetryMode(p.RetryMode) {
+	case "", aws.RetryModeStandard, aws.RetryModeAdaptive:
+	default:
+		return fmt.Errorf("invalid RetryMode %q, expected standard or adaptive", p.RetryMode)
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("invalid MaxAttempts %d, expected a positive value", p.MaxAttempts)
+	}
+	if p.MaxBackoffSeconds < 0 {
+		return fmt.Errorf("invalid MaxBackoffSeconds %d, expected a positive value", p.MaxBackoffSeconds)
+	}
+
+	if p.RoleArn == "" {
+		if p.ExternalId != "" || p.RoleSessionName != "" || p.DurationSeconds != 0 {
+			return errors.New(`ExternalId, RoleSessionName and DurationSeconds require "RoleArn"`)
+		}
+		return nil
+	}
+	if a, err := arn.Parse(p.RoleArn); err != nil || a.Service != "iam" {
+		return fmt.Errorf("invalid RoleArn %q, expected the ARN of an IAM role", p.RoleArn)
+	}
+	if p.RoleSessionName != "" && !roleSessionNameRegexp.MatchString(p.RoleSessionName) {
+		return fmt.Errorf("invalid RoleSessionName %q, expected 2 to 64 letters, digits or any of _+=,.@-", p.RoleSessionName)
+	}
+	if p.DurationSeconds != 0 && (p.DurationSeconds < minDurationSeconds || p.DurationSeconds > maxDurationSeconds) {
+		return fmt.Errorf("invalid DurationSeconds %d, expected a value from %d to %d", p.DurationSeconds, minDurationSeconds, maxDurationSeconds)
+	}
+	return nil
+}
+
+// configOptions sets the options of the AWS config of p.
+func (p *AWSParams) configOptions(o *config.Options) {
+	o.EndpointURL = p.EndpointURL
+	o.EndpointURLs = p.EndpointURLs
+	o.AssumeRole = config.AssumeRole{
+		RoleARN:     p.RoleArn,
+		ExternalID:  p.ExternalId,
+		SessionName: p.RoleSessionName,
+		Duration:    time.Duration(p.DurationSeconds) * time.Second,
+	}
+	o.Retry = config.Retry{
+		Mode:        aws.RetryMode(p.RetryMode),
+		MaxAttempts: p.MaxAttempts,
+		MaxBackoff:  time.Duration(p.MaxBackoffSeconds) * time.Second,
+	}
+}
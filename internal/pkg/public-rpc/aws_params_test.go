@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"iatk/internal/pkg/aws/config"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAWSParams(t *testing.T) {
+	cases := map[string]struct {
+		params AWSParams
+		err    string
+	}{
+		"none": {},
+		"role": {
+			params: AWSParams{RoleArn: "arn:aws:iam::123456789012:role/test", ExternalId: "ext", RoleSessionName: "ci@run-1", DurationSeconds: 3600},
+		},
+		"invalid endpoint url": {
+			params: AWSParams{EndpointURL: "localhost"},
+			err:    `invalid endpoint URL "localhost", expected an absolute URL such as http://localhost:4566`,
+		},
+		"role options without role": {
+			params: AWSParams{ExternalId: "ext"},
+			err:    `ExternalId, RoleSessionName and DurationSeconds require "RoleArn"`,
+		},
+		"invalid role arn": {
+			params: AWSParams{RoleArn: "arn:aws:sqs:us-east-1:123456789012:queue"},
+			err:    `invalid RoleArn "arn:aws:sqs:us-east-1:123456789012:queue", expected the ARN of an IAM role`,
+		},
+		"invalid session name": {
+			params: AWSParams{RoleArn: "arn:aws:iam::123456789012:role/test", RoleSessionName: "my session"},
+			err:    `invalid RoleSessionName "my session", expected 2 to 64 letters, digits or any of _+=,.@-`,
+		},
+		"retries": {
+			params: AWSParams{RetryMode: "adaptive", MaxAttempts: 10, MaxBackoffSeconds: 5},
+		},
+		"invalid retry mode": {
+			params: AWSParams{RetryMode: "forever"},
+			err:    `invalid RetryMode "forever", expected standard or adaptive`,
+		},
+		"negative max attempts": {
+			params: AWSParams{MaxAttempts: -1},
+			err:    "invalid MaxAttempts -1, expected a positive value",
+		},
+		"duration too short": {
+			params: AWSParams{RoleArn: "arn:aws:iam::123456789012:role/test", DurationSeconds: 60},
+			err:    "invalid DurationSeconds 60, expected a value from 900 to 43200",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tt.params.validateAWSParams()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	p := AWSParams{
+		EndpointURL:     "http://localhost:4566",
+		RoleArn:         "arn:aws:iam::123456789012:role/test",
+		ExternalId:      "ext",
+		RoleSessionName: "ci",
+		DurationSeconds: 3600,
+		RetryMode:       "adaptive",
+		MaxAttempts:     10,
+	}
+	var o config.Options
+	p.configOptions(&o)
+	assert.Equal(t, config.Options{
+		EndpointURL: "http://localhost:4566",
+		AssumeRole: config.AssumeRole{
+			RoleARN:     "arn:aws:iam::123456789012:role/test",
+			ExternalID:  "ext",
+			SessionName: "ci",
+			Duration:    time.Hour,
+		},
+		Retry: config.Retry{Mode: aws.RetryModeAdaptive, MaxAttempts: 10},
+	}, o)
+}
@@ -0,0 +1,43 @@
+package publicrpc
+
+import (
+	"context"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"iatk/internal/pkg/version"
+	"reflect"
+)
+
+// Features lists the optional parts of the protocol the binary supports, so that clients can detect
+// them rather than pin a binary version.
+var Features = []string{
+	"batch",          // arrays of requests are answered with arrays of responses
+	"cancel_request", // $/cancelRequest aborts a call in flight, in -serve mode
+	"progress",       // $/progress notifications precede the response when metadata.progress is set
+	"timeout",        // metadata.timeout limits how long a call may run
+	"log_level",      // metadata.log_level overrides the level of the log lines of a call
+	"serve",          // the -serve flag keeps the binary running to answer many requests
+	"http",           // the -http flag answers requests POSTed over HTTP
+}
+
+type CapabilitiesParams struct{}
+
+type CapabilitiesOutput struct {
+	Version  string   `json:"Version" description:"Release of the binary" required:"true"`
+	Methods  []string `json:"Methods" description:"Names of the RPC methods the binary supports, sorted" required:"true"`
+	Features []string `json:"Features" description:"Optional parts of the protocol the binary supports" required:"true"`
+}
+
+func (p *CapabilitiesParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	return &types.Result{
+		Output: CapabilitiesOutput{
+			Version:  version.Version,
+			Methods:  Default.Names(),
+			Features: Features,
+		},
+	}, nil
+}
+
+func (p *CapabilitiesParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(CapabilitiesOutput{})).Elem()
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"fmt"
+	"iatk/internal/pkg/version"
+	"os"
+	"strings"
+	"time"
+)
+
+// CrashDirEnv names the environment variable holding the directory crash reports are written to when a
+// method panics. No report is written if it is not set.
+const CrashDirEnv = "IATK_CRASH_DIR"
+
+// writeCrashReport writes a report about the panic of call, answering the request identified by requestID,
+// to a new file in dir, created if need be, and returns the path of the file. The params of call are left
+// out, as they may be sensitive.
+func writeCrashReport(dir string, call *Call, requestID string, errPanic *ErrPanic) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create crash report directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "iatk-crash-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create crash report: %w", err)
+	}
+	defer f.Close()
+
+	info := version.Get()
+	var b strings.Builder
+	fmt.Fprintf(&b, "iatk crash report\n\n")
+	fmt.Fprintf(&b, "time: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "version: %s\n", info.Version)
+	fmt.Fprintf(&b, "go version: %s\n", info.GoVersion)
+	fmt.Fprintf(&b, "platform: %s\n", info.Platform)
+	if info.Revision != "" {
+		fmt.Fprintf(&b, "revision: %s\n", info.Revision)
+	}
+	fmt.Fprintf(&b, "method: %s\n", call.Method)
+	fmt.Fprintf(&b, "request id: %s\n", requestID)
+	if call.Metadata != nil {
+		fmt.Fprintf(&b, "client: %s %s\n", call.Metadata.Client, call.Metadata.Version)
+	}
+	fmt.Fprintf(&b, "\npanic: %v\n\n%s", errPanic.Value, errPanic.Stack)
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return f.Name(), nil
+}
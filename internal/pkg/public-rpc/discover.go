@@ -0,0 +1,22 @@
+package publicrpc
+
+import (
+	"context"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"iatk/internal/pkg/rpcspecs"
+	"iatk/internal/pkg/version"
+	"reflect"
+)
+
+type DiscoverParams struct{}
+
+func (p *DiscoverParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	return &types.Result{
+		Output: rpcspecs.Discover(rpcspecs.Generate(Default.Methods()), "iatk", version.Version),
+	}, nil
+}
+
+func (p *DiscoverParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(rpcspecs.OpenRPC{})).Elem()
+}
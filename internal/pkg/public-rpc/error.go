@@ -20,3 +20,45 @@ func (e *ErrParameter) Error() string {
 	}
 	return "no parameters found"
 }
+
+// ErrValidation is returned when params are well-formed but invalid for the method, e.g. a required param is missing.
+type ErrValidation struct {
+	Err error
+}
+
+func (e *ErrValidation) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrValidation) Unwrap() error {
+	return e.Err
+}
+
+// ErrPanic is returned when a method panics.
+type ErrPanic struct {
+	// Value is the value the method panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+	// CrashReport is the path of the crash report written about the panic, if any, see CrashDirEnv.
+	CrashReport string
+}
+
+func (e *ErrPanic) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// ErrDestroy is returned when some of the test harnesses a method destroys could not be destroyed. Report
+// is the outcome for each of them, for callers to retry only the ones that failed.
+type ErrDestroy struct {
+	Err    error
+	Report interface{}
+}
+
+func (e *ErrDestroy) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrDestroy) Unwrap() error {
+	return e.Err
+}
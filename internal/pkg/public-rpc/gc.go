@@ -0,0 +1,51 @@
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"iatk/internal/pkg/harness/gc"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+	"time"
+
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+type GCParams struct {
+	MaxAgeSeconds int                  `json:"MaxAgeSeconds,omitempty" description:"Age in seconds over which test harnesses are destroyed. If not supplied, only the expired and orphaned ones are"`
+	TagFilters    []tagtypes.TagFilter `json:"TagFilters,omitempty" description:"Tag filters matching the test harnesses to consider, all iatk-created ones if not supplied"`
+	DryRun        bool                 `json:"DryRun,omitempty" description:"Whether to only report the test harnesses that would be destroyed, without destroying them"`
+
+	AWSParams
+}
+
+func (p *GCParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	results, err := gc.Collect(ctx, p.TagFilters, time.Duration(p.MaxAgeSeconds)*time.Second, p.DryRun, gc.NewOptions(p.cfg))
+	if err != nil && results == nil {
+		return nil, err
+	}
+
+	out := make([]gc.Output, 0, len(results))
+	for _, r := range results {
+		out = append(out, r.JSON())
+	}
+	if err != nil {
+		// failures are reported per test harness, for callers to retry only those
+		return nil, &ErrDestroy{Err: err, Report: out}
+	}
+	return &types.Result{
+		Output: out,
+	}, nil
+}
+
+func (p *GCParams) validateParams() error {
+	if p.MaxAgeSeconds < 0 {
+		return errors.New(`"MaxAgeSeconds" must be a positive integer`)
+	}
+	return nil
+}
+
+func (p *GCParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf([]gc.Output{})).Elem()
+}
@@ -7,7 +7,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/jsonrpc"
 	mockevent "iatk/internal/pkg/mock/event"
 	"iatk/internal/pkg/public-rpc/types"
@@ -18,32 +17,19 @@ import (
 )
 
 type GenerateBareboneEventsParams struct {
-	RegistryName  string
-	SchemaName    string
-	SchemaVersion *string
+	RegistryName  string  `description:"Name of the registry of the schema stored in EventBridge Schema Registry" required:"true"`
+	SchemaName    string  `description:"Name of the schema stored in EventBridge Schema Registry" required:"true"`
+	SchemaVersion *string `description:"Version of the schema stored in EventBridge Schema Registry"`
 
-	EventRef     *string
-	SkipOptional bool
+	EventRef     *string `description:"Location to the event in the schema in json schema ref syntax, only applicable for openapi schema"`
+	SkipOptional bool    `json:"SkipOptional,omitempty" description:"If set to true, do not generate optional fields"`
 
-	Profile string
-	Region  string
+	AWSParams
 }
 
-func (p *GenerateBareboneEventsParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	err := p.validateParams()
-	if err != nil {
-		return nil, err
-	}
-
-	ctx := context.TODO()
-	cfg, err := config.GetAWSConfig(ctx, p.Region, p.Profile, metadata)
-	if err != nil {
-		return nil, fmt.Errorf("error loading AWS config: %w", err)
-	}
-
-	var schema *mockevent.Schema
-	client := schemas.NewFromConfig(cfg)
-	schema, err = mockevent.NewSchemaFromRegistry(
+func (p *GenerateBareboneEventsParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	client := schemas.NewFromConfig(p.cfg)
+	schema, err := mockevent.NewSchemaFromRegistry(
 		ctx,
 		aws.String(p.RegistryName),
 		aws.String(p.SchemaName),
@@ -2,10 +2,8 @@ package publicrpc
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
-	"iatk/internal/pkg/aws/config"
 	iatkcfn "iatk/internal/pkg/cloudformation"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
@@ -14,24 +12,16 @@ import (
 )
 
 type GetPhysicalIdParams struct {
-	LogicalResourceId string `json:"LogicalResourceId"`
+	LogicalResourceId string `json:"LogicalResourceId" description:"Name of the Logical Id within the Stack to fetch" required:"true"`
 
-	StackName string `json:"StackName"`
+	StackName string `json:"StackName" description:"Name of the CloudFormation Stack" required:"true"`
 
-	Profile string `json:"Profile,omitempty"`
-
-	Region string `json:"Region,omitempty"`
+	AWSParams
 }
 
-func (p *GetPhysicalIdParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	cfg, err := config.GetAWSConfig(context.TODO(), p.Region, p.Profile, metadata)
-
-	if err != nil {
-		return nil, fmt.Errorf("error when loading AWS config: %v", err)
-	}
-
-	cfnClient := cloudformation.NewFromConfig(cfg)
-	id, err := iatkcfn.GetPhysicalId(p.StackName, p.LogicalResourceId, cfnClient)
+func (p *GetPhysicalIdParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	cfnClient := cloudformation.NewFromConfig(p.cfg)
+	id, err := iatkcfn.GetPhysicalId(ctx, p.StackName, p.LogicalResourceId, cfnClient)
 
 	// Fowards id and err to caller for handling
 	return &types.Result{
@@ -2,8 +2,6 @@ package publicrpc
 
 import (
 	"context"
-	"fmt"
-	"iatk/internal/pkg/aws/config"
 	iatkcfn "iatk/internal/pkg/cloudformation"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
@@ -13,22 +11,16 @@ import (
 )
 
 type GetStackOutputParams struct {
-	StackName   string
-	OutputNames []string
-	Profile     string
-	Region      string
-}
-
-func (p *GetStackOutputParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	cfg, err := config.GetAWSConfig(context.TODO(), p.Region, p.Profile, metadata)
+	StackName   string   `description:"Name of the CloudFormation Stack" required:"true"`
+	OutputNames []string `description:"Keys of the Stack Outputs to fetch" required:"true"`
 
-	if err != nil {
-		return nil, fmt.Errorf("error when loading AWS config: %v", err)
-	}
+	AWSParams
+}
 
-	cfnClient := cloudformation.NewFromConfig(cfg)
+func (p *GetStackOutputParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	cfnClient := cloudformation.NewFromConfig(p.cfg)
 
-	mOutputKeys, err := iatkcfn.GetStackOuput(p.StackName, p.OutputNames, cfnClient)
+	mOutputKeys, err := iatkcfn.GetStackOuput(ctx, p.StackName, p.OutputNames, cfnClient)
 
 	// Fowards id and err to caller for handling
 	return &types.Result{
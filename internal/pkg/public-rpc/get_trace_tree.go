@@ -7,39 +7,25 @@ import (
 	"reflect"
 	"strings"
 
-	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
 	iatkxray "iatk/internal/pkg/xray"
 )
 
 type GetTraceTreeParams struct {
-	TracingHeader    string `json:"TracingHeader"`
-	Profile          string `json:"Profile,omitempty"`
-	Region           string `json:"Region,omitempty"`
-	FetchChildTraces bool   `json:"FetchChildTraces,omitempty"`
-}
-
-func (p *GetTraceTreeParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
+	TracingHeader    string `json:"TracingHeader" description:"Trace header to get the trace tree" required:"true"`
+	FetchChildTraces bool   `json:"FetchChildTraces,omitempty" description:"Flag to determine if linked traces will be included in the tree"`
 
-	if p.TracingHeader == "" {
-		return nil, errors.New(`missing required param "TracingHeader"`)
-	}
+	AWSParams
+}
 
+func (p *GetTraceTreeParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
 	traceId, err := getTracIdFromTracingHeader(p.TracingHeader)
-
 	if err != nil {
-		return nil, fmt.Errorf("error while getting trace_id from the tracing header: %v", err)
+		return nil, &ErrValidation{fmt.Errorf("error while getting trace_id from the tracing header: %w", err)}
 	}
 
-	ctx := context.TODO()
-	cfg, err := config.GetAWSConfig(context.TODO(), p.Region, p.Profile, metadata)
-
-	if err != nil {
-		return nil, fmt.Errorf("error when loading AWS config: %v", err)
-	}
-
-	traceTree, err := iatkxray.NewTree(ctx, iatkxray.NewTreeOptions(cfg), *traceId, p.FetchChildTraces)
+	traceTree, err := iatkxray.NewTree(ctx, iatkxray.NewTreeOptions(p.cfg), *traceId, p.FetchChildTraces)
 	if err != nil {
 		return nil, fmt.Errorf("error building trace tree: %w", err)
 	}
@@ -54,9 +40,9 @@ func getTracIdFromTracingHeader(tracingHeader string) (*string, error) {
 
 	splitHeader := strings.Split(tracingHeader, ";")
 	for _, headerComponent := range splitHeader {
-		splitComponent := strings.Split(headerComponent, "=")
+		splitComponent := strings.SplitN(headerComponent, "=", 2)
 		if strings.ToLower(splitComponent[0]) == "root" {
-			if splitComponent[1] != "" {
+			if len(splitComponent) == 2 && splitComponent[1] != "" {
 				return &splitComponent[1], nil
 			} else {
 				return nil, errors.New(`invalid tracing header provided`)
@@ -67,6 +53,16 @@ func getTracIdFromTracingHeader(tracingHeader string) (*string, error) {
 
 }
 
+func (p *GetTraceTreeParams) validateParams() error {
+	if p.TracingHeader == "" {
+		return errors.New(`missing required param "TracingHeader"`)
+	}
+	if _, err := getTracIdFromTracingHeader(p.TracingHeader); err != nil {
+		return fmt.Errorf("error while getting trace_id from the tracing header: %w", err)
+	}
+	return nil
+}
+
 func (p *GetTraceTreeParams) ReflectOutput() reflect.Value {
 	ft := reflect.TypeOf(iatkxray.NewTree)
 	out0 := ft.Out(0)
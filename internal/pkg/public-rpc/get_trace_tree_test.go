@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTraceIdFromTracingHeader(t *testing.T) {
+	cases := map[string]struct {
+		header    string
+		expect    string
+		expectErr bool
+	}{
+		"root only":          {header: "Root=1-5759e988-bd862e3fe1be46a994272793", expect: "1-5759e988-bd862e3fe1be46a994272793"},
+		"root with parent":   {header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1", expect: "1-5759e988-bd862e3fe1be46a994272793"},
+		"lower case root":    {header: "Sampled=1;root=1-5759e988-bd862e3fe1be46a994272793", expect: "1-5759e988-bd862e3fe1be46a994272793"},
+		"empty root":         {header: "Root=;Sampled=1", expectErr: true},
+		"root without value": {header: "Root", expectErr: true},
+		"no root":            {header: "Parent=53995c3f42cd8ad8", expectErr: true},
+		"empty":              {header: "", expectErr: true},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			traceId, err := getTracIdFromTracingHeader(tt.header)
+			if tt.expectErr {
+				assert.EqualError(t, err, "invalid tracing header provided")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, *traceId)
+		})
+	}
+}
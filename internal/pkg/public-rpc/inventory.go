@@ -0,0 +1,65 @@
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"iatk/internal/pkg/harness/inventory"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+type ListTestHarnessesParams struct {
+	TagFilters []tagtypes.TagFilter `json:"TagFilters,omitempty" description:"Tag filters matching the test harnesses to list, all iatk-created ones if not supplied"`
+
+	AWSParams
+}
+
+func (p *ListTestHarnessesParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	descriptions, err := inventory.List(ctx, p.TagFilters, inventory.NewOptions(p.cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]inventory.Output, 0, len(descriptions))
+	for _, d := range descriptions {
+		out = append(out, d.JSON())
+	}
+	return &types.Result{
+		Output: out,
+	}, nil
+}
+
+func (p *ListTestHarnessesParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf([]inventory.Output{})).Elem()
+}
+
+type DescribeTestHarnessParams struct {
+	ID string `json:"Id" description:"Id of the test harness to describe" required:"true"`
+
+	AWSParams
+}
+
+func (p *DescribeTestHarnessParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	d, err := inventory.Describe(ctx, p.ID, inventory.NewOptions(p.cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Result{
+		Output: d.JSON(),
+	}, nil
+}
+
+func (p *DescribeTestHarnessParams) validateParams() error {
+	if p.ID == "" {
+		return errors.New(`missing required param "Id"`)
+	}
+	return nil
+}
+
+func (p *DescribeTestHarnessParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(inventory.Output{})).Elem()
+}
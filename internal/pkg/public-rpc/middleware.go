@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"iatk/internal/pkg/aws/config"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/public-rpc/types"
+	"iatk/internal/pkg/timer"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Recover turns a panic of the calls it wraps into an ErrPanic. If CrashDirEnv is set, a crash report is
+// written as well.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (result *types.Result, err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					errPanic := &ErrPanic{Value: v, Stack: debug.Stack()}
+					if dir := os.Getenv(CrashDirEnv); dir != "" {
+						path, errReport := writeCrashReport(dir, call, requestID(ctx), errPanic)
+						if errReport != nil {
+							logging.FromContext(ctx).Warn("failed to write crash report", "error", errReport)
+						}
+						errPanic.CrashReport = path
+					}
+					result, err = nil, errPanic
+				}
+			}()
+			return next(ctx, call)
+		}
+	}
+}
+
+// Logging logs the start and the outcome of the calls it wraps with the logger of their context.
+func Logging() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*types.Result, error) {
+			logger := logging.FromContext(ctx)
+			logger.Debug("call started")
+			result, err := next(ctx, call)
+			if err != nil {
+				logger.Warn("call failed", "error", err)
+			} else {
+				logger.Info("call succeeded")
+			}
+			return result, err
+		}
+	}
+}
+
+// Timing logs the duration of the calls it wraps, see timer.Track.
+func Timing() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*types.Result, error) {
+			defer timer.Duration(timer.Track(ctx, call.Method))
+			return next(ctx, call)
+		}
+	}
+}
+
+// rpcMetrics holds the metrics of every method, published with expvar.
+var rpcMetrics = expvar.NewMap("rpc")
+
+var rpcMetricsMu sync.Mutex
+
+// Metrics counts the calls it wraps, the failed ones and the time spent in them, per method. They are
+// published with expvar under "rpc", e.g. "rpc"."get_physical_id"."calls".
+func Metrics() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*types.Result, error) {
+			m := methodMetrics(call.Method)
+			start := time.Now()
+			result, err := next(ctx, call)
+			m.Add("calls", 1)
+			if err != nil {
+				m.Add("errors", 1)
+			}
+			m.AddFloat("duration_seconds", time.Since(start).Seconds())
+			return result, err
+		}
+	}
+}
+
+func methodMetrics(method string) *expvar.Map {
+	if m, ok := rpcMetrics.Get(method).(*expvar.Map); ok {
+		return m
+	}
+	rpcMetricsMu.Lock()
+	defer rpcMetricsMu.Unlock()
+	if m, ok := rpcMetrics.Get(method).(*expvar.Map); ok {
+		return m
+	}
+	m := new(expvar.Map).Init()
+	rpcMetrics.Set(method, m)
+	return m
+}
+
+type requestIDKey struct{}
+
+// NewRequestIDContext returns a copy of ctx carrying the ID of the request a call answers, reported by
+// Recover in crash reports.
+func NewRequestIDContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func requestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return "null"
+}
+
+// defaulter is implemented by params setting the default value of the optional params left out.
+type defaulter interface {
+	setDefaultValues()
+}
+
+// validator is implemented by params checking their values.
+type validator interface {
+	validateParams() error
+}
+
+// Validation sets the default values of the params of the calls it wraps and rejects the calls whose
+// params are invalid with an ErrValidation.
+func Validation() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*types.Result, error) {
+			if d, ok := call.Params.(defaulter); ok {
+				d.setDefaultValues()
+			}
+			if v, ok := call.Params.(validator); ok {
+				if err := v.validateParams(); err != nil {
+					var errValidation *ErrValidation
+					if errors.As(err, &errValidation) {
+						return nil, err
+					}
+					return nil, &ErrValidation{err}
+				}
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// awsParamsEmbedder is implemented by params embedding AWSParams.
+type awsParamsEmbedder interface {
+	awsParams() *AWSParams
+}
+
+// awsConfigOptional is implemented by params embedding AWSParams that only call AWS for some values,
+// e.g. to get a rule rather than test a pattern given inline.
+type awsConfigOptional interface {
+	needsAWSConfig() bool
+}
+
+// LoadAWSConfig loads the AWS config of the calls it wraps whose params embed AWSParams, for their
+// region, profile, endpoint URLs, role and retries. Calls that do not call AWS, see awsConfigOptional,
+// run without one, so that they do not fail on e.g. a missing profile.
+func LoadAWSConfig() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*types.Result, error) {
+			if e, ok := call.Params.(awsParamsEmbedder); ok {
+				p := e.awsParams()
+				if err := p.validateAWSParams(); err != nil {
+					return nil, &ErrValidation{err}
+				}
+				if o, ok := call.Params.(awsConfigOptional); ok && !o.needsAWSConfig() {
+					return next(ctx, call)
+				}
+				cfg, err := config.GetAWSConfig(ctx, p.Region, p.Profile, call.Metadata, p.configOptions)
+				if err != nil {
+					return nil, fmt.Errorf("error loading AWS config: %w", err)
+				}
+				p.cfg = cfg
+			}
+			return next(ctx, call)
+		}
+	}
+}
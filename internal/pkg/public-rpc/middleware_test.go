@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestRecover(t *testing.T) {
+	r := NewRegistry(Recover())
+	r.Register("test.echo", new(testParams))
+
+	result, err := r.Call(context.Background(), "test.echo", []byte(`{"Message":"hello","Panic":true}`), nil)
+
+	assert.Nil(t, result)
+	var errPanic *ErrPanic
+	require.ErrorAs(t, err, &errPanic)
+	assert.Equal(t, "boom", errPanic.Value)
+	assert.Contains(t, string(errPanic.Stack), "RPCMethod")
+	assert.EqualError(t, err, "panic: boom")
+}
+
+func TestValidation(t *testing.T) {
+	r := NewRegistry(Validation())
+	r.Register("test.echo", new(testParams))
+
+	_, err := r.Call(context.Background(), "test.echo", []byte(`{}`), nil)
+	var errValidation *ErrValidation
+	require.ErrorAs(t, err, &errValidation)
+	assert.EqualError(t, err, `missing required param "Message"`)
+
+	result, err := r.Call(context.Background(), "test.echo", []byte(`{"Message":"hello"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result.Output)
+}
+
+type testAWSParams struct {
+	AWSParams
+}
+
+func (p *testAWSParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	return &types.Result{Output: p.cfg}, nil
+}
+
+func (p *testAWSParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(aws.Config{}))
+}
+
+func TestLoadAWSConfig(t *testing.T) {
+	r := NewRegistry(LoadAWSConfig())
+	r.Register("test.aws", new(testAWSParams))
+
+	result, err := r.Call(context.Background(), "test.aws", []byte(`{"Region":"us-west-2","EndpointURL":"http://localhost:4566","EndpointURLs":{"SQS":"http://localhost:9324"}}`), nil)
+	require.NoError(t, err)
+	cfg := result.Output.(aws.Config)
+	assert.Equal(t, "us-west-2", cfg.Region)
+	endpoint, err := cfg.EndpointResolverWithOptions.ResolveEndpoint("EventBridge", "us-west-2")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4566", endpoint.URL)
+	endpoint, err = cfg.EndpointResolverWithOptions.ResolveEndpoint("SQS", "us-west-2")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:9324", endpoint.URL)
+
+	_, err = r.Call(context.Background(), "test.aws", []byte(`{"Region":"us-west-2","EndpointURLs":{"SQS":"localhost:9324"}}`), nil)
+	var errValidation *ErrValidation
+	require.ErrorAs(t, err, &errValidation)
+	assert.EqualError(t, err, `invalid endpoint URL "localhost:9324", expected an absolute URL such as http://localhost:4566 for service "SQS"`)
+}
+
+type testOptionalAWSParams struct {
+	testAWSParams
+	Offline bool
+}
+
+func (p *testOptionalAWSParams) needsAWSConfig() bool {
+	return !p.Offline
+}
+
+func TestLoadAWSConfigOptional(t *testing.T) {
+	r := NewRegistry(LoadAWSConfig())
+	r.Register("test.aws", new(testOptionalAWSParams))
+
+	result, err := r.Call(context.Background(), "test.aws", []byte(`{"Region":"us-west-2","Offline":true}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Config{}, result.Output)
+
+	result, err = r.Call(context.Background(), "test.aws", []byte(`{"Region":"us-west-2"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "us-west-2", result.Output.(aws.Config).Region)
+
+	_, err = r.Call(context.Background(), "test.aws", []byte(`{"Region":"us-west-2","EndpointURL":"localhost","Offline":true}`), nil)
+	var errValidation *ErrValidation
+	assert.ErrorAs(t, err, &errValidation)
+}
+
+func TestTiming(t *testing.T) {
+	r := NewRegistry(Timing())
+	r.Register("test.echo", new(testParams))
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ctx := logging.NewContext(context.Background(), logger.With(logging.RequestIDKey, "42"))
+
+	_, err := r.Call(ctx, "test.echo", []byte(`{"Message":"hello"}`), nil)
+
+	require.NoError(t, err)
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "test.echo", line["msg"])
+	assert.Equal(t, "42", line[logging.RequestIDKey])
+	assert.Contains(t, line, "duration")
+}
+
+func TestMetrics(t *testing.T) {
+	r := NewRegistry(Metrics())
+	r.Register("test.metrics", new(testParams))
+
+	_, err := r.Call(context.Background(), "test.metrics", []byte(`{"Message":"hello"}`), nil)
+	require.NoError(t, err)
+	_, err = r.Call(context.Background(), "test.metrics", []byte(`{"Message":"fail"}`), nil)
+	require.Error(t, err)
+
+	m, ok := expvar.Get("rpc").(*expvar.Map).Get("test.metrics").(*expvar.Map)
+	require.True(t, ok)
+	assert.Equal(t, "2", m.Get("calls").String())
+	assert.Equal(t, "1", m.Get("errors").String())
+	assert.NotNil(t, m.Get("duration_seconds"))
+}
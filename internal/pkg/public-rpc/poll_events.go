@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/harness/eventbridge/listener"
+	"iatk/internal/pkg/harness/eventbridge/pattern"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
 	"reflect"
@@ -14,34 +14,27 @@ import (
 )
 
 type PollEventsParams struct {
-	ListenerID          string `json:"ListenerId"`
-	WaitTimeSeconds     *int32
-	MaxNumberOfMessages *int32
-	Profile             string
-	Region              string
-}
-
-func (p *PollEventsParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	p.setDefaultValues()
-
-	err := p.validateParams()
-	if err != nil {
-		return nil, err
-	}
+	ListenerID          string `json:"ListenerId" description:"Id of the Listener that was created" required:"true"`
+	WaitTimeSeconds     *int32 `description:"Time in seconds to wait for polling"`
+	MaxNumberOfMessages *int32 `description:"Max number of messages to poll"`
+	EventPattern        string `json:"EventPattern,omitempty" description:"Event pattern the events returned must match. Events polled that do not match it are left on the queue"`
 
-	ctx := context.TODO()
+	AWSParams
+}
 
-	cfg, err := config.GetAWSConfig(ctx, p.Region, p.Profile, metadata)
+func (p *PollEventsParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	lr, err := listener.Get(ctx, p.ListenerID, listener.NewOptions(p.cfg))
 	if err != nil {
-		return nil, fmt.Errorf("error loading AWS config: %w", err)
+		return nil, fmt.Errorf("error retreiving listener info: %w", err)
 	}
 
-	lr, err := listener.Get(ctx, p.ListenerID, listener.NewOptions(cfg))
-	if err != nil {
-		return nil, fmt.Errorf("error retreiving listener info: %w", err)
+	var pt *pattern.Pattern
+	if p.EventPattern != "" {
+		// validated by validateParams
+		pt, _ = pattern.Parse(p.EventPattern)
 	}
 
-	events, err := listener.PollEvents(ctx, lr, *p.WaitTimeSeconds, *p.MaxNumberOfMessages)
+	events, err := listener.PollEvents(ctx, lr, *p.WaitTimeSeconds, *p.MaxNumberOfMessages, pt)
 	if err != nil {
 		return nil, fmt.Errorf("error polling events: %w", err)
 	}
@@ -69,6 +62,12 @@ func (p *PollEventsParams) validateParams() error {
 	if *p.WaitTimeSeconds < 0 || *p.WaitTimeSeconds > 20 {
 		return errors.New(`"WaitTimeSeconds" must be an integer between 0 and 20`)
 	}
+
+	if p.EventPattern != "" {
+		if _, err := pattern.Parse(p.EventPattern); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
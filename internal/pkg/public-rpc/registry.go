@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Call is a call of a method, its params decoded.
+type Call struct {
+	// Method is the name the method is registered under.
+	Method string
+	// Params are the decoded params, whose RPCMethod runs the call.
+	Params Method
+	// Metadata describes the client, if it sent any.
+	Metadata *jsonrpc.Metadata
+}
+
+// Handler runs a call.
+type Handler func(ctx context.Context, call *Call) (*types.Result, error)
+
+// Middleware wraps a Handler with behavior shared by all methods, e.g. logging.
+type Middleware func(next Handler) Handler
+
+// Registry holds the methods that can be called by name and the middleware every call runs through.
+type Registry struct {
+	mu         sync.RWMutex
+	methods    map[string]Method
+	middleware []Middleware
+}
+
+// NewRegistry returns an empty registry whose calls run through middleware, the first one being the
+// outermost.
+func NewRegistry(middleware ...Middleware) *Registry {
+	return &Registry{
+		methods:    map[string]Method{},
+		middleware: middleware,
+	}
+}
+
+// Register makes a method callable by name. params is a pointer to the params of the method; each call
+// decodes its params into a new instance of the same type, so that concurrent or repeated calls never
+// share state. Register panics if name is already registered.
+func (r *Registry) Register(name string, params Method) {
+	if reflect.TypeOf(params).Kind() != reflect.Pointer {
+		panic(fmt.Sprintf("params of method %q must be a pointer", name))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.methods[name]; ok {
+		panic(fmt.Sprintf("method %q is already registered", name))
+	}
+	r.methods[name] = params
+}
+
+// Use appends middleware to the ones calls run through, inside the existing ones.
+func (r *Registry) Use(middleware ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Methods returns the registered methods keyed by name.
+func (r *Registry) Methods() map[string]Method {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	methods := make(map[string]Method, len(r.methods))
+	for name, m := range r.methods {
+		methods[name] = m
+	}
+	return methods
+}
+
+// Names returns the names of the registered methods, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.methods))
+	for name := range r.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Call decodes params for the method registered under name and runs the call through the middleware.
+// It returns an ErrNoMethodFound if no method is registered under name and an ErrParameter if params
+// cannot be decoded.
+func (r *Registry) Call(ctx context.Context, name string, params json.RawMessage, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	r.mu.RLock()
+	registered, ok := r.methods[name]
+	middleware := r.middleware
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &ErrNoMethodFound{
+			err: fmt.Sprintf("No Method found for %s", name),
+		}
+	}
+
+	requestParams := reflect.New(reflect.TypeOf(registered).Elem()).Interface().(Method)
+	decoder := newDecoder(params)
+	if err := decoder.Decode(requestParams); err != nil {
+		return nil, &ErrParameter{
+			ParentErr: err,
+		}
+	}
+
+	h := run
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h(ctx, &Call{Method: name, Params: requestParams, Metadata: metadata})
+}
+
+func run(ctx context.Context, call *Call) (*types.Result, error) {
+	return call.Params.RPCMethod(ctx, call.Metadata)
+}
+
+func newDecoder(params json.RawMessage) *json.Decoder {
+	reader := bytes.NewReader(params)
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	return decoder
+}
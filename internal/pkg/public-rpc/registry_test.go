@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testParams struct {
+	Message  string
+	Panic    bool `json:",omitempty"`
+	defaults bool
+}
+
+func (p *testParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	if p.Panic {
+		panic("boom")
+	}
+	if p.Message == "fail" {
+		return nil, errors.New("failed")
+	}
+	return &types.Result{Output: p.Message}, nil
+}
+
+func (p *testParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(""))
+}
+
+func (p *testParams) setDefaultValues() {
+	p.defaults = true
+}
+
+func (p *testParams) validateParams() error {
+	if !p.defaults {
+		return errors.New("defaults not set")
+	}
+	if p.Message == "" {
+		return errors.New(`missing required param "Message"`)
+	}
+	return nil
+}
+
+func TestRegistryCall(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) (*types.Result, error) {
+				order = append(order, name+":"+call.Method)
+				return next(ctx, call)
+			}
+		}
+	}
+	r := NewRegistry(trace("first"), trace("second"))
+	r.Use(trace("third"))
+	r.Register("test.echo", new(testParams))
+
+	result, err := r.Call(context.Background(), "test.echo", []byte(`{"Message":"hello"}`), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result.Output)
+	assert.Equal(t, []string{"first:test.echo", "second:test.echo", "third:test.echo"}, order)
+}
+
+func TestRegistryCallErrors(t *testing.T) {
+	r := NewRegistry()
+	r.Register("test.echo", new(testParams))
+
+	_, err := r.Call(context.Background(), "does.not.exist", []byte(`{}`), nil)
+	var errNoMethodFound *ErrNoMethodFound
+	assert.ErrorAs(t, err, &errNoMethodFound)
+
+	_, err = r.Call(context.Background(), "test.echo", []byte(`{"Unknown":1}`), nil)
+	var errParameter *ErrParameter
+	assert.ErrorAs(t, err, &errParameter)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	r.Register("test.b", new(testParams))
+	r.Register("test.a", new(testParams))
+
+	assert.Equal(t, []string{"test.a", "test.b"}, r.Names())
+	assert.Len(t, r.Methods(), 2)
+	assert.PanicsWithValue(t, `method "test.a" is already registered`, func() {
+		r.Register("test.a", new(testParams))
+	})
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	for name, m := range Default.Methods() {
+		assert.Equal(t, reflect.Pointer, reflect.TypeOf(m).Kind(), name)
+	}
+	assert.Contains(t, Default.Names(), "iatk.capabilities")
+}
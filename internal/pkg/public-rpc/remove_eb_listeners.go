@@ -4,13 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
 
-	"iatk/internal/pkg/aws/config"
 	"iatk/internal/pkg/harness/eventbridge/listener"
 	"iatk/internal/pkg/harness/tags"
 	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/logging"
 	"iatk/internal/pkg/public-rpc/types"
 
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
@@ -18,46 +17,49 @@ import (
 )
 
 type RemoveEbListenersParams struct {
-	IDs        []string `json:"Ids"`
-	TagFilters []tagtypes.TagFilter
-	Profile    string
-	Region     string
-}
-
-func (p *RemoveEbListenersParams) RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error) {
-	if p.IDs != nil && p.TagFilters != nil {
-		return nil, errors.New("only one of Ids and TagFilters is needed, not both")
-	}
+	IDs        []string             `json:"Ids,omitempty" description:"Ids of the Listeners to remove, one of Ids and TagFilters must be supplied"`
+	TagFilters []tagtypes.TagFilter `json:"TagFilters,omitempty" description:"Tag filters matching the Listeners to remove, one of Ids and TagFilters must be supplied"`
+	DryRun     bool                 `json:"DryRun,omitempty" description:"Whether to only report the Listeners and components that would be removed, without removing them"`
 
-	ctx := context.TODO()
-
-	cfg, err := config.GetAWSConfig(ctx, p.Region, p.Profile, metadata)
-	if err != nil {
-		return nil, fmt.Errorf("error when loading AWS config: %v", err)
-	}
+	AWSParams
+}
 
+func (p *RemoveEbListenersParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	taggingClient := resourcegroupstaggingapi.NewFromConfig(p.cfg)
 	var listenerIDs []string
 	if p.TagFilters != nil {
-		listenerIDs, err = tags.GetTestHarnessIDsWithTagFilters(ctx, resourcegroupstaggingapi.NewFromConfig(cfg), p.TagFilters)
+		var err error
+		listenerIDs, err = tags.GetTestHarnessIDsWithTagFilters(ctx, taggingClient, p.TagFilters)
 		if err != nil {
-			return nil, fmt.Errorf("unable to find listeners with tag filters: %v", err)
+			return nil, fmt.Errorf("unable to find listeners with tag filters: %w", err)
 		}
-		log.Printf("found listener ids matching tag filters: %v", listenerIDs)
+		logging.FromContext(ctx).Info("found listener ids matching tag filters", "ids", listenerIDs)
 	} else {
 		listenerIDs = p.IDs
 	}
 
-	err = listener.DestroyMultiple(ctx, listenerIDs, cfg, listener.NewDestroyOptions())
-
+	opts := listener.NewDestroyOptions()
+	opts.DryRun = p.DryRun
+	// finds the rule of a listener whose queue is gone, to destroy it too
+	opts.Get = listener.GetOrRecover(taggingClient)
+	report, err := listener.DestroyMultiple(ctx, listenerIDs, p.cfg, opts)
 	if err != nil {
-		return nil, err
+		// failures are reported per listener, for callers to retry only those
+		return nil, &ErrDestroy{Err: err, Report: report.JSON()}
 	}
 
 	return &types.Result{
-		Output: "success",
+		Output: report.JSON(),
 	}, nil
 }
 
+func (p *RemoveEbListenersParams) validateParams() error {
+	if p.IDs != nil && p.TagFilters != nil {
+		return errors.New("only one of Ids and TagFilters is needed, not both")
+	}
+	return nil
+}
+
 func (p *RemoveEbListenersParams) ReflectOutput() reflect.Value {
-	return reflect.New(reflect.TypeOf(""))
+	return reflect.New(reflect.TypeOf([]listener.DestroyOutput{})).Elem()
 }
@@ -1,51 +1,45 @@
 package publicrpc
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"iatk/internal/pkg/jsonrpc"
 	"iatk/internal/pkg/public-rpc/types"
 	"reflect"
 )
 
+// Method is implemented by the params of a method: RPCMethod runs a call with the params decoded from
+// the request and ReflectOutput declares the type of its result.
 type Method interface {
-	RPCMethod(metadata *jsonrpc.Metadata) (*types.Result, error)
+	RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error)
 	ReflectOutput() reflect.Value
 }
 
-var MethodMap = map[string]Method{}
+// Default is the registry of the methods served by the binary, running every call through the middleware
+// recovering from panics, logging, timing and counting it, and validating its params and loading its
+// AWS config before running it.
+var Default = NewDefaultRegistry()
 
-func init() {
-	MethodMap["get_physical_id"] = new(GetPhysicalIdParams)
-	MethodMap["get_stack_outputs"] = new(GetStackOutputParams)
-	MethodMap["test_harness.eventbridge.add_listener"] = new(AddEbListenerParams)
-	MethodMap["test_harness.eventbridge.remove_listeners"] = new(RemoveEbListenersParams)
-	MethodMap["test_harness.eventbridge.poll_events"] = new(PollEventsParams)
-	MethodMap["get_trace_tree"] = new(GetTraceTreeParams)
-	MethodMap["mock.generate_barebone_event"] = new(GenerateBareboneEventsParams)
-}
-
-func GetRPCStruct(methodName string, params json.RawMessage) (Method, error) {
-	requestParams, ok := MethodMap[methodName]
-	if !ok {
-		return nil, &ErrNoMethodFound{
-			err: fmt.Sprintf("No Method found for %s", methodName),
-		}
-	}
-
-	decoder := newDecoder(params)
-	if err := decoder.Decode(requestParams); err != nil {
-		return nil, &ErrParameter{
-			ParentErr: err,
-		}
-	}
-	return requestParams, nil
-}
-
-func newDecoder(params json.RawMessage) *json.Decoder {
-	reader := bytes.NewReader(params)
-	decoder := json.NewDecoder(reader)
-	decoder.DisallowUnknownFields()
-	return decoder
+// NewDefaultRegistry returns a new registry of the methods served by the binary, see Default. Calls run
+// through middleware, e.g. to check their params further, after being logged, timed and counted and before their params are
+// validated.
+func NewDefaultRegistry(middleware ...Middleware) *Registry {
+	chain := []Middleware{Recover(), Logging(), Timing(), Metrics()}
+	chain = append(chain, middleware...)
+	chain = append(chain, Validation(), LoadAWSConfig())
+	r := NewRegistry(chain...)
+	r.Register("get_physical_id", new(GetPhysicalIdParams))
+	r.Register("get_stack_outputs", new(GetStackOutputParams))
+	r.Register("test_harness.eventbridge.add_listener", new(AddEbListenerParams))
+	r.Register("test_harness.eventbridge.remove_listeners", new(RemoveEbListenersParams))
+	r.Register("test_harness.eventbridge.poll_events", new(PollEventsParams))
+	r.Register("test_harness.eventbridge.test_pattern", new(TestEbPatternParams))
+	r.Register("test_harness.list", new(ListTestHarnessesParams))
+	r.Register("test_harness.describe", new(DescribeTestHarnessParams))
+	r.Register("test_harness.gc", new(GCParams))
+	r.Register("get_trace_tree", new(GetTraceTreeParams))
+	r.Register("mock.generate_barebone_event", new(GenerateBareboneEventsParams))
+	r.Register("rpc.discover", new(DiscoverParams))
+	r.Register("iatk.version", new(VersionParams))
+	r.Register("iatk.capabilities", new(CapabilitiesParams))
+	return r
 }
@@ -0,0 +1,77 @@
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/harness/eventbridge/pattern"
+	"iatk/internal/pkg/harness/resource/eventrule"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+)
+
+type TestEbPatternParams struct {
+	EventPattern string   `json:"EventPattern,omitempty" description:"Event pattern to test. Required if no RuleName is supplied"`
+	RuleName     string   `json:"RuleName,omitempty" description:"Name of a Rule whose event pattern to test"`
+	EventBusName string   `json:"EventBusName,omitempty" description:"Name of the AWS Event Bus of the Rule, default if not supplied"`
+	Events       []string `json:"Events,omitempty" description:"Events to match against the event pattern, as JSON strings. If not supplied, the event pattern is only validated"`
+
+	AWSParams
+}
+
+func (p *TestEbPatternParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	eventPattern := p.EventPattern
+	if p.RuleName != "" {
+		r, err := eventrule.Get(ctx, eventbridge.NewFromConfig(p.cfg), p.RuleName, p.EventBusName)
+		if err != nil {
+			return nil, err
+		}
+		eventPattern = r.EventPattern
+	}
+
+	pt, err := pattern.Parse(eventPattern)
+	if err != nil {
+		if p.RuleName == "" {
+			return nil, fmt.Errorf("invalid event pattern: %w", err)
+		}
+		return nil, fmt.Errorf("rule %q has no valid event pattern: %w", p.RuleName, err)
+	}
+
+	return &types.Result{
+		Output: pattern.Test(pt, p.Events),
+	}, nil
+}
+
+func (p *TestEbPatternParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(pattern.TestOutput{})).Elem()
+}
+
+// needsAWSConfig tells whether the event pattern is the one of a rule, got from AWS; a pattern given inline
+// is tested offline.
+func (p *TestEbPatternParams) needsAWSConfig() bool {
+	return p.RuleName != ""
+}
+
+func (p *TestEbPatternParams) validateParams() error {
+	if p.EventPattern == "" && p.RuleName == "" {
+		return errors.New(`missing required param "EventPattern" or "RuleName"`)
+	}
+	if p.EventPattern != "" && p.RuleName != "" {
+		return errors.New(`only one of "EventPattern" and "RuleName" can be supplied`)
+	}
+	if p.EventPattern != "" {
+		if _, err := pattern.Parse(p.EventPattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *TestEbPatternParams) setDefaultValues() {
+	if p.EventBusName == "" {
+		p.EventBusName = "default"
+	}
+}
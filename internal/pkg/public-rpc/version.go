@@ -0,0 +1,21 @@
+package publicrpc
+
+import (
+	"context"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"iatk/internal/pkg/version"
+	"reflect"
+)
+
+type VersionParams struct{}
+
+func (p *VersionParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	return &types.Result{
+		Output: version.Get(),
+	}, nil
+}
+
+func (p *VersionParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(version.Info{})).Elem()
+}
@@ -0,0 +1,303 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/logging"
+	"iatk/internal/pkg/progress"
+	publicrpc "iatk/internal/pkg/public-rpc"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// CancelRequestMethod is the method of the notification a client sends to abort one of its requests
+// that is still in flight, e.g. {"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"42"}}.
+// The cancelled request is answered with ErrCodeCancelled; the notification itself is never answered.
+const CancelRequestMethod = "$/cancelRequest"
+
+type cancelParams struct {
+	ID *string `json:"id"`
+}
+
+// ProgressMethod is the method of the notifications sent ahead of the response of a request whose
+// metadata asks for progress, one for each step the call completes.
+const ProgressMethod = "$/progress"
+
+// ProgressParams are the params of a ProgressMethod notification.
+type ProgressParams struct {
+	// ID identifies the request the progress is reported for.
+	ID *string `json:"id"`
+	// Message describes the step completed, e.g. "queue created".
+	Message string `json:"message"`
+	// Data holds the details of the step, e.g. the ARN of the queue.
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// WithProgress returns a copy of ctx in which the progress reported by the call of req is sent
+// through notify, if the request metadata asks for it.
+func WithProgress(ctx context.Context, req jsonrpc.Request, notify func([]byte)) context.Context {
+	if notify == nil || req.ID == nil || req.Metadata == nil || !req.Metadata.Progress {
+		return ctx
+	}
+	return progress.NewContext(ctx, func(message string, data map[string]interface{}) {
+		notify(encode(jsonrpc.Notification{
+			JSONRPC: "2.0",
+			Method:  ProgressMethod,
+			Params:  ProgressParams{ID: req.ID, Message: message, Data: data},
+		}, nil))
+	})
+}
+
+// Handle answers msg, which holds either a single request or a batch of requests as a JSON array, and
+// returns the encoded reply, or nil if there is nothing to reply. Requests of a batch run concurrently
+// and a failing one does not affect the others; their responses are returned in the same order as the
+// requests. Notifications to the client, such as progress, are sent through notify if it is not nil.
+func Handle(ctx context.Context, msg []byte, notify func([]byte)) []byte {
+	return newSession(notify).handle(ctx, msg)
+}
+
+// session tracks the requests of one client that are in flight, so that they can be cancelled by ID.
+type session struct {
+	mu       sync.Mutex
+	inflight map[string]*call
+	notify   func([]byte)
+}
+
+type call struct {
+	cancel context.CancelFunc
+}
+
+func newSession(notify func([]byte)) *session {
+	return &session{inflight: map[string]*call{}, notify: notify}
+}
+
+func (s *session) handle(ctx context.Context, msg []byte) []byte {
+	if !IsBatch(msg) {
+		resp, ok := s.handleSingle(ctx, msg)
+		if !ok {
+			return nil
+		}
+		return encode(resp, resp.ID)
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		logging.FromContext(ctx).Warn("failed to decode batch", "error", err)
+		return encode(jsonrpc.ParseError(nil), nil)
+	}
+	if len(batch) == 0 {
+		return encode(jsonrpc.InvalidRequestError(nil), nil)
+	}
+
+	responses := make([]*jsonrpc.Response, len(batch))
+	var wg sync.WaitGroup
+	for i, raw := range batch {
+		wg.Add(1)
+		go func(i int, raw json.RawMessage) {
+			defer wg.Done()
+			var req jsonrpc.Request
+			defer func() {
+				// a panic outside of the method, which Recover does not cover, must not stop the process
+				if v := recover(); v != nil {
+					resp := panicResponse(ctx, req.ID, &publicrpc.ErrPanic{Value: v, Stack: debug.Stack()})
+					responses[i] = &resp
+				}
+			}()
+			req, err := DecodeRequest(bytes.NewReader(raw))
+			if err != nil {
+				logging.FromContext(ctx).Warn("failed to decode request of batch", "index", i, "error", err)
+				resp := jsonrpc.InvalidRequestError(req.ID)
+				responses[i] = &resp
+				return
+			}
+			if resp, ok := s.handleRequest(ctx, req); ok {
+				responses[i] = &resp
+			}
+		}(i, raw)
+	}
+	wg.Wait()
+
+	var reply jsonrpc.BatchResponse
+	for _, resp := range responses {
+		if resp != nil {
+			reply = append(reply, *resp)
+		}
+	}
+	// a batch of notifications only is not answered at all
+	if len(reply) == 0 {
+		return nil
+	}
+	return encode(reply, nil)
+}
+
+// IsBatch reports whether msg holds a batch, i.e. a JSON array of requests.
+func IsBatch(msg []byte) bool {
+	trimmed := bytes.TrimLeft(msg, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+func (s *session) handleSingle(ctx context.Context, msg []byte) (jsonrpc.Response, bool) {
+	req, err := DecodeRequest(bytes.NewReader(msg))
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to decode request", "error", err)
+		return jsonrpc.ParseError(req.ID), true
+	}
+	return s.handleRequest(ctx, req)
+}
+
+// handleRequest dispatches req and reports whether its response is to be sent, which it is not for
+// notifications, i.e. requests without an ID.
+func (s *session) handleRequest(ctx context.Context, req jsonrpc.Request) (jsonrpc.Response, bool) {
+	if req.Method == CancelRequestMethod {
+		s.cancel(ctx, req.Params)
+		return jsonrpc.Response{}, false
+	}
+	ctx = WithProgress(ctx, req, s.notify)
+	if req.ID == nil {
+		Dispatch(ctx, req)
+		return jsonrpc.Response{}, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c := &call{cancel: cancel}
+	s.mu.Lock()
+	s.inflight[*req.ID] = c
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// the ID may have been reused by a later request in the meantime
+		if s.inflight[*req.ID] == c {
+			delete(s.inflight, *req.ID)
+		}
+	}()
+
+	return Dispatch(ctx, req), true
+}
+
+func (s *session) cancel(ctx context.Context, params json.RawMessage) {
+	var p cancelParams
+	if err := json.Unmarshal(params, &p); err != nil || p.ID == nil {
+		logging.FromContext(ctx).Warn("ignoring cancel request without a request id")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.inflight[*p.ID]
+	if !ok {
+		logging.FromContext(ctx).Debug("ignoring cancel request, the request is not in flight", logging.RequestIDKey, *p.ID)
+		return
+	}
+	c.cancel()
+}
+
+func encode(reply interface{ Encode() ([]byte, error) }, id *string) []byte {
+	buffer, err := reply.Encode()
+
+	// This shouldn't happen but in the event it does, report an Internal Service Error
+	if err != nil {
+		slog.Error("failed to encode response", "error", err)
+		buffer, _ = jsonrpc.InternalServiceError(id).Encode()
+	}
+	return buffer
+}
+
+// Dispatch runs a single request against publicrpc.Default and returns the response to write back.
+// The call is aborted when ctx is done or, if set, the timeout of the request metadata has elapsed.
+func Dispatch(ctx context.Context, req jsonrpc.Request) jsonrpc.Response {
+	ctx = withRequestLogger(ctx, req)
+	if req.Metadata != nil && req.Metadata.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.Metadata.Timeout*float64(time.Second)))
+		defer cancel()
+	}
+
+	if req.ID != nil {
+		ctx = publicrpc.NewRequestIDContext(ctx, *req.ID)
+	}
+	result, errRPC := publicrpc.Default.Call(ctx, req.Method, req.Params, req.Metadata)
+	if errRPC != nil {
+		var errPanic *publicrpc.ErrPanic
+		if errors.As(errRPC, &errPanic) {
+			return panicResponse(ctx, req.ID, errPanic)
+		}
+		return errorResponse(req.ID, errRPC)
+	}
+
+	return jsonrpc.Response{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  result,
+	}
+}
+
+// panicResponse answers the request of id, whose handling panicked, with an internal error carrying the
+// stack trace of the panic and the path of its crash report, if any.
+func panicResponse(ctx context.Context, id *string, errPanic *publicrpc.ErrPanic) jsonrpc.Response {
+	resp := jsonrpc.InternalServiceError(id)
+	resp.Error.Data = &jsonrpc.ErrData{Stack: string(errPanic.Stack), CrashReport: errPanic.CrashReport}
+	logging.FromContext(ctx).Error("method panicked", "panic", fmt.Sprint(errPanic.Value), "crash_report", errPanic.CrashReport)
+	return resp
+}
+
+// withRequestLogger returns a copy of ctx whose logger identifies req on every line and logs at the
+// level the request metadata asks for, if any.
+func withRequestLogger(ctx context.Context, req jsonrpc.Request) context.Context {
+	logger := logging.FromContext(ctx).With(logging.MethodKey, req.Method)
+	if req.ID != nil {
+		logger = logger.With(logging.RequestIDKey, *req.ID)
+	}
+	if req.Metadata != nil && req.Metadata.LogLevel != "" {
+		level, err := logging.ParseLevel(req.Metadata.LogLevel)
+		if err != nil {
+			logger.Warn("ignoring log level of request metadata", "error", err)
+		} else {
+			logger = logging.WithLevel(logger, level)
+		}
+	}
+	return logging.NewContext(ctx, logger)
+}
+
+func errorResponse(id *string, errRPC error) jsonrpc.Response {
+	var errNoMethodFound *publicrpc.ErrNoMethodFound
+	if errors.As(errRPC, &errNoMethodFound) {
+		return jsonrpc.NoMethodFoundError(id)
+	}
+	var errParameter *publicrpc.ErrParameter
+	if errors.As(errRPC, &errParameter) {
+		return jsonrpc.InvalidParamsError(id)
+	}
+	var errValidation *publicrpc.ErrValidation
+	if errors.As(errRPC, &errValidation) {
+		return jsonrpc.Response{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &jsonrpc.ErrIatk{
+				Code:    jsonrpc.ErrCodeValidation,
+				Message: errRPC.Error(),
+			},
+		}
+	}
+
+	resp := jsonrpc.ServiceError(id, errRPC)
+	var errDestroy *publicrpc.ErrDestroy
+	if errors.As(errRPC, &errDestroy) {
+		if resp.Error.Data == nil {
+			resp.Error.Data = &jsonrpc.ErrData{}
+		}
+		resp.Error.Data.Report = errDestroy.Report
+	}
+	return resp
+}
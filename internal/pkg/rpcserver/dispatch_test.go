@@ -0,0 +1,223 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	publicrpc "iatk/internal/pkg/public-rpc"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle(t *testing.T) {
+	cases := map[string]struct {
+		input  string
+		expect string
+	}{
+		"single request": {
+			input:  `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expect: `{"jsonrpc":"2.0","id":"1","result":{"output":"hello"}}`,
+		},
+		"not json": {
+			input:  `not json`,
+			expect: `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error"}}`,
+		},
+		"empty batch": {
+			input:  ` []`,
+			expect: `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`,
+		},
+		"invalid batch": {
+			input:  `[{"jsonrpc":"2.0"`,
+			expect: `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error"}}`,
+		},
+		"batch keeps request order": {
+			input: `[
+				{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"first"}},
+				{"jsonrpc":"2.0","id":"2","method":"test.echo","params":{}},
+				1,
+				{"jsonrpc":"2.0","id":"3","method":"does.not.exist","params":{}},
+				{"jsonrpc":"2.0","id":"4","method":"test.echo","params":{"Message":"last"}}
+			]`,
+			expect: `[` +
+				`{"jsonrpc":"2.0","id":"1","result":{"output":"first"}},` +
+				`{"jsonrpc":"2.0","id":"2","error":{"code":14,"message":"missing required param \"Message\""}},` +
+				`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}},` +
+				`{"jsonrpc":"2.0","id":"3","error":{"code":-32601,"message":"Method not found"}},` +
+				`{"jsonrpc":"2.0","id":"4","result":{"output":"last"}}` +
+				`]`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := Handle(context.Background(), []byte(tt.input), nil)
+			assert.Equal(t, tt.expect+"\n", string(actual))
+		})
+	}
+}
+
+func TestHandleBatchOfOne(t *testing.T) {
+	actual := Handle(context.Background(), []byte(`[{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}]`), nil)
+
+	var batch jsonrpc.BatchResponse
+	require.NoError(t, json.Unmarshal(actual, &batch))
+	require.Len(t, batch, 1)
+	assert.Equal(t, "1", *batch[0].ID)
+}
+
+func TestDispatchTimeout(t *testing.T) {
+	id := "1"
+	resp := Dispatch(context.Background(), jsonrpc.Request{
+		JSONRPC:  "2.0",
+		ID:       &id,
+		Method:   testWaitMethod,
+		Params:   []byte(`{}`),
+		Metadata: &jsonrpc.Metadata{Timeout: 0.01},
+	})
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, jsonrpc.ErrCodeTimeout, resp.Error.Code)
+	assert.Equal(t, "stopped waiting: context deadline exceeded", resp.Error.Message)
+}
+
+func TestCancelRequest(t *testing.T) {
+	s := newSession(nil)
+	replies := make(chan []byte, 1)
+	go func() {
+		replies <- s.handle(context.Background(), []byte(`{"jsonrpc":"2.0","id":"1","method":"test.wait","params":{}}`))
+	}()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.inflight["1"] != nil
+	}, time.Second, time.Millisecond)
+
+	reply := s.handle(context.Background(), []byte(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"1"}}`))
+
+	assert.Nil(t, reply)
+	select {
+	case reply = <-replies:
+		assert.Equal(t, `{"jsonrpc":"2.0","id":"1","error":{"code":16,"message":"stopped waiting: context canceled"}}`+"\n", string(reply))
+	case <-time.After(time.Second):
+		t.Fatal("request was not cancelled")
+	}
+	assert.Empty(t, s.inflight)
+}
+
+func TestHandleBatchOfNotifications(t *testing.T) {
+	actual := Handle(context.Background(), []byte(`[{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"1"}}]`), nil)
+
+	assert.Nil(t, actual)
+}
+
+func TestHandleNotification(t *testing.T) {
+	var out strings.Builder
+
+	err := Serve(context.Background(), strings.NewReader(`{"jsonrpc":"2.0","method":"test.echo","params":{"Message":"hello"}}`+"\n"), &out)
+
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+	actual := Handle(context.Background(), []byte(`[
+		{"jsonrpc":"2.0","method":"test.echo","params":{"Message":"hello"}},
+		{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"world"}}
+	]`), nil)
+	assert.Equal(t, `[{"jsonrpc":"2.0","id":"1","result":{"output":"world"}}]`+"\n", string(actual))
+}
+
+func TestHandleProgress(t *testing.T) {
+	cases := map[string]struct {
+		input        string
+		expectNotify []string
+	}{
+		"progress asked": {
+			input:        `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"},"metadata":{"progress":true}}`,
+			expectNotify: []string{`{"jsonrpc":"2.0","method":"$/progress","params":{"id":"1","message":"echoing","data":{"length":5}}}` + "\n"},
+		},
+		"progress not asked": {
+			input: `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"},"metadata":{}}`,
+		},
+		"no metadata": {
+			input: `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var notified []string
+			actual := Handle(context.Background(), []byte(tt.input), func(buffer []byte) {
+				notified = append(notified, string(buffer))
+			})
+
+			assert.Equal(t, `{"jsonrpc":"2.0","id":"1","result":{"output":"hello"}}`+"\n", string(actual))
+			assert.Equal(t, tt.expectNotify, notified)
+		})
+	}
+}
+
+func TestDispatchPanic(t *testing.T) {
+	id := "1"
+	req := jsonrpc.Request{
+		JSONRPC:  "2.0",
+		ID:       &id,
+		Method:   testPanicMethod,
+		Params:   []byte(`{}`),
+		Metadata: &jsonrpc.Metadata{Client: "python", Version: "0.1.0"},
+	}
+
+	t.Run("without crash report", func(t *testing.T) {
+		t.Setenv(publicrpc.CrashDirEnv, "")
+
+		resp := Dispatch(context.Background(), req)
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, -32603, resp.Error.Code)
+		assert.Equal(t, "Internal error", resp.Error.Message)
+		require.NotNil(t, resp.Error.Data)
+		assert.Contains(t, resp.Error.Data.Stack, "panicParams")
+		assert.Empty(t, resp.Error.Data.CrashReport)
+	})
+
+	t.Run("with crash report", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "crashes")
+		t.Setenv(publicrpc.CrashDirEnv, dir)
+
+		resp := Dispatch(context.Background(), req)
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, -32603, resp.Error.Code)
+		require.NotNil(t, resp.Error.Data)
+		assert.Equal(t, dir, filepath.Dir(resp.Error.Data.CrashReport))
+		report, err := os.ReadFile(resp.Error.Data.CrashReport)
+		require.NoError(t, err)
+		assert.Contains(t, string(report), "method: test.panic\n")
+		assert.Contains(t, string(report), "request id: 1\n")
+		assert.Contains(t, string(report), "client: python 0.1.0\n")
+		assert.Contains(t, string(report), "panic: runtime error: invalid memory address or nil pointer dereference")
+		assert.Contains(t, string(report), "panicParams")
+	})
+}
+
+func TestErrorResponseDestroy(t *testing.T) {
+	id := "1"
+	report := []map[string]string{{"Id": "a", "Status": "Destroyed"}, {"Id": "b", "Status": "Failed"}}
+	err := fmt.Errorf("failed: %w", &publicrpc.ErrDestroy{Err: errors.New("failed to destroy b"), Report: report})
+
+	resp := errorResponse(&id, err)
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, jsonrpc.ErrCodeIatk, resp.Error.Code)
+	assert.Equal(t, "failed: failed to destroy b", resp.Error.Message)
+	require.NotNil(t, resp.Error.Data)
+	assert.Equal(t, report, resp.Error.Data.Report)
+}
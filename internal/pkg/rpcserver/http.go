@@ -0,0 +1,225 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"expvar"
+	"fmt"
+	"iatk/internal/pkg/logging"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenEnv names the environment variable holding the bearer token HTTP clients must present, if any.
+const TokenEnv = "IATK_HTTP_TOKEN"
+
+// MetricsPath is the path HTTP clients GET the metrics of the binary from, published with expvar.
+const MetricsPath = "/debug/vars"
+
+// maxBodySize limits the size of an HTTP request body, in bytes.
+const maxBodySize = 10 << 20
+
+// readHeaderTimeout limits how long a client may take to send the headers of an HTTP request.
+const readHeaderTimeout = 10 * time.Second
+
+// SessionHeader is the header HTTP clients identify their session with, a random ID they choose, e.g. a
+// UUID. Only requests of the same session can be cancelled with CancelRequestMethod.
+const SessionHeader = "Iatk-Session"
+
+// NewHTTPHandler answers JSON-RPC messages POSTed to any path with Content-Type application/json, each
+// body being either a single request or a batch of them, see Handle. Notifications get a 204 No Content
+// reply. The metrics of the binary can be fetched with a GET of MetricsPath.
+//
+// Requests from web pages, which carry an Origin header, are rejected so that a page open in a browser
+// cannot call the binary with the AWS credentials of its user. Without a token, requests must be made to
+// a loopback host too, against DNS rebinding.
+//
+// Requests carrying the same SessionHeader share a session, so that CancelRequestMethod may cancel a call
+// made by another HTTP request of the session; requests without it are a session of their own. A call is
+// cancelled as well when its client goes away. Progress notifications are not sent over HTTP.
+//
+// If token is not empty, requests must carry it in an "Authorization: Bearer <token>" header.
+func NewHTTPHandler(token string) http.Handler {
+	return &httpHandler{token: token, sessions: map[string]*httpSession{}}
+}
+
+type httpHandler struct {
+	token string
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+// httpSession is a session shared by the HTTP requests in flight that carry the same SessionHeader.
+type httpSession struct {
+	*session
+	requests int
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Origin") != "" {
+		http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+		return
+	}
+	if h.token == "" && !isLoopback(hostOnly(r.Host)) {
+		http.Error(w, "host not allowed", http.StatusForbidden)
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method == http.MethodGet && r.URL.Path == MetricsPath {
+		expvar.Handler().ServeHTTP(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		http.Error(w, "unsupported media type, expected application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		var errTooLarge *http.MaxBytesError
+		if errors.As(err, &errTooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	id := r.Header.Get(SessionHeader)
+	s := h.acquireSession(id)
+	defer h.releaseSession(id)
+	reply := s.handle(ctx, body)
+	if reply == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(reply); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to write response", "error", err)
+	}
+}
+
+// acquireSession returns the session identified by id, which is kept until every request acquiring it
+// releases it. An empty id gets a new session.
+func (h *httpHandler) acquireSession(id string) *session {
+	if id == "" {
+		return newSession(nil)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.sessions[id]
+	if !ok {
+		s = &httpSession{session: newSession(nil)}
+		h.sessions[id] = s
+	}
+	s.requests++
+	return s.session
+}
+
+func (h *httpHandler) releaseSession(id string) {
+	if id == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.sessions[id]
+	s.requests--
+	if s.requests == 0 {
+		delete(h.sessions, id)
+	}
+}
+
+func (h *httpHandler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+	token := bearerToken(r)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return ""
+	}
+	return strings.TrimPrefix(auth, "Bearer ")
+}
+
+// hostOnly returns the host of hostport, an address with or without a port.
+func hostOnly(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]")
+	}
+	return host
+}
+
+// isLoopback tells whether host, a name or an IP address, is a loopback one.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ListenAndServeHTTP serves JSON-RPC over HTTP on addr, e.g. "127.0.0.1:8080", until ctx is done, see
+// NewHTTPHandler. A token is required unless addr is a loopback address. On shutdown, new connections are
+// refused but in-flight requests are still answered before ListenAndServeHTTP returns.
+func ListenAndServeHTTP(ctx context.Context, addr string, token string) error {
+	if token == "" && !isLoopback(hostOnly(addr)) {
+		return fmt.Errorf("a token in $%s is required to listen on %q, which is not a loopback address", TokenEnv, addr)
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", addr, err)
+	}
+	return serveHTTP(ctx, l, token)
+}
+
+func serveHTTP(ctx context.Context, l net.Listener, token string) error {
+	logging.FromContext(ctx).Info("listening", "address", l.Addr().String(), "auth", token != "")
+	srv := &http.Server{
+		Handler:           NewHTTPHandler(token),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	shutdownErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			shutdownErr <- srv.Shutdown(context.Background())
+		case <-done:
+		}
+	}()
+
+	if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP: %w", err)
+	}
+	if err := <-shutdownErr; err != nil {
+		return fmt.Errorf("failed to shut down HTTP server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"iatk/internal/pkg/jsonrpc"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	cases := map[string]struct {
+		method       string
+		token        string
+		header       map[string]string
+		body         string
+		expectStatus int
+		expectBody   string
+	}{
+		"single request": {
+			method:       http.MethodPost,
+			token:        "secret",
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusOK,
+			expectBody:   `{"jsonrpc":"2.0","id":"1","result":{"output":"hello"}}` + "\n",
+		},
+		"batch": {
+			method:       http.MethodPost,
+			token:        "secret",
+			body:         `[{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"a"}},{"jsonrpc":"2.0","id":"2","method":"test.echo","params":{"Message":"b"}}]`,
+			expectStatus: http.StatusOK,
+			expectBody:   `[{"jsonrpc":"2.0","id":"1","result":{"output":"a"}},{"jsonrpc":"2.0","id":"2","result":{"output":"b"}}]` + "\n",
+		},
+		"not json": {
+			method:       http.MethodPost,
+			token:        "secret",
+			body:         `not json`,
+			expectStatus: http.StatusOK,
+			expectBody:   `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error"}}` + "\n",
+		},
+		"notification": {
+			method:       http.MethodPost,
+			token:        "secret",
+			body:         `{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"unknown"}}`,
+			expectStatus: http.StatusNoContent,
+		},
+		"wrong token": {
+			method:       http.MethodPost,
+			token:        "guess",
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusUnauthorized,
+			expectBody:   "unauthorized\n",
+		},
+		"missing token": {
+			method:       http.MethodPost,
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusUnauthorized,
+			expectBody:   "unauthorized\n",
+		},
+		"not json content type": {
+			method:       http.MethodPost,
+			token:        "secret",
+			header:       map[string]string{"Content-Type": "text/plain"},
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusUnsupportedMediaType,
+			expectBody:   "unsupported media type, expected application/json\n",
+		},
+		"json content type with charset": {
+			method:       http.MethodPost,
+			token:        "secret",
+			header:       map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusOK,
+			expectBody:   `{"jsonrpc":"2.0","id":"1","result":{"output":"hello"}}` + "\n",
+		},
+		"cross origin": {
+			method:       http.MethodPost,
+			token:        "secret",
+			header:       map[string]string{"Origin": "https://example.com"},
+			body:         `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+			expectStatus: http.StatusForbidden,
+			expectBody:   "cross-origin requests are not allowed\n",
+		},
+		"not a post": {
+			method:       http.MethodGet,
+			token:        "secret",
+			expectStatus: http.StatusMethodNotAllowed,
+			expectBody:   "method not allowed\n",
+		},
+	}
+
+	srv := httptest.NewServer(NewHTTPHandler("secret"))
+	defer srv.Close()
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectBody, string(body))
+		})
+	}
+}
+
+func TestHTTPHandlerWithoutToken(t *testing.T) {
+	srv := httptest.NewServer(NewHTTPHandler(""))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func TestHTTPHandlerHost(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`
+	cases := map[string]struct {
+		token        string
+		host         string
+		expectStatus int
+	}{
+		"loopback ip":                 {host: "127.0.0.1:8080", expectStatus: http.StatusOK},
+		"loopback ipv6":               {host: "[::1]:8080", expectStatus: http.StatusOK},
+		"localhost":                   {host: "localhost", expectStatus: http.StatusOK},
+		"rebound name":                {host: "attacker.example.com:8080", expectStatus: http.StatusForbidden},
+		"other address":               {host: "192.168.1.2:8080", expectStatus: http.StatusForbidden},
+		"other address, with a token": {token: "secret", host: "192.168.1.2:8080", expectStatus: http.StatusOK},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Host = tt.host
+			req.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			NewHTTPHandler(tt.token).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestListenAndServeHTTPRequiresToken(t *testing.T) {
+	err := ListenAndServeHTTP(context.Background(), ":0", "")
+
+	assert.EqualError(t, err, `a token in $IATK_HTTP_TOKEN is required to listen on ":0", which is not a loopback address`)
+}
+
+func TestHTTPCancelRequest(t *testing.T) {
+	srv := httptest.NewServer(NewHTTPHandler(""))
+	defer srv.Close()
+	post := func(session string, body string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SessionHeader, session)
+		return http.DefaultClient.Do(req)
+	}
+
+	done := make(chan jsonrpc.Response, 1)
+	go func() {
+		resp, err := post("session-a", `{"jsonrpc":"2.0","id":"1","method":"test.wait","params":{}}`)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer resp.Body.Close()
+		var r jsonrpc.Response
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+		done <- r
+	}()
+
+	// another session must not cancel the call, whether it is in flight yet or not
+	for i := 0; i < 5; i++ {
+		resp, err := post("session-b", `{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"1"}}`)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-done:
+		t.Fatal("call was cancelled by another session")
+	default:
+	}
+
+	// the cancel request may arrive before the call is in flight, so send it until the call returns
+	var r jsonrpc.Response
+	require.Eventually(t, func() bool {
+		resp, err := post("session-a", `{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"1"}}`)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		select {
+		case r = <-done:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, jsonrpc.ErrCodeCancelled, r.Error.Code)
+}
+
+func TestServeHTTPShutdown(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serveHTTP(ctx, l, "")
+	}()
+
+	resp, err := http.Post("http://"+l.Addr().String(), "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not shut down")
+	}
+	_, err = http.Post("http://"+l.Addr().String(), "application/json", strings.NewReader(`{}`))
+	assert.Error(t, err)
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	srv := httptest.NewServer(NewHTTPHandler("secret"))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+MetricsPath, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var vars map[string]json.RawMessage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&vars))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, vars, "rpc")
+}
@@ -0,0 +1,206 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/logging"
+	publicrpc "iatk/internal/pkg/public-rpc"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"runtime/debug"
+	"sync"
+	"syscall"
+
+	"golang.org/x/exp/slog"
+)
+
+// DecodeRequest decodes a single request, rejecting unknown fields.
+func DecodeRequest(r io.Reader) (jsonrpc.Request, error) {
+	var req jsonrpc.Request
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&req)
+	return req, err
+}
+
+// Serve reads newline-delimited messages from r and writes one newline-delimited reply per message to w.
+// A message is either a single request or a batch of them, see Handle. Notifications, such as
+// CancelRequestMethod, get no reply. Progress notifications are written to w as well.
+//
+// Messages are handled concurrently, so responses can be written in a different order than the requests
+// were read; clients should match them by ID. Calls in flight are cancelled once ctx is done. Serve returns
+// once r is exhausted and every request read so far has been answered.
+func Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	out := &responseWriter{w: w}
+	s := newSession(out.write)
+	reader := bufio.NewReader(r)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			wg.Add(1)
+			go func(line []byte) {
+				defer wg.Done()
+				defer recoverLine(ctx, line, out)
+				if reply := s.handle(ctx, line); reply != nil {
+					out.write(reply)
+				}
+			}(line)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+	}
+}
+
+// recoverLine answers the message of line, whose handling panicked, with an internal error instead of
+// letting the panic stop the process. It must be deferred.
+func recoverLine(ctx context.Context, line []byte, out *responseWriter) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	// the ID is unknown if the message is not a single request
+	var msg struct {
+		ID *string `json:"id"`
+	}
+	_ = json.Unmarshal(line, &msg)
+	resp := panicResponse(ctx, msg.ID, &publicrpc.ErrPanic{Value: v, Stack: debug.Stack()})
+	out.write(encode(resp, resp.ID))
+}
+
+// ListenAndServe accepts connections on a unix socket at socketPath and serves each of them like Serve
+// until ctx is done. On shutdown, open connections stop reading new requests and in-flight ones are
+// cancelled, but still answered before ListenAndServe returns.
+func ListenAndServe(ctx context.Context, socketPath string) error {
+	if err := removeStaleSocket(ctx, socketPath); err != nil {
+		return err
+	}
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", socketPath, err)
+	}
+	logging.FromContext(ctx).Info("listening", "socket", socketPath)
+
+	conns := &connSet{m: map[*net.UnixConn]struct{}{}}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			l.Close()
+			conns.closeRead()
+		case <-done:
+		}
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			l.Close()
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		uc := conn.(*net.UnixConn)
+		conns.add(uc)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conns.remove(uc)
+			defer uc.Close()
+			if err := Serve(ctx, uc, uc); err != nil {
+				logging.FromContext(ctx).Warn("connection closed", "error", err)
+			}
+		}()
+	}
+}
+
+// removeStaleSocket removes a socket file left behind by a previous process that did not shut down cleanly.
+// A socket that still accepts connections belongs to a running server and is left alone.
+func removeStaleSocket(ctx context.Context, socketPath string) error {
+	info, err := os.Stat(socketPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check socket path %q: %w", socketPath, err)
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("socket path %q exists and is not a socket", socketPath)
+	}
+	conn, err := net.Dial("unix", socketPath)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("failed to listen on %q: address in use", socketPath)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("failed to check socket path %q: %w", socketPath, err)
+	}
+	logging.FromContext(ctx).Info("removing stale socket", "socket", socketPath)
+	return os.Remove(socketPath)
+}
+
+type responseWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (rw *responseWriter) write(buffer []byte) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if _, err := rw.w.Write(buffer); err != nil {
+		slog.Error("failed to write response", "error", err)
+	}
+}
+
+type connSet struct {
+	mu     sync.Mutex
+	m      map[*net.UnixConn]struct{}
+	closed bool
+}
+
+func (s *connSet) add(c *net.UnixConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		// accepted right before shutdown; answer what was already sent but read nothing more
+		c.CloseRead()
+	}
+	s.m[c] = struct{}{}
+}
+
+func (s *connSet) remove(c *net.UnixConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, c)
+}
+
+func (s *connSet) closeRead() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for c := range s.m {
+		c.CloseRead()
+	}
+}
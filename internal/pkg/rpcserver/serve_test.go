@@ -0,0 +1,269 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/progress"
+	publicrpc "iatk/internal/pkg/public-rpc"
+	"iatk/internal/pkg/public-rpc/types"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMethod      = "test.echo"
+	testWaitMethod  = "test.wait"
+	testPanicMethod = "test.panic"
+	testBadOutput   = "test.badOutput"
+)
+
+type echoParams struct {
+	Message string
+}
+
+func (p *echoParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	if p.Message == "" {
+		return nil, &publicrpc.ErrValidation{Err: errors.New(`missing required param "Message"`)}
+	}
+	progress.Report(ctx, "echoing", map[string]interface{}{"length": len(p.Message)})
+	return &types.Result{Output: p.Message}, nil
+}
+
+func (p *echoParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(""))
+}
+
+// waitParams blocks until the call is cancelled or times out.
+type waitParams struct{}
+
+func (p *waitParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	<-ctx.Done()
+	return nil, fmt.Errorf("stopped waiting: %w", ctx.Err())
+}
+
+func (p *waitParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(""))
+}
+
+// panicParams panics, like a method dereferencing a nil pointer.
+type panicParams struct{}
+
+func (p *panicParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	var segment *struct{ ID string }
+	return &types.Result{Output: segment.ID}, nil
+}
+
+func (p *panicParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(""))
+}
+
+// badOutputParams returns an output that panics when encoded, after the method has returned.
+type badOutputParams struct{}
+
+type badOutput struct{}
+
+func (badOutput) MarshalJSON() ([]byte, error) {
+	panic("cannot encode")
+}
+
+func (p *badOutputParams) RPCMethod(ctx context.Context, metadata *jsonrpc.Metadata) (*types.Result, error) {
+	return &types.Result{Output: badOutput{}}, nil
+}
+
+func (p *badOutputParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(""))
+}
+
+func init() {
+	publicrpc.Default.Register(testMethod, new(echoParams))
+	publicrpc.Default.Register(testWaitMethod, new(waitParams))
+	publicrpc.Default.Register(testPanicMethod, new(panicParams))
+	publicrpc.Default.Register(testBadOutput, new(badOutputParams))
+}
+
+func decodeResponses(t *testing.T, out string) map[string]jsonrpc.Response {
+	t.Helper()
+	responses := map[string]jsonrpc.Response{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var batch jsonrpc.BatchResponse
+		if IsBatch([]byte(line)) {
+			require.NoError(t, json.Unmarshal([]byte(line), &batch))
+		} else {
+			var resp jsonrpc.Response
+			require.NoError(t, json.Unmarshal([]byte(line), &resp))
+			batch = append(batch, resp)
+		}
+		for _, resp := range batch {
+			id := "null"
+			if resp.ID != nil {
+				id = *resp.ID
+			}
+			responses[id] = resp
+		}
+	}
+	return responses
+}
+
+func TestServe(t *testing.T) {
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":"1","method":"test.echo","params":{"Message":"hello"}}`,
+		``,
+		`{"jsonrpc":"2.0","id":"2","method":"test.echo","params":{}}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":"3","method":"does.not.exist","params":{}}`,
+		`{"jsonrpc":"2.0","id":"4","method":"test.echo","params":{"Message":"world"}}`,
+		`[{"jsonrpc":"2.0","id":"5","method":"test.echo","params":{"Message":"batched"}}]`,
+		`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":"unknown"}}`,
+	}, "\n")
+	var out strings.Builder
+
+	err := Serve(context.Background(), strings.NewReader(in), &out)
+
+	require.NoError(t, err)
+	responses := decodeResponses(t, out.String())
+	require.Len(t, responses, 6)
+	assert.Equal(t, map[string]interface{}{"output": "hello"}, responses["1"].Result)
+	// must not see the Message of the previous call to the same method
+	assert.Equal(t, `missing required param "Message"`, responses["2"].Error.Message)
+	assert.Equal(t, jsonrpc.ErrCodeValidation, responses["2"].Error.Code)
+	assert.Equal(t, -32700, responses["null"].Error.Code)
+	assert.Equal(t, -32601, responses["3"].Error.Code)
+	assert.Equal(t, map[string]interface{}{"output": "world"}, responses["4"].Result)
+	assert.Equal(t, map[string]interface{}{"output": "batched"}, responses["5"].Result)
+}
+
+func TestServeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":"1","method":"test.wait","params":{}}` + "\n")
+	var out strings.Builder
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Serve(ctx, in, &out)
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("call in flight was not cancelled")
+	}
+	responses := decodeResponses(t, out.String())
+	assert.Equal(t, jsonrpc.ErrCodeCancelled, responses["1"].Error.Code)
+}
+
+func TestServePanicOutsideMethod(t *testing.T) {
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":"1","method":"test.badOutput","params":{}}`,
+		`[{"jsonrpc":"2.0","id":"2","method":"test.badOutput","params":{}}]`,
+		`{"jsonrpc":"2.0","id":"3","method":"test.echo","params":{"Message":"still serving"}}`,
+	}, "\n")
+	var out strings.Builder
+
+	err := Serve(context.Background(), strings.NewReader(in), &out)
+
+	require.NoError(t, err)
+	responses := decodeResponses(t, out.String())
+	require.Len(t, responses, 3)
+	assert.Equal(t, -32603, responses["1"].Error.Code)
+	require.NotNil(t, responses["1"].Error.Data)
+	assert.Contains(t, responses["1"].Error.Data.Stack, "MarshalJSON")
+	// the ID of a request of a batch is unknown once the batch is encoded
+	assert.Equal(t, -32603, responses["null"].Error.Code)
+	assert.Equal(t, map[string]interface{}{"output": "still serving"}, responses["3"].Result)
+}
+
+func TestListenAndServe(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "iatk.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ListenAndServe(ctx, socketPath)
+	}()
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		conn, err = net.Dial("unix", socketPath)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for _, msg := range []string{"a", "b"} {
+		_, err := conn.Write([]byte(`{"jsonrpc":"2.0","id":"` + msg + `","method":"test.echo","params":{"Message":"` + msg + `"}}` + "\n"))
+		require.NoError(t, err)
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		var resp jsonrpc.Response
+		require.NoError(t, json.Unmarshal(line, &resp))
+		assert.Equal(t, msg, *resp.ID)
+		assert.Equal(t, map[string]interface{}{"output": msg}, resp.Result)
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestListenAndServe_SocketInUse(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "iatk.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ListenAndServe(ctx, socketPath)
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("unix", socketPath)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	err := ListenAndServe(context.Background(), socketPath)
+
+	assert.EqualError(t, err, fmt.Sprintf("failed to listen on %q: address in use", socketPath))
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err, "socket of the running server must not be removed")
+	conn.Close()
+}
+
+func TestListenAndServe_StaleSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "iatk.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ListenAndServe(ctx, socketPath)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("unix", socketPath)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	assert.NoError(t, <-errCh)
+}
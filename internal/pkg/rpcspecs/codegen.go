@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GeneratedHeader starts every file generated from a spec.
+const GeneratedHeader = "Code generated by rpcspecs. DO NOT EDIT."
+
+// methodNames names each method of spec in generated code after the last segment of its RPC name in
+// camel case, e.g. "test_harness.eventbridge.add_listener" is "AddListener", unless two methods would
+// share a name, in which case the whole RPC name is used.
+func methodNames(spec *Spec) map[string]string {
+	count := map[string]int{}
+	for rpcName := range spec.Methods {
+		count[lastSegment(rpcName)]++
+	}
+	names := map[string]string{}
+	for rpcName := range spec.Methods {
+		if count[lastSegment(rpcName)] > 1 {
+			names[rpcName] = camelCase(rpcName)
+		} else {
+			names[rpcName] = camelCase(lastSegment(rpcName))
+		}
+	}
+	return names
+}
+
+func lastSegment(rpcName string) string {
+	return rpcName[strings.LastIndex(rpcName, ".")+1:]
+}
+
+// definitionTypeName names a definition in generated code, e.g. "xray.Segment" is "XraySegment".
+func definitionTypeName(ref string) string {
+	return camelCase(strings.TrimPrefix(ref, "#/definitions/"))
+}
+
+// camelCase joins the words of s, separated by anything but letters and digits, capitalizing the
+// first letter of each, e.g. "trace_id" is "TraceId" and "xray.Segment" is "XraySegment".
+func camelCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// snakeCase turns a camel case or dotted name to snake case, e.g. "ListenerId" is "listener_id" and
+// "aws.xray.reserved.reference_type" is "aws_xray_reserved_reference_type".
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			r = '_'
+		case unicode.IsUpper(r):
+			// start a new word, unless in the middle of an acronym, plural or not
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]) && !isPluralS(runes, i+1))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isPluralS reports whether runes[i] is an "s" ending a word, e.g. the last rune of "URLs".
+func isPluralS(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fields returns the names of the properties of p, required ones first, each group sorted.
+func fields(p *Property) []string {
+	required := map[string]bool{}
+	for _, name := range p.Required {
+		required[name] = true
+	}
+	names := sortedKeys(p.Properties)
+	sort.SliceStable(names, func(i, j int) bool {
+		return required[names[i]] && !required[names[j]]
+	})
+	return names
+}
+
+func isRequired(p *Property, name string) bool {
+	for _, r := range p.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAWSParams reports whether params take the AWS profile and region, which clients fill in by default.
+func hasAWSParams(params *Property) bool {
+	return params.Properties["Profile"] != nil && params.Properties["Region"] != nil
+}
+
+// defaultedFields are the fields of AWS params clients fill in by default, besides the region and the
+// profile, if methods take them.
+var defaultedFields = []string{
+	"EndpointURL", "EndpointURLs", "RoleArn", "ExternalId", "RoleSessionName", "DurationSeconds",
+	"RetryMode", "MaxAttempts", "MaxBackoffSeconds",
+}
+
+// isStruct reports whether p is an object with known properties, for which a type is generated.
+func isStruct(p *Property) bool {
+	return p.Type == "object" && p.Properties != nil
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"trace_id":                         "TraceId",
+		"xray.Segment":                     "XraySegment",
+		"aws.xray.reserved.reference_type": "AwsXrayReservedReferenceType",
+		"ListenerId":                       "ListenerId",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, camelCase(input), input)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"ListenerId":                       "listener_id",
+		"ARN":                              "arn",
+		"PhysicalID":                       "physical_id",
+		"HTTPStatusCode":                   "http_status_code",
+		"aws.xray.reserved.reference_type": "aws_xray_reserved_reference_type",
+		"limitExceeded":                    "limit_exceeded",
+		"EndpointURLs":                     "endpoint_urls",
+		"URLsFor":                          "urls_for",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, snakeCase(input), input)
+	}
+}
+
+func TestMethodNames(t *testing.T) {
+	spec := &Spec{Methods: map[string]Schemas{
+		"get_physical_id":        {},
+		"test.a.remove":          {},
+		"test.b.remove":          {},
+		"mock.generate_barebone": {},
+	}}
+
+	assert.Equal(t, map[string]string{
+		"get_physical_id":        "GetPhysicalId",
+		"test.a.remove":          "TestARemove",
+		"test.b.remove":          "TestBRemove",
+		"mock.generate_barebone": "GenerateBarebone",
+	}, methodNames(spec))
+}
+
+func TestGoClient(t *testing.T) {
+	spec := Generate(map[string]Method{
+		"test.get":  new(testParams),
+		"test.list": new(testListParams),
+	})
+
+	src, err := GoClient(spec, "client")
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "client_gen.go", src, 0)
+	require.NoError(t, err)
+
+	code := string(src)
+	assert.Contains(t, code, "// "+GeneratedHeader)
+	assert.Contains(t, code, "type RpcspecsTestNode struct {")
+	assert.Regexp(t, "Children +\\[\\]RpcspecsTestNode +`json:\"children,omitempty\"`", code)
+	assert.Regexp(t, "// Id of the thing.\n\tId +string +`json:\"Id\"`", code)
+	assert.Regexp(t, "// One of: fast, slow.\n\tMode +string +`json:\"Mode\"`", code)
+	assert.Regexp(t, "Count +\\*int64 +`json:\"Count,omitempty\"`", code)
+	assert.Contains(t, code, "type GetResult struct {")
+	assert.Contains(t, code, "func (c *Client) Get(ctx context.Context, params GetParams) (*GetResult, error) {")
+	assert.Contains(t, code, "func (c *Client) List(ctx context.Context, params ListParams) ([]RpcspecsTestNode, error) {")
+	// only methods taking both a region and a profile default them
+	assert.NotContains(t, code, "params.Region = c.Region")
+}
+
+func TestPythonClient(t *testing.T) {
+	spec := Generate(map[string]Method{
+		"test.get":  new(testParams),
+		"test.list": new(testListParams),
+	})
+
+	code := string(PythonClient(spec))
+	assert.Contains(t, code, "# "+GeneratedHeader)
+	assert.Contains(t, code, "class RpcspecsTestNode:")
+	assert.Contains(t, code, "    children: Optional[List[RpcspecsTestNode]] = None\n")
+	assert.Contains(t, code, "    id: str\n")
+	assert.Contains(t, code, "    count: Optional[int] = None\n")
+	assert.Contains(t, code, "            children=None if data.get(\"children\") is None else [None if v is None else RpcspecsTestNode.from_dict(v) for v in data.get(\"children\")],\n")
+	assert.Contains(t, code, "    def get(self, params: GetParams) -> GetResult:\n")
+	assert.Contains(t, code, "        return None if output is None else [None if v is None else RpcspecsTestNode.from_dict(v) for v in output]\n")
+}
@@ -0,0 +1,169 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// GoClient generates the Go source of package pkg declaring a type per definition of spec, the params
+// and result types of each method and a method of Client calling it. The package is expected to
+// declare Client by hand, with the fields Region and Profile and the method
+//
+//	call(ctx context.Context, method string, params interface{}, result interface{}) error
+func GoClient(spec *Spec, pkg string) ([]byte, error) {
+	g := &goGenerator{}
+	g.printf("// %s\n\n", GeneratedHeader)
+	g.printf("package %s\n\n", pkg)
+	g.printf("import \"context\"\n")
+
+	for _, name := range sortedKeys(spec.Definitions) {
+		g.printf("\n// %s is %s.\n", definitionTypeName(name), name)
+		g.structType(definitionTypeName(name), spec.Definitions[name])
+	}
+
+	names := methodNames(spec)
+	for _, rpcName := range sortedKeys(spec.Methods) {
+		g.method(names[rpcName], rpcName, spec.Methods[rpcName])
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated Go code: %w", err)
+	}
+	return src, nil
+}
+
+type goGenerator struct {
+	buf bytes.Buffer
+}
+
+func (g *goGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *goGenerator) structType(name string, p *Property) {
+	g.printf("type %s struct {\n", name)
+	for _, field := range fields(p) {
+		fp := p.Properties[field]
+		required := isRequired(p, field)
+		if doc := fieldDoc(fp); doc != "" {
+			g.printf("// %s\n", doc)
+		}
+		tag := field
+		if !required {
+			tag += ",omitempty"
+		}
+		g.printf("%s %s `json:%q`\n", camelCase(field), goType(fp, required), tag)
+	}
+	g.printf("}\n")
+}
+
+func (g *goGenerator) method(name, rpcName string, m Schemas) {
+	paramsType := name + "Params"
+	g.printf("\n// %s are the params of %s.\n", paramsType, name)
+	g.structType(paramsType, m.Parameters)
+
+	resultType := goType(m.Returns, true)
+	zero := zeroValue(m.Returns)
+	if isStruct(m.Returns) {
+		resultType = name + "Result"
+		g.printf("\n// %s is the result of %s.\n", resultType, name)
+		g.structType(resultType, m.Returns)
+		resultType = "*" + resultType
+		zero = "nil"
+	}
+
+	g.printf("\n// %s calls %s.\n", name, rpcName)
+	g.printf("func (c *Client) %s(ctx context.Context, params %s) (%s, error) {\n", name, paramsType, resultType)
+	if hasAWSParams(m.Parameters) {
+		g.printf("if params.Region == \"\" {\nparams.Region = c.Region\n}\n")
+		g.printf("if params.Profile == \"\" {\nparams.Profile = c.Profile\n}\n")
+		for _, field := range defaultedFields {
+			fp := m.Parameters.Properties[field]
+			if fp == nil {
+				continue
+			}
+			zero := zeroValue(fp)
+			if strings.HasPrefix(goType(fp, isRequired(m.Parameters, field)), "*") {
+				zero = "nil"
+			}
+			g.printf("if params.%s == %s {\nparams.%s = c.%s\n}\n", field, zero, field, field)
+		}
+	}
+	g.printf("var result %s\n", strings.TrimPrefix(resultType, "*"))
+	g.printf("if err := c.call(ctx, %q, params, &result); err != nil {\nreturn %s, err\n}\n", rpcName, zero)
+	if strings.HasPrefix(resultType, "*") {
+		g.printf("return &result, nil\n}\n")
+	} else {
+		g.printf("return result, nil\n}\n")
+	}
+}
+
+// goType returns the Go type of values described by p. Optional numbers, booleans and definitions are
+// pointers, so that their zero value can be told apart from a missing one.
+func goType(p *Property, required bool) string {
+	if p.Ref != "" {
+		return "*" + definitionTypeName(p.Ref)
+	}
+	var t string
+	switch p.Type {
+	case "string":
+		return "string"
+	case "integer":
+		t = "int64"
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + goElemType(p.Items)
+	case "object":
+		if ap, ok := p.AdditionalProperties.(*Property); ok {
+			return "map[string]" + goElemType(ap)
+		}
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+	if !required {
+		return "*" + t
+	}
+	return t
+}
+
+// goElemType returns the Go type of the items of an array or the values of a map described by p.
+func goElemType(p *Property) string {
+	if p.Ref != "" {
+		return definitionTypeName(p.Ref)
+	}
+	return goType(p, true)
+}
+
+func zeroValue(p *Property) string {
+	switch goType(p, true) {
+	case "string":
+		return `""`
+	case "int64", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	}
+	return "nil"
+}
+
+// fieldDoc documents a field with the description and allowed values of p, if any.
+func fieldDoc(p *Property) string {
+	var doc []string
+	if p.Description != "" {
+		doc = append(doc, strings.TrimSuffix(p.Description, ".")+".")
+	}
+	if len(p.Enum) > 0 {
+		doc = append(doc, "One of: "+strings.Join(p.Enum, ", ")+".")
+	}
+	return strings.Join(doc, " ")
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import "strings"
+
+// OpenRPCVersion is the version of the OpenRPC specification documents follow.
+const OpenRPCVersion = "1.2.6"
+
+// OpenRPC is an OpenRPC document describing the methods of a Spec, see https://spec.open-rpc.org.
+type OpenRPC struct {
+	OpenRPC    string            `json:"openrpc" description:"Version of the OpenRPC specification the document follows" required:"true"`
+	Info       OpenRPCInfo       `json:"info" required:"true"`
+	Methods    []OpenRPCMethod   `json:"methods" required:"true"`
+	Components OpenRPCComponents `json:"components" required:"true"`
+}
+
+// OpenRPCInfo identifies the API a document describes.
+type OpenRPCInfo struct {
+	Title   string `json:"title" required:"true"`
+	Version string `json:"version" required:"true"`
+}
+
+// OpenRPCMethod describes a method, whose params are passed by name.
+type OpenRPCMethod struct {
+	Name           string              `json:"name" required:"true"`
+	ParamStructure string              `json:"paramStructure" enum:"by-name,by-position,either" required:"true"`
+	Params         []ContentDescriptor `json:"params" required:"true"`
+	Result         ContentDescriptor   `json:"result" required:"true"`
+}
+
+// ContentDescriptor describes a param or the result of a method.
+type ContentDescriptor struct {
+	Name        string    `json:"name" required:"true"`
+	Description string    `json:"description,omitempty"`
+	Required    bool      `json:"required,omitempty"`
+	Schema      *Property `json:"schema"`
+}
+
+// OpenRPCComponents holds the schemas of the types shared by methods.
+type OpenRPCComponents struct {
+	Schemas map[string]*Property `json:"schemas,omitempty"`
+}
+
+const openRPCSchemaRefPrefix = "#/components/schemas/"
+
+// Discover returns the OpenRPC document describing the methods of spec, sorted by name, as those of
+// the API of the given title and version.
+func Discover(spec *Spec, title, version string) *OpenRPC {
+	doc := &OpenRPC{
+		OpenRPC: OpenRPCVersion,
+		Info:    OpenRPCInfo{Title: title, Version: version},
+		Methods: []OpenRPCMethod{},
+	}
+	for _, name := range sortedKeys(spec.Methods) {
+		m := spec.Methods[name]
+		method := OpenRPCMethod{
+			Name:           name,
+			ParamStructure: "by-name",
+			Params:         []ContentDescriptor{},
+			Result:         ContentDescriptor{Name: "result", Schema: openRPCSchema(m.Returns)},
+		}
+		for _, field := range fields(m.Parameters) {
+			schema := openRPCSchema(m.Parameters.Properties[field])
+			method.Params = append(method.Params, ContentDescriptor{
+				Name:        field,
+				Description: schema.Description,
+				Required:    isRequired(m.Parameters, field),
+				Schema:      schema,
+			})
+		}
+		doc.Methods = append(doc.Methods, method)
+	}
+	if len(spec.Definitions) > 0 {
+		doc.Components.Schemas = map[string]*Property{}
+		for name, def := range spec.Definitions {
+			doc.Components.Schemas[name] = openRPCSchema(def)
+		}
+	}
+	return doc
+}
+
+// openRPCSchema returns a copy of p whose references point to the schemas of OpenRPC components
+// rather than to definitions.
+func openRPCSchema(p *Property) *Property {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	if cp.Ref != "" {
+		cp.Ref = openRPCSchemaRefPrefix + strings.TrimPrefix(cp.Ref, "#/definitions/")
+	}
+	if p.Properties != nil {
+		cp.Properties = make(map[string]*Property, len(p.Properties))
+		for name, prop := range p.Properties {
+			cp.Properties[name] = openRPCSchema(prop)
+		}
+	}
+	cp.Items = openRPCSchema(p.Items)
+	if ap, ok := p.AdditionalProperties.(*Property); ok {
+		cp.AdditionalProperties = openRPCSchema(ap)
+	}
+	return &cp
+}
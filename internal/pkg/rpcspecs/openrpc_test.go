@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	spec := Generate(map[string]Method{
+		"test.list": new(testListParams),
+		"test.get":  new(testParams),
+	})
+
+	doc := Discover(spec, "test", "1.0.0")
+
+	assert.Equal(t, OpenRPCVersion, doc.OpenRPC)
+	assert.Equal(t, OpenRPCInfo{Title: "test", Version: "1.0.0"}, doc.Info)
+	require.Len(t, doc.Methods, 2)
+
+	get := doc.Methods[0]
+	assert.Equal(t, "test.get", get.Name)
+	assert.Equal(t, "by-name", get.ParamStructure)
+	names := []string{}
+	for _, p := range get.Params {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"Id", "Mode", "Any", "Count", "Name", "Raw", "Region", "Tags"}, names)
+	assert.Equal(t, ContentDescriptor{
+		Name:        "Id",
+		Description: "Id of the thing",
+		Required:    true,
+		Schema:      &Property{Type: "string", Description: "Id of the thing"},
+	}, get.Params[0])
+	assert.False(t, get.Params[5].Required)
+	assert.Equal(t, "#/components/schemas/rpcspecs.testNode", get.Result.Schema.Properties["children"].Items.Ref)
+
+	list := doc.Methods[1]
+	assert.Equal(t, "test.list", list.Name)
+	assert.Empty(t, list.Params)
+	assert.Equal(t, "#/components/schemas/rpcspecs.testNode", list.Result.Schema.Items.Ref)
+
+	actual, err := json.Marshal(doc.Components.Schemas["rpcspecs.testNode"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"children": {"type": "array", "items": {"$ref": "#/components/schemas/rpcspecs.testNode"}}
+		},
+		"required": ["name"],
+		"additionalProperties": false
+	}`, string(actual))
+
+	// the spec is left untouched
+	assert.Equal(t, "#/definitions/rpcspecs.testNode", spec.Methods["test.list"].Returns.Items.Ref)
+}
@@ -0,0 +1,258 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const pythonPrelude = `from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Any, Callable, Dict, List, Optional
+
+
+def _to_json(value):
+    if hasattr(value, "to_dict"):
+        return value.to_dict()
+    if isinstance(value, list):
+        return [_to_json(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _to_json(v) for k, v in value.items()}
+    return value
+
+
+def _drop_none(d: dict) -> dict:
+    return {k: v for k, v in d.items() if v is not None}
+`
+
+// PythonClient generates a Python module declaring a dataclass per definition of spec, the params
+// and result dataclasses of each method and an RpcClient class with a method calling each of them.
+func PythonClient(spec *Spec) []byte {
+	g := &pyGenerator{}
+	g.printf("# %s\n\n", GeneratedHeader)
+	g.printf("%s", pythonPrelude)
+
+	for _, name := range sortedKeys(spec.Definitions) {
+		g.class(definitionTypeName(name), name, spec.Definitions[name])
+	}
+
+	names := methodNames(spec)
+	rpcNames := sortedKeys(spec.Methods)
+	for _, rpcName := range rpcNames {
+		m := spec.Methods[rpcName]
+		g.class(names[rpcName]+"Params", "params of "+rpcName, m.Parameters)
+		if isStruct(m.Returns) {
+			g.class(names[rpcName]+"Result", "result of "+rpcName, m.Returns)
+		}
+	}
+
+	g.printf(`
+
+class RpcClient:
+    """
+    Calls the RPC methods of iatk
+
+    Parameters
+    ----------
+    invoke : Callable[[str, dict], Any]
+        Calls the RPC method of the given name with the given params and returns its output
+    region : str, optional
+        AWS Region used by default by methods taking one
+    profile : str, optional
+        AWS Profile used by default by methods taking one
+    endpoint_url : str, optional
+        URL AWS requests are sent to by default by methods taking one, e.g. the one of a local emulator
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to by default by methods taking them, keyed by service ID
+    role_arn : str, optional
+        ARN of the IAM role assumed by default by methods taking one
+    external_id : str, optional
+        External ID used by default to assume the role
+    role_session_name : str, optional
+        Name of the session of the role used by default
+    duration_seconds : int, optional
+        Duration of the session of the role used by default, in seconds
+    retry_mode : str, optional
+        How failed AWS calls are retried by default: standard or adaptive
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call used by default
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call used by default, in seconds
+    """
+
+    def __init__(
+        self,
+        invoke: Callable[[str, dict], Any],
+        region: Optional[str] = None,
+        profile: Optional[str] = None,
+        endpoint_url: Optional[str] = None,
+        endpoint_urls: Optional[Dict[str, str]] = None,
+        role_arn: Optional[str] = None,
+        external_id: Optional[str] = None,
+        role_session_name: Optional[str] = None,
+        duration_seconds: Optional[int] = None,
+        retry_mode: Optional[str] = None,
+        max_attempts: Optional[int] = None,
+        max_backoff_seconds: Optional[int] = None,
+    ) -> None:
+        self._invoke = invoke
+        self._region = region
+        self._profile = profile
+        self._endpoint_url = endpoint_url
+        self._endpoint_urls = endpoint_urls
+        self._role_arn = role_arn
+        self._external_id = external_id
+        self._role_session_name = role_session_name
+        self._duration_seconds = duration_seconds
+        self._retry_mode = retry_mode
+        self._max_attempts = max_attempts
+        self._max_backoff_seconds = max_backoff_seconds
+`)
+	for _, rpcName := range rpcNames {
+		g.method(names[rpcName], rpcName, spec.Methods[rpcName])
+	}
+	return g.buf.Bytes()
+}
+
+type pyGenerator struct {
+	buf bytes.Buffer
+}
+
+func (g *pyGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *pyGenerator) class(name, doc string, p *Property) {
+	names := fields(p)
+	g.printf("\n\n@dataclass\nclass %s:\n", name)
+	g.printf("    \"\"\"\n    %s\n", doc)
+	if len(names) > 0 {
+		g.printf("\n    Parameters\n    ----------\n")
+		for _, field := range names {
+			fp := p.Properties[field]
+			typ := pyType(fp)
+			if !isRequired(p, field) {
+				typ += ", optional"
+			}
+			g.printf("    %s : %s\n", snakeCase(field), typ)
+			if doc := fieldDoc(fp); doc != "" {
+				g.printf("        %s\n", doc)
+			}
+		}
+	}
+	g.printf("    \"\"\"\n\n")
+
+	for _, field := range names {
+		if isRequired(p, field) {
+			g.printf("    %s: %s\n", snakeCase(field), pyType(p.Properties[field]))
+		} else {
+			g.printf("    %s: Optional[%s] = None\n", snakeCase(field), pyType(p.Properties[field]))
+		}
+	}
+	if len(names) > 0 {
+		g.printf("\n")
+	}
+
+	g.printf("    def to_dict(self) -> dict:\n")
+	g.printf("        return _drop_none({\n")
+	for _, field := range names {
+		g.printf("            %q: _to_json(self.%s),\n", field, snakeCase(field))
+	}
+	g.printf("        })\n\n")
+
+	g.printf("    @classmethod\n")
+	g.printf("    def from_dict(cls, data: dict) -> %s:\n", name)
+	g.printf("        return cls(\n")
+	for _, field := range names {
+		g.printf("            %s=%s,\n", snakeCase(field), pyFromJSON(p.Properties[field], fmt.Sprintf("data.get(%q)", field)))
+	}
+	g.printf("        )\n")
+}
+
+func (g *pyGenerator) method(name, rpcName string, m Schemas) {
+	method := snakeCase(name)
+	paramsType := name + "Params"
+	resultType := pyType(m.Returns)
+	if isStruct(m.Returns) {
+		resultType = name + "Result"
+	}
+
+	g.printf("\n    def %s(self, params: %s) -> %s:\n", method, paramsType, resultType)
+	g.printf("        \"\"\"\n        calls %s\n        \"\"\"\n", rpcName)
+	g.printf("        data = params.to_dict()\n")
+	if hasAWSParams(m.Parameters) {
+		g.printf("        if self._region is not None:\n            data.setdefault(\"Region\", self._region)\n")
+		g.printf("        if self._profile is not None:\n            data.setdefault(\"Profile\", self._profile)\n")
+		for _, field := range defaultedFields {
+			if m.Parameters.Properties[field] != nil {
+				g.printf("        if self._%s is not None:\n            data.setdefault(%q, self._%s)\n", snakeCase(field), field, snakeCase(field))
+			}
+		}
+	}
+	g.printf("        output = self._invoke(%q, data)\n", rpcName)
+	if isStruct(m.Returns) {
+		g.printf("        return %s.from_dict(output)\n", resultType)
+	} else {
+		g.printf("        return %s\n", pyFromJSON(m.Returns, "output"))
+	}
+}
+
+// pyType returns the Python type hint of values described by p.
+func pyType(p *Property) string {
+	if p.Ref != "" {
+		return definitionTypeName(p.Ref)
+	}
+	switch p.Type {
+	case "string":
+		return "str"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "List[" + pyType(p.Items) + "]"
+	case "object":
+		if ap, ok := p.AdditionalProperties.(*Property); ok {
+			return "Dict[str, " + pyType(ap) + "]"
+		}
+		return "Dict[str, Any]"
+	}
+	return "Any"
+}
+
+// pyFromJSON returns a Python expression turning expr, a decoded JSON value described by p, into
+// the Python type of p. A null value stays None.
+func pyFromJSON(p *Property, expr string) string {
+	switch {
+	case p.Ref != "":
+		return fmt.Sprintf("None if %s is None else %s.from_dict(%s)", expr, definitionTypeName(p.Ref), expr)
+	case p.Type == "array" && needsConversion(p.Items):
+		return fmt.Sprintf("None if %s is None else [%s for v in %s]", expr, pyFromJSON(p.Items, "v"), expr)
+	case p.Type == "object" && !isStruct(p):
+		if ap, ok := p.AdditionalProperties.(*Property); ok && needsConversion(ap) {
+			return fmt.Sprintf("None if %s is None else {k: %s for k, v in %s.items()}", expr, pyFromJSON(ap, "v"), expr)
+		}
+	}
+	return expr
+}
+
+// needsConversion reports whether decoded JSON values described by p are not already of the Python
+// type of p.
+func needsConversion(p *Property) bool {
+	switch {
+	case p.Ref != "":
+		return true
+	case p.Type == "array":
+		return needsConversion(p.Items)
+	case p.Type == "object":
+		if ap, ok := p.AdditionalProperties.(*Property); ok {
+			return needsConversion(ap)
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rpcspecs describes the params and results of RPC methods as JSON Schema, by reflecting on
+// the types implementing them.
+//
+// A struct field is required only if it is tagged with `required:"true"`, whatever its json tag, so
+// that types not written for the spec, such as those of the AWS SDK, have no required fields. This holds
+// for results as well as params: fields of results that are always set, such as those of
+// harness.Resource, must be tagged for generated clients to type them as present rather than optional,
+// and params checked by a method must be tagged for clients to require them.
+//
+// Fields can be documented with a `description:"..."` tag and limited to some values with an
+// `enum:"a,b"` tag. Named struct types, except the params and results themselves, are described once
+// under the definitions of the spec and referred to with $ref.
+package rpcspecs
+
+import (
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaVersion is the JSON Schema draft the spec follows.
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// Method is implemented by RPC methods: the params of a method are the fields of the struct
+// implementing it and ReflectOutput returns a value of the type of its result.
+type Method interface {
+	ReflectOutput() reflect.Value
+}
+
+type Spec struct {
+	Schema      string               `json:"$schema"`
+	Methods     map[string]Schemas   `json:"methods"`
+	Definitions map[string]*Property `json:"definitions,omitempty"`
+}
+
+// Schemas describes the params and result of a method.
+type Schemas struct {
+	Parameters *Property `json:"parameters"`
+	Returns    *Property `json:"returns"`
+}
+
+// Property is a JSON Schema. The zero value accepts any value.
+type Property struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Type        string               `json:"type,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Enum        []string             `json:"enum,omitempty"`
+	Properties  map[string]*Property `json:"properties,omitempty"`
+	Required    []string             `json:"required,omitempty"`
+	Items       *Property            `json:"items,omitempty"`
+	// AdditionalProperties is either a *Property describing the values of a map or false for a struct.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+}
+
+// Generate returns the spec of methods, keyed by method name.
+func Generate[M Method](methods map[string]M) *Spec {
+	g := &generator{definitions: map[string]*Property{}}
+	spec := &Spec{
+		Schema:  SchemaVersion,
+		Methods: map[string]Schemas{},
+	}
+	for name, m := range methods {
+		spec.Methods[name] = Schemas{
+			Parameters: g.schema(reflect.TypeOf(m), true),
+			Returns:    g.schema(m.ReflectOutput().Type(), true),
+		}
+	}
+	if len(g.definitions) > 0 {
+		spec.Definitions = g.definitions
+	}
+	return spec
+}
+
+type generator struct {
+	definitions map[string]*Property
+}
+
+// schema describes t. A named struct is described inline if inline is set, else by a reference to
+// its definition.
+func (g *generator) schema(t reflect.Type, inline bool) *Property {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Property{Type: "string"}
+	case reflect.Bool:
+		return &Property{Type: "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return &Property{Type: "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Property{Type: "integer"}
+	case reflect.Map:
+		return &Property{
+			Type:                 "object",
+			AdditionalProperties: g.schema(t.Elem(), false),
+		}
+	case reflect.Array, reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string
+			return &Property{Type: "string"}
+		}
+		return &Property{
+			Type:  "array",
+			Items: g.schema(t.Elem(), false),
+		}
+	case reflect.Struct:
+		if inline || t.Name() == "" {
+			return g.object(t)
+		}
+		name := definitionName(t)
+		if _, ok := g.definitions[name]; !ok {
+			// reserve the name first, the type may refer to itself
+			g.definitions[name] = nil
+			g.definitions[name] = g.object(t)
+		}
+		return &Property{Ref: "#/definitions/" + name}
+	default:
+		// interfaces hold anything
+		return &Property{}
+	}
+}
+
+func (g *generator) object(t reflect.Type) *Property {
+	p := &Property{
+		Type:                 "object",
+		Properties:           map[string]*Property{},
+		AdditionalProperties: false,
+	}
+	g.addFields(p, t)
+	sort.Strings(p.Required)
+	return p
+}
+
+func (g *generator) addFields(p *Property, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			et := ft
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				// encoding/json promotes the fields of an embedded struct
+				g.addFields(p, et)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fp := g.schema(ft, false)
+		fp.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			fp.Enum = strings.Split(enum, ",")
+		}
+		p.Properties[name] = fp
+		if field.Tag.Get("required") == "true" {
+			p.Required = append(p.Required, name)
+		}
+	}
+}
+
+// jsonName returns the name of field given by its json tag, if any, and whether it is not encoded at all.
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+// definitionName names the definition of t after its package and type name, e.g. "xray.Segment".
+// Packages named "types", as in the AWS SDK, are named after their parent instead.
+func definitionName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	name := path.Base(pkg)
+	if name == "types" {
+		name = path.Base(path.Dir(pkg))
+	}
+	return name + "." + t.Name()
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcspecs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNode struct {
+	Name     string      `json:"name" required:"true"`
+	Children []*testNode `json:"children,omitempty"`
+	internal string
+}
+
+type testCommon struct {
+	Region string `json:",omitempty" description:"AWS Region"`
+}
+
+type testParams struct {
+	testCommon
+	ID      string            `json:"Id" description:"Id of the thing" required:"true"`
+	Mode    string            `enum:"fast,slow" required:"true"`
+	Count   *int32            `json:"Count"`
+	Tags    map[string]string `json:"Tags,omitempty"`
+	Name    string
+	Any     interface{}
+	Raw     []byte
+	Ignored string `json:"-"`
+}
+
+func (p *testParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf(testNode{})).Elem()
+}
+
+type testListParams struct{}
+
+func (p *testListParams) ReflectOutput() reflect.Value {
+	return reflect.New(reflect.TypeOf([]testNode{})).Elem()
+}
+
+func TestGenerate(t *testing.T) {
+	spec := Generate(map[string]Method{
+		"test.get":  new(testParams),
+		"test.list": new(testListParams),
+	})
+
+	actual, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"methods": {
+			"test.get": {
+				"parameters": {
+					"type": "object",
+					"properties": {
+						"Region": {"type": "string", "description": "AWS Region"},
+						"Id": {"type": "string", "description": "Id of the thing"},
+						"Mode": {"type": "string", "enum": ["fast", "slow"]},
+						"Count": {"type": "integer"},
+						"Tags": {"type": "object", "additionalProperties": {"type": "string"}},
+						"Name": {"type": "string"},
+						"Any": {},
+						"Raw": {"type": "string"}
+					},
+					"required": ["Id", "Mode"],
+					"additionalProperties": false
+				},
+				"returns": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#/definitions/rpcspecs.testNode"}}
+					},
+					"required": ["name"],
+					"additionalProperties": false
+				}
+			},
+			"test.list": {
+				"parameters": {"type": "object", "additionalProperties": false},
+				"returns": {"type": "array", "items": {"$ref": "#/definitions/rpcspecs.testNode"}}
+			}
+		},
+		"definitions": {
+			"rpcspecs.testNode": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/definitions/rpcspecs.testNode"}}
+				},
+				"required": ["name"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(actual))
+}
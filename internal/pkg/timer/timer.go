@@ -2,18 +2,20 @@
 package timer
 
 import (
-	"log"
+	"context"
+	"iatk/internal/pkg/logging"
 	"time"
 )
 
-func Track(msg string) (string, time.Time) {
+func Track(ctx context.Context, msg string) (context.Context, string, time.Time) {
 	// track the time when this func is executed. Use with Duration.
-	return msg, time.Now()
+	return ctx, msg, time.Now()
 }
 
-func Duration(msg string, start time.Time) {
-	// track the duration since start. Use with Track.
+func Duration(ctx context.Context, msg string, start time.Time) {
+	// track the duration since start with the logger of ctx, so that the line identifies the call.
+	// Use with Track.
 	//  Example:
-	//    defer Duration(Track("MyFunc"))
-	log.Printf("%v: %v\n", msg, time.Since(start))
+	//    defer Duration(Track(ctx, "MyFunc"))
+	logging.FromContext(ctx).Debug(msg, "duration", time.Since(start))
 }
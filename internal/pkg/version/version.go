@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package version identifies the build of the iatk binary.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version is the release of the binary, set at build time to the one of the Python client it ships
+// with, by -ldflags "-X iatk/internal/pkg/version.Version=<version>". Builds without it are "dev".
+var Version = "dev"
+
+// Info describes the build of the binary.
+type Info struct {
+	// Version is the release of the binary.
+	Version string `json:"Version" description:"Release of the binary" required:"true"`
+	// GoVersion is the Go release the binary was built with.
+	GoVersion string `json:"GoVersion" description:"Go release the binary was built with" required:"true"`
+	// Platform is the operating system and architecture the binary was built for, e.g. "linux/amd64".
+	Platform string `json:"Platform" description:"Operating system and architecture the binary was built for" required:"true"`
+	// Revision is the VCS revision the binary was built from, if known.
+	Revision string `json:"Revision,omitempty" description:"VCS revision the binary was built from, if known"`
+}
+
+// Get returns the Info of the running binary.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" {
+				info.Revision = s.Value
+			}
+		}
+	}
+	return info
+}
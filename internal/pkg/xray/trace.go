@@ -45,7 +45,7 @@ func GetTraces(ctx context.Context, api BatchGetTracesAPI, traceIds []string) (m
 			resp, err := paginator.NextPage(ctx)
 
 			if err != nil {
-				return nil, fmt.Errorf("failed to get Traces: %v", err)
+				return nil, fmt.Errorf("failed to get Traces: %w", err)
 			}
 
 			unprocessedTraceIds = resp.UnprocessedTraceIds
@@ -3,6 +3,7 @@ package xray
 import (
 	"context"
 	"fmt"
+	"iatk/internal/pkg/progress"
 	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -80,6 +81,7 @@ func buildTree(traceMap map[string]*Trace, sourceTrace *Trace, fetchLinkedTraces
 			if err != nil {
 				return nil, fmt.Errorf("failed to fetch linked traces %s with error: %w", linkedTraceIds, err)
 			}
+			progress.Report(ctx, fmt.Sprintf("linked traces fetched at depth %d", *depth), map[string]interface{}{"depth": *depth, "trace_ids": linkedTraceIds})
 			for linkedTraceId, linkedTrace := range linkedTraceMap {
 				linkedTree, err := buildTree(linkedTraceMap, linkedTrace, fetchLinkedTraces, ctx, opts, depth)
 				if err != nil {
@@ -109,14 +111,14 @@ func buildTree(traceMap map[string]*Trace, sourceTrace *Trace, fetchLinkedTraces
 func getLinkedTraces(segment *Segment, linksToSegMap map[string]*Segment) {
 	linkedTraces := segment.Links
 	for _, trace := range linkedTraces {
-		if *trace.Attributes.ReferenceType == "child" {
+		if isChildLink(trace) {
 			linksToSegMap[*trace.TraceId] = segment
 		}
 	}
 
 	for _, subseg := range segment.Subsegments {
 		for _, trace := range subseg.Links {
-			if *trace.Attributes.ReferenceType == "child" {
+			if isChildLink(trace) {
 				linksToSegMap[*trace.TraceId] = segment
 			}
 		}
@@ -124,6 +126,13 @@ func getLinkedTraces(segment *Segment, linksToSegMap map[string]*Segment) {
 
 }
 
+// isChildLink reports whether link points to a child trace. Links missing their trace id or
+// reference type, which X-Ray does not guarantee, are not followed.
+func isChildLink(link *Link) bool {
+	return link != nil && link.TraceId != nil && link.Attributes != nil &&
+		link.Attributes.ReferenceType != nil && *link.Attributes.ReferenceType == ReferenceTypeChild
+}
+
 // Add the provided child segment to the parentSegment's children
 func InsertSegmentChild(parentSegment *Segment, childSegment *Segment) {
 	parentSegment.children = append(parentSegment.children, childSegment)
@@ -308,6 +308,39 @@ func TestCreateSubSegIdtoSegMap(t *testing.T) {
 	}
 }
 
+func TestGetLinkedTraces(t *testing.T) {
+	child := ReferenceTypeChild
+	parent := ReferenceTypeParent
+	segment := &Segment{
+		Id: aws.String("segment1-id"),
+		Links: []*Link{
+			{TraceId: aws.String("child-trace"), Attributes: &LinkAttributes{ReferenceType: &child}},
+			{TraceId: aws.String("parent-trace"), Attributes: &LinkAttributes{ReferenceType: &parent}},
+			{TraceId: aws.String("no-attributes")},
+			{TraceId: aws.String("no-reference-type"), Attributes: &LinkAttributes{}},
+			{Attributes: &LinkAttributes{ReferenceType: &child}},
+			nil,
+		},
+		Subsegments: []*Subsegment{
+			{
+				Id: aws.String("subsegment1-id"),
+				Links: []*Link{
+					{TraceId: aws.String("subsegment-child-trace"), Attributes: &LinkAttributes{ReferenceType: &child}},
+					{TraceId: aws.String("subsegment-no-attributes")},
+				},
+			},
+		},
+	}
+	linksToSegMap := map[string]*Segment{}
+
+	getLinkedTraces(segment, linksToSegMap)
+
+	assert.Equal(t, map[string]*Segment{
+		"child-trace":            segment,
+		"subsegment-child-trace": segment,
+	}, linksToSegMap)
+}
+
 func TestBuildTreeFromTraceDoc(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -9,7 +9,7 @@ type Trace struct {
 	Id            *string    `json:"id"`
 	Duration      *float64   `json:"duration"`
 	LimitExceeded *bool      `json:"limitExceeded"`
-	Segments      []*Segment `json:"segments"`
+	Segments      []*Segment `json:"segments" required:"true"`
 }
 
 type Segment struct {
@@ -133,14 +133,14 @@ type Link struct {
 }
 
 type LinkAttributes struct {
-	ReferenceType *ReferenceType `json:"aws.xray.reserved.reference_type"`
+	ReferenceType *ReferenceType `json:"aws.xray.reserved.reference_type" enum:"parent,child"`
 }
 
 type Tree struct {
 	Root                     *Segment     `json:"root"`
-	Paths                    [][]*Segment `json:"paths"`
+	Paths                    [][]*Segment `json:"paths" required:"true"`
 	SourceTrace              *Trace       `json:"source_trace"`
-	LinkedTraceLimitExceeded bool         `json:"linked_trace_limit_exceeded"`
+	LinkedTraceLimitExceeded bool         `json:"linked_trace_limit_exceeded" required:"true"`
 }
 
 type treeOptions struct {
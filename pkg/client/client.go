@@ -0,0 +1,183 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client calls the RPC methods of the iatk binary from Go. The types and methods of Client
+// matching each RPC method are generated in client_gen.go by cmd/rpcspecs.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	"iatk/internal/pkg/version"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Version is the release of the client, sent along with the Go release in the metadata of calls, as the
+// binary identifies its client in the user agent of AWS calls. It is the release of the binary the client
+// is built with, see version.Version, or 0.0.0-dev for builds without one.
+var Version = clientVersion()
+
+func clientVersion() string {
+	if version.Version == "dev" {
+		return "0.0.0-dev"
+	}
+	return version.Version
+}
+
+// Client runs the iatk binary once per call.
+type Client struct {
+	// BinaryPath locates the iatk binary. Defaults to "iatk", looked up in PATH.
+	BinaryPath string
+	// Region is the AWS Region used by methods taking one, unless set in their params.
+	Region string
+	// Profile is the AWS Profile used by methods taking one, unless set in their params.
+	Profile string
+	// EndpointURL is the URL AWS requests are sent to by methods taking one, unless set in their params,
+	// e.g. the one of a local emulator.
+	EndpointURL string
+	// EndpointURLs are the URLs the AWS requests of some services are sent to by methods taking them,
+	// keyed by service ID, unless set in their params.
+	EndpointURLs map[string]string
+	// RoleArn is the ARN of the IAM role assumed by methods taking one, unless set in their params, along
+	// with ExternalId, RoleSessionName and DurationSeconds.
+	RoleArn         string
+	ExternalId      string
+	RoleSessionName string
+	DurationSeconds *int64
+	// RetryMode, MaxAttempts and MaxBackoffSeconds tune how failed AWS calls are retried by methods
+	// taking them, unless set in their params.
+	RetryMode         string
+	MaxAttempts       *int64
+	MaxBackoffSeconds *int64
+	// Env is added to the environment of the binary.
+	Env []string
+}
+
+// Error is an error response of the binary.
+type Error struct {
+	Code    int
+	Message string
+	Data    *ErrorData
+}
+
+// ErrorData details the failure behind an Error: the AWS call that failed or, for an internal error, the
+// panic of the method.
+type ErrorData struct {
+	// Service is the AWS service called, e.g. "SQS".
+	Service string `json:"service,omitempty"`
+	// Operation is the API operation called, e.g. "GetQueueUrl".
+	Operation string `json:"operation,omitempty"`
+	// ErrorCode is the error code returned by the service, e.g. "AWS.SimpleQueueService.NonExistentQueue".
+	ErrorCode string `json:"error_code,omitempty"`
+	// RequestID identifies the request to the service.
+	RequestID string `json:"request_id,omitempty"`
+	// HTTPStatusCode is the status code of the service response.
+	HTTPStatusCode int `json:"http_status_code,omitempty"`
+	// Retryable tells whether the call may succeed if made again.
+	Retryable bool `json:"retryable"`
+	// Attempts is the number of attempts of the call, retries included.
+	Attempts int `json:"attempts,omitempty"`
+	// Stack is the stack trace of the method that panicked.
+	Stack string `json:"stack,omitempty"`
+	// CrashReport is the path of the crash report written about the panic, if any.
+	CrashReport string `json:"crash_report,omitempty"`
+	// Report is the outcome for each test harness of a method that failed to destroy some of them, as
+	// in the output of the method.
+	Report json.RawMessage `json:"report,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("iatk error %d: %s", e.Code, e.Message)
+}
+
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params: %w", err)
+	}
+	id := uuid.NewString()
+	req, err := json.Marshal(jsonrpc.Request{
+		JSONRPC: "2.0",
+		ID:      &id,
+		Method:  method,
+		Params:  rawParams,
+		Metadata: &jsonrpc.Metadata{
+			Client:        "go",
+			Version:       Version,
+			ClientVersion: strings.TrimPrefix(runtime.Version(), "go"),
+			Caller:        method,
+			DedupKey:      uuid.NewString(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	binaryPath := c.BinaryPath
+	if binaryPath == "" {
+		binaryPath = "iatk"
+	}
+	cmd := exec.CommandContext(ctx, binaryPath)
+	cmd.Env = append(os.Environ(), c.Env...)
+	cmd.Stdin = bytes.NewReader(req)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w: %s", binaryPath, err, stderr.String())
+	}
+
+	resp, err := findResponse(out, id)
+	if err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", binaryPath, err)
+	}
+	if resp.Error != nil {
+		return &Error{Code: resp.Error.Code, Message: resp.Error.Message, Data: resp.Error.Data}
+	}
+	if resp.Result == nil {
+		return fmt.Errorf("response of %s has no result", method)
+	}
+	if err := json.Unmarshal(resp.Result.Output, result); err != nil {
+		return fmt.Errorf("failed to decode output: %w", err)
+	}
+	return nil
+}
+
+type response struct {
+	ID     *string `json:"id"`
+	Result *struct {
+		Output json.RawMessage `json:"output"`
+	} `json:"result"`
+	Error *struct {
+		Code    int        `json:"code"`
+		Message string     `json:"message"`
+		Data    *ErrorData `json:"data"`
+	} `json:"error"`
+}
+
+// findResponse returns the response to the request identified by id among the lines of out, which
+// may also hold notifications, such as progress, sent ahead of it.
+func findResponse(out []byte, id string) (*response, error) {
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var resp response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return nil, err
+		}
+		if resp.ID != nil && *resp.ID == id {
+			return &resp, nil
+		}
+	}
+	return nil, fmt.Errorf("no response to request %q in output %q", id, out)
+}
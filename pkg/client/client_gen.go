@@ -0,0 +1,1151 @@
+// Code generated by rpcspecs. DO NOT EDIT.
+
+package client
+
+import "context"
+
+// GcOutput is gc.Output.
+type GcOutput struct {
+	// Creation time of the test harness, in RFC3339 format.
+	Created string `json:"Created"`
+	Id      string `json:"Id"`
+	// One of: Expired, TTLExpired, Orphaned.
+	Reason string `json:"Reason"`
+	// One of: Destroyed, AlreadyAbsent, Failed, WouldDestroy.
+	Status string `json:"Status"`
+	Type   string `json:"Type"`
+	// ARNs of the resources of the test harness that would be destroyed, on a dry run.
+	ComponentArns []string `json:"ComponentArns,omitempty"`
+	// Reason the test harness could not be destroyed, if it failed.
+	Error string `json:"Error,omitempty"`
+	// ARNs of the resources of the test harness left behind, if it failed.
+	LeftoverArns []string `json:"LeftoverArns,omitempty"`
+}
+
+// HarnessResource is harness.Resource.
+type HarnessResource struct {
+	ARN        string `json:"ARN"`
+	PhysicalID string `json:"PhysicalID"`
+	Type       string `json:"Type"`
+}
+
+// InventoryOutput is inventory.Output.
+type InventoryOutput struct {
+	// Resources of the test harness that exist.
+	Components []HarnessResource `json:"Components"`
+	Id         string            `json:"Id"`
+	// Custom tags of the test harness.
+	Tags map[string]string `json:"Tags"`
+	// ARN of the resource under test, e.g. the event bus of a Listener.
+	Target string `json:"Target"`
+	Type   string `json:"Type"`
+	// Approximate number of events waiting in the queue of a Listener.
+	ApproximateQueueDepth *int64 `json:"ApproximateQueueDepth,omitempty"`
+	// Creation time of the test harness, in RFC3339 format.
+	Created string `json:"Created,omitempty"`
+	// Reason the test harness could not be described, if it failed.
+	Error string `json:"Error,omitempty"`
+}
+
+// ListenerDestroyOutput is listener.DestroyOutput.
+type ListenerDestroyOutput struct {
+	Id string `json:"Id"`
+	// One of: Destroyed, AlreadyAbsent, Failed, WouldDestroy.
+	Status string `json:"Status"`
+	// ARNs of the components of the Listener that would be removed, on a dry run.
+	ComponentArns []string `json:"ComponentArns,omitempty"`
+	// Reason the Listener could not be removed, if it failed.
+	Error string `json:"Error,omitempty"`
+	// ARNs of the components of the Listener left behind, if it failed.
+	LeftoverArns []string `json:"LeftoverArns,omitempty"`
+}
+
+// PatternResult is pattern.Result.
+type PatternResult struct {
+	Match bool `json:"Match"`
+	// Reason the event could not be matched, e.g. it is not a JSON object.
+	Error string `json:"Error,omitempty"`
+}
+
+// ResourcegroupstaggingapiTagFilter is resourcegroupstaggingapi.TagFilter.
+type ResourcegroupstaggingapiTagFilter struct {
+	Key    string   `json:"Key,omitempty"`
+	Values []string `json:"Values,omitempty"`
+}
+
+// RpcspecsContentDescriptor is rpcspecs.ContentDescriptor.
+type RpcspecsContentDescriptor struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Required    *bool             `json:"required,omitempty"`
+	Schema      *RpcspecsProperty `json:"schema,omitempty"`
+}
+
+// RpcspecsOpenRPCComponents is rpcspecs.OpenRPCComponents.
+type RpcspecsOpenRPCComponents struct {
+	Schemas map[string]RpcspecsProperty `json:"schemas,omitempty"`
+}
+
+// RpcspecsOpenRPCInfo is rpcspecs.OpenRPCInfo.
+type RpcspecsOpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// RpcspecsOpenRPCMethod is rpcspecs.OpenRPCMethod.
+type RpcspecsOpenRPCMethod struct {
+	Name string `json:"name"`
+	// One of: by-name, by-position, either.
+	ParamStructure string                      `json:"paramStructure"`
+	Params         []RpcspecsContentDescriptor `json:"params"`
+	Result         *RpcspecsContentDescriptor  `json:"result"`
+}
+
+// RpcspecsProperty is rpcspecs.Property.
+type RpcspecsProperty struct {
+	Ref                  string                      `json:"$ref,omitempty"`
+	AdditionalProperties interface{}                 `json:"additionalProperties,omitempty"`
+	Description          string                      `json:"description,omitempty"`
+	Enum                 []string                    `json:"enum,omitempty"`
+	Items                *RpcspecsProperty           `json:"items,omitempty"`
+	Properties           map[string]RpcspecsProperty `json:"properties,omitempty"`
+	Required             []string                    `json:"required,omitempty"`
+	Type                 string                      `json:"type,omitempty"`
+}
+
+// XrayHttp is xray.Http.
+type XrayHttp struct {
+	Request  *XrayRequest  `json:"request,omitempty"`
+	Response *XrayResponse `json:"response,omitempty"`
+}
+
+// XrayLink is xray.Link.
+type XrayLink struct {
+	Attributes *XrayLinkAttributes `json:"attributes,omitempty"`
+	Id         string              `json:"id,omitempty"`
+	TraceId    string              `json:"trace_id,omitempty"`
+}
+
+// XrayLinkAttributes is xray.LinkAttributes.
+type XrayLinkAttributes struct {
+	// One of: parent, child.
+	AwsXrayReservedReferenceType string `json:"aws.xray.reserved.reference_type,omitempty"`
+}
+
+// XrayRequest is xray.Request.
+type XrayRequest struct {
+	ClientIp      string `json:"client_ip,omitempty"`
+	Method        string `json:"method,omitempty"`
+	Traced        *bool  `json:"traced,omitempty"`
+	Url           string `json:"url,omitempty"`
+	UserAgent     string `json:"user_agent,omitempty"`
+	XForwardedFor *bool  `json:"x_forwarded_for,omitempty"`
+}
+
+// XrayResponse is xray.Response.
+type XrayResponse struct {
+	ContentLength *int64 `json:"content_length,omitempty"`
+	Status        *int64 `json:"status,omitempty"`
+}
+
+// XraySegment is xray.Segment.
+type XraySegment struct {
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+	Aws         interface{}            `json:"aws,omitempty"`
+	Cause       interface{}            `json:"cause,omitempty"`
+	EndTime     *float64               `json:"end_time,omitempty"`
+	Error       *bool                  `json:"error,omitempty"`
+	Fault       *bool                  `json:"fault,omitempty"`
+	Http        *XrayHttp              `json:"http,omitempty"`
+	Id          string                 `json:"id,omitempty"`
+	InProgress  *bool                  `json:"in_progress,omitempty"`
+	Links       []XrayLink             `json:"links,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Origin      string                 `json:"origin,omitempty"`
+	ParentId    string                 `json:"parent_id,omitempty"`
+	Service     *XrayService           `json:"service,omitempty"`
+	StartTime   *float64               `json:"start_time,omitempty"`
+	Subsegments []XraySubsegment       `json:"subsegments,omitempty"`
+	Throttle    *bool                  `json:"throttle,omitempty"`
+	TraceId     string                 `json:"trace_id,omitempty"`
+	User        string                 `json:"user,omitempty"`
+}
+
+// XrayService is xray.Service.
+type XrayService struct {
+	Type string `json:"type,omitempty"`
+}
+
+// XraySql is xray.Sql.
+type XraySql struct {
+	ConnectionString string `json:"connection_string,omitempty"`
+	DatabaseType     string `json:"database_type,omitempty"`
+	DatabaseVersion  string `json:"database_version,omitempty"`
+	DriverVersion    string `json:"driver_version,omitempty"`
+	Preparation      string `json:"preparation,omitempty"`
+	SanitizedQuery   string `json:"sanitized_query,omitempty"`
+	Url              string `json:"url,omitempty"`
+	User             string `json:"user,omitempty"`
+}
+
+// XraySubsegment is xray.Subsegment.
+type XraySubsegment struct {
+	Annotations  map[string]interface{} `json:"annotations,omitempty"`
+	Aws          interface{}            `json:"aws,omitempty"`
+	Cause        interface{}            `json:"cause,omitempty"`
+	EndTime      *float64               `json:"end_time,omitempty"`
+	Error        *bool                  `json:"error,omitempty"`
+	Fault        *bool                  `json:"fault,omitempty"`
+	Http         *XrayHttp              `json:"http,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	InProgress   *bool                  `json:"in_progress,omitempty"`
+	Links        []XrayLink             `json:"links,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Name         string                 `json:"name,omitempty"`
+	Namespace    string                 `json:"namespace,omitempty"`
+	ParentId     string                 `json:"parent_id,omitempty"`
+	PrecursorIds []string               `json:"precursor_ids,omitempty"`
+	Sql          *XraySql               `json:"sql,omitempty"`
+	StartTime    *float64               `json:"start_time,omitempty"`
+	Subsegments  []XraySubsegment       `json:"subsegments,omitempty"`
+	Throttle     *bool                  `json:"throttle,omitempty"`
+	TraceId      string                 `json:"trace_id,omitempty"`
+	Traced       *bool                  `json:"traced,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+}
+
+// XrayTrace is xray.Trace.
+type XrayTrace struct {
+	Segments      []XraySegment `json:"segments"`
+	Duration      *float64      `json:"duration,omitempty"`
+	Id            string        `json:"id,omitempty"`
+	LimitExceeded *bool         `json:"limitExceeded,omitempty"`
+}
+
+// GetPhysicalIdParams are the params of GetPhysicalId.
+type GetPhysicalIdParams struct {
+	// Name of the Logical Id within the Stack to fetch.
+	LogicalResourceId string `json:"LogicalResourceId"`
+	// Name of the CloudFormation Stack.
+	StackName string `json:"StackName"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+}
+
+// GetPhysicalId calls get_physical_id.
+func (c *Client) GetPhysicalId(ctx context.Context, params GetPhysicalIdParams) (string, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result string
+	if err := c.call(ctx, "get_physical_id", params, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// GetStackOutputsParams are the params of GetStackOutputs.
+type GetStackOutputsParams struct {
+	// Keys of the Stack Outputs to fetch.
+	OutputNames []string `json:"OutputNames"`
+	// Name of the CloudFormation Stack.
+	StackName string `json:"StackName"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+}
+
+// GetStackOutputs calls get_stack_outputs.
+func (c *Client) GetStackOutputs(ctx context.Context, params GetStackOutputsParams) (map[string]string, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result map[string]string
+	if err := c.call(ctx, "get_stack_outputs", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTraceTreeParams are the params of GetTraceTree.
+type GetTraceTreeParams struct {
+	// Trace header to get the trace tree.
+	TracingHeader string `json:"TracingHeader"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Flag to determine if linked traces will be included in the tree.
+	FetchChildTraces *bool `json:"FetchChildTraces,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+}
+
+// GetTraceTreeResult is the result of GetTraceTree.
+type GetTraceTreeResult struct {
+	LinkedTraceLimitExceeded bool            `json:"linked_trace_limit_exceeded"`
+	Paths                    [][]XraySegment `json:"paths"`
+	Root                     *XraySegment    `json:"root,omitempty"`
+	SourceTrace              *XrayTrace      `json:"source_trace,omitempty"`
+}
+
+// GetTraceTree calls get_trace_tree.
+func (c *Client) GetTraceTree(ctx context.Context, params GetTraceTreeParams) (*GetTraceTreeResult, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result GetTraceTreeResult
+	if err := c.call(ctx, "get_trace_tree", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CapabilitiesParams are the params of Capabilities.
+type CapabilitiesParams struct {
+}
+
+// CapabilitiesResult is the result of Capabilities.
+type CapabilitiesResult struct {
+	// Optional parts of the protocol the binary supports.
+	Features []string `json:"Features"`
+	// Names of the RPC methods the binary supports, sorted.
+	Methods []string `json:"Methods"`
+	// Release of the binary.
+	Version string `json:"Version"`
+}
+
+// Capabilities calls iatk.capabilities.
+func (c *Client) Capabilities(ctx context.Context, params CapabilitiesParams) (*CapabilitiesResult, error) {
+	var result CapabilitiesResult
+	if err := c.call(ctx, "iatk.capabilities", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// VersionParams are the params of Version.
+type VersionParams struct {
+}
+
+// VersionResult is the result of Version.
+type VersionResult struct {
+	// Go release the binary was built with.
+	GoVersion string `json:"GoVersion"`
+	// Operating system and architecture the binary was built for.
+	Platform string `json:"Platform"`
+	// Release of the binary.
+	Version string `json:"Version"`
+	// VCS revision the binary was built from, if known.
+	Revision string `json:"Revision,omitempty"`
+}
+
+// Version calls iatk.version.
+func (c *Client) Version(ctx context.Context, params VersionParams) (*VersionResult, error) {
+	var result VersionResult
+	if err := c.call(ctx, "iatk.version", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GenerateBareboneEventParams are the params of GenerateBareboneEvent.
+type GenerateBareboneEventParams struct {
+	// Name of the registry of the schema stored in EventBridge Schema Registry.
+	RegistryName string `json:"RegistryName"`
+	// Name of the schema stored in EventBridge Schema Registry.
+	SchemaName string `json:"SchemaName"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// Location to the event in the schema in json schema ref syntax, only applicable for openapi schema.
+	EventRef string `json:"EventRef,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Version of the schema stored in EventBridge Schema Registry.
+	SchemaVersion string `json:"SchemaVersion,omitempty"`
+	// If set to true, do not generate optional fields.
+	SkipOptional *bool `json:"SkipOptional,omitempty"`
+}
+
+// GenerateBareboneEvent calls mock.generate_barebone_event.
+func (c *Client) GenerateBareboneEvent(ctx context.Context, params GenerateBareboneEventParams) (string, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result string
+	if err := c.call(ctx, "mock.generate_barebone_event", params, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// DiscoverParams are the params of Discover.
+type DiscoverParams struct {
+}
+
+// DiscoverResult is the result of Discover.
+type DiscoverResult struct {
+	Components *RpcspecsOpenRPCComponents `json:"components"`
+	Info       *RpcspecsOpenRPCInfo       `json:"info"`
+	Methods    []RpcspecsOpenRPCMethod    `json:"methods"`
+	// Version of the OpenRPC specification the document follows.
+	Openrpc string `json:"openrpc"`
+}
+
+// Discover calls rpc.discover.
+func (c *Client) Discover(ctx context.Context, params DiscoverParams) (*DiscoverResult, error) {
+	var result DiscoverResult
+	if err := c.call(ctx, "rpc.discover", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DescribeParams are the params of Describe.
+type DescribeParams struct {
+	// Id of the test harness to describe.
+	Id string `json:"Id"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+}
+
+// DescribeResult is the result of Describe.
+type DescribeResult struct {
+	// Resources of the test harness that exist.
+	Components []HarnessResource `json:"Components"`
+	Id         string            `json:"Id"`
+	// Custom tags of the test harness.
+	Tags map[string]string `json:"Tags"`
+	// ARN of the resource under test, e.g. the event bus of a Listener.
+	Target string `json:"Target"`
+	Type   string `json:"Type"`
+	// Approximate number of events waiting in the queue of a Listener.
+	ApproximateQueueDepth *int64 `json:"ApproximateQueueDepth,omitempty"`
+	// Creation time of the test harness, in RFC3339 format.
+	Created string `json:"Created,omitempty"`
+	// Reason the test harness could not be described, if it failed.
+	Error string `json:"Error,omitempty"`
+}
+
+// Describe calls test_harness.describe.
+func (c *Client) Describe(ctx context.Context, params DescribeParams) (*DescribeResult, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result DescribeResult
+	if err := c.call(ctx, "test_harness.describe", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddListenerParams are the params of AddListener.
+type AddListenerParams struct {
+	// Name of the AWS Event Bus.
+	EventBusName string `json:"EventBusName"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// Event pattern of the listener, overriding the one of the Rule if supplied.
+	EventPattern string `json:"EventPattern,omitempty"`
+	// Event patterns AND-merged with the event pattern of the listener.
+	EventPatternRefinements []string `json:"EventPatternRefinements,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Name of a Rule on the EventBus to replicate. Required if no EventPattern is supplied.
+	RuleName string `json:"RuleName,omitempty"`
+	// A key-value pair associated EventBridge rule.
+	Tags map[string]string `json:"Tags,omitempty"`
+	// Target Id on the given rule to replicate.
+	TargetId string `json:"TargetId,omitempty"`
+	// Time-to-live of the listener in seconds, after which test_harness.gc destroys it.
+	TtlSeconds *int64 `json:"TtlSeconds,omitempty"`
+}
+
+// AddListenerResult is the result of AddListener.
+type AddListenerResult struct {
+	Components      []HarnessResource `json:"Components"`
+	Id              string            `json:"Id"`
+	TargetUnderTest *HarnessResource  `json:"TargetUnderTest"`
+}
+
+// AddListener calls test_harness.eventbridge.add_listener.
+func (c *Client) AddListener(ctx context.Context, params AddListenerParams) (*AddListenerResult, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result AddListenerResult
+	if err := c.call(ctx, "test_harness.eventbridge.add_listener", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PollEventsParams are the params of PollEvents.
+type PollEventsParams struct {
+	// Id of the Listener that was created.
+	ListenerId string `json:"ListenerId"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// Event pattern the events returned must match. Events polled that do not match it are left on the queue.
+	EventPattern string `json:"EventPattern,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// Max number of messages to poll.
+	MaxNumberOfMessages *int64 `json:"MaxNumberOfMessages,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Time in seconds to wait for polling.
+	WaitTimeSeconds *int64 `json:"WaitTimeSeconds,omitempty"`
+}
+
+// PollEvents calls test_harness.eventbridge.poll_events.
+func (c *Client) PollEvents(ctx context.Context, params PollEventsParams) ([]string, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result []string
+	if err := c.call(ctx, "test_harness.eventbridge.poll_events", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RemoveListenersParams are the params of RemoveListeners.
+type RemoveListenersParams struct {
+	// Whether to only report the Listeners and components that would be removed, without removing them.
+	DryRun *bool `json:"DryRun,omitempty"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Ids of the Listeners to remove, one of Ids and TagFilters must be supplied.
+	Ids []string `json:"Ids,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Tag filters matching the Listeners to remove, one of Ids and TagFilters must be supplied.
+	TagFilters []ResourcegroupstaggingapiTagFilter `json:"TagFilters,omitempty"`
+}
+
+// RemoveListeners calls test_harness.eventbridge.remove_listeners.
+func (c *Client) RemoveListeners(ctx context.Context, params RemoveListenersParams) ([]ListenerDestroyOutput, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result []ListenerDestroyOutput
+	if err := c.call(ctx, "test_harness.eventbridge.remove_listeners", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TestPatternParams are the params of TestPattern.
+type TestPatternParams struct {
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// Name of the AWS Event Bus of the Rule, default if not supplied.
+	EventBusName string `json:"EventBusName,omitempty"`
+	// Event pattern to test. Required if no RuleName is supplied.
+	EventPattern string `json:"EventPattern,omitempty"`
+	// Events to match against the event pattern, as JSON strings. If not supplied, the event pattern is only validated.
+	Events []string `json:"Events,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Name of a Rule whose event pattern to test.
+	RuleName string `json:"RuleName,omitempty"`
+}
+
+// TestPatternResult is the result of TestPattern.
+type TestPatternResult struct {
+	// Event pattern the events were matched against.
+	EventPattern string `json:"EventPattern"`
+	// Outcome of matching each event, in order.
+	Results []PatternResult `json:"Results"`
+}
+
+// TestPattern calls test_harness.eventbridge.test_pattern.
+func (c *Client) TestPattern(ctx context.Context, params TestPatternParams) (*TestPatternResult, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result TestPatternResult
+	if err := c.call(ctx, "test_harness.eventbridge.test_pattern", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GcParams are the params of Gc.
+type GcParams struct {
+	// Whether to only report the test harnesses that would be destroyed, without destroying them.
+	DryRun *bool `json:"DryRun,omitempty"`
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Age in seconds over which test harnesses are destroyed. If not supplied, only the expired and orphaned ones are.
+	MaxAgeSeconds *int64 `json:"MaxAgeSeconds,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Tag filters matching the test harnesses to consider, all iatk-created ones if not supplied.
+	TagFilters []ResourcegroupstaggingapiTagFilter `json:"TagFilters,omitempty"`
+}
+
+// Gc calls test_harness.gc.
+func (c *Client) Gc(ctx context.Context, params GcParams) ([]GcOutput, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result []GcOutput
+	if err := c.call(ctx, "test_harness.gc", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListParams are the params of List.
+type ListParams struct {
+	// Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+	// URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+	EndpointURL string `json:"EndpointURL,omitempty"`
+	// URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+	EndpointURLs map[string]string `json:"EndpointURLs,omitempty"`
+	// External ID the trust policy of the role to assume may require.
+	ExternalId string `json:"ExternalId,omitempty"`
+	// Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+	MaxAttempts *int64 `json:"MaxAttempts,omitempty"`
+	// Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+	MaxBackoffSeconds *int64 `json:"MaxBackoffSeconds,omitempty"`
+	// AWS Profile used to communicate with AWS resources.
+	Profile string `json:"Profile,omitempty"`
+	// AWS Region used to interact with AWS.
+	Region string `json:"Region,omitempty"`
+	// How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+	RetryMode string `json:"RetryMode,omitempty"`
+	// ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// Name of the session of the role to assume, aws-iatk by default.
+	RoleSessionName string `json:"RoleSessionName,omitempty"`
+	// Tag filters matching the test harnesses to list, all iatk-created ones if not supplied.
+	TagFilters []ResourcegroupstaggingapiTagFilter `json:"TagFilters,omitempty"`
+}
+
+// List calls test_harness.list.
+func (c *Client) List(ctx context.Context, params ListParams) ([]InventoryOutput, error) {
+	if params.Region == "" {
+		params.Region = c.Region
+	}
+	if params.Profile == "" {
+		params.Profile = c.Profile
+	}
+	if params.EndpointURL == "" {
+		params.EndpointURL = c.EndpointURL
+	}
+	if params.EndpointURLs == nil {
+		params.EndpointURLs = c.EndpointURLs
+	}
+	if params.RoleArn == "" {
+		params.RoleArn = c.RoleArn
+	}
+	if params.ExternalId == "" {
+		params.ExternalId = c.ExternalId
+	}
+	if params.RoleSessionName == "" {
+		params.RoleSessionName = c.RoleSessionName
+	}
+	if params.DurationSeconds == nil {
+		params.DurationSeconds = c.DurationSeconds
+	}
+	if params.RetryMode == "" {
+		params.RetryMode = c.RetryMode
+	}
+	if params.MaxAttempts == nil {
+		params.MaxAttempts = c.MaxAttempts
+	}
+	if params.MaxBackoffSeconds == nil {
+		params.MaxBackoffSeconds = c.MaxBackoffSeconds
+	}
+	var result []InventoryOutput
+	if err := c.call(ctx, "test_harness.list", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iatk/internal/pkg/jsonrpc"
+	publicrpc "iatk/internal/pkg/public-rpc"
+	"iatk/internal/pkg/rpcspecs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	spec := rpcspecs.Generate(publicrpc.Default.Methods())
+
+	goSrc, err := rpcspecs.GoClient(spec, "client")
+	require.NoError(t, err)
+	actual, err := os.ReadFile("client_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(goSrc), string(actual), "client_gen.go is out of date, run make generate-rpc-spec")
+
+	actual, err = os.ReadFile("../../python-client/src/aws_iatk/rpc.py")
+	require.NoError(t, err)
+	assert.Equal(t, string(rpcspecs.PythonClient(spec)), string(actual), "rpc.py is out of date, run make generate-rpc-spec")
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv("IATK_TEST_HELPER") == "1" {
+		helperProcess()
+	}
+	os.Exit(m.Run())
+}
+
+// helperProcess stands in for the iatk binary, run by the test binary itself, answering the request
+// read from stdin with the response in IATK_TEST_RESPONSE, with $ID replaced by the ID of the request,
+// if it calls the expected method with the expected params.
+func helperProcess() {
+	var req map[string]interface{}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(2)
+	}
+	if os.Getenv("IATK_TEST_EXPECT_METHOD") != req["method"] {
+		fmt.Fprintf(os.Stderr, "unexpected method %v", req["method"])
+		os.Exit(3)
+	}
+	metadata, _ := json.Marshal(req["metadata"])
+	var m jsonrpc.Metadata
+	if err := json.Unmarshal(metadata, &m); err != nil || m.UserAgentValue() == "unknown" {
+		fmt.Fprintf(os.Stderr, "invalid metadata %s", metadata)
+		os.Exit(3)
+	}
+	params, _ := json.Marshal(req["params"])
+	if os.Getenv("IATK_TEST_EXPECT_PARAMS") != string(params) {
+		fmt.Fprintf(os.Stderr, "unexpected params %s", params)
+		os.Exit(3)
+	}
+	fmt.Println(`{"jsonrpc":"2.0","method":"$/progress","params":{"message":"working"}}`)
+	fmt.Println(strings.ReplaceAll(os.Getenv("IATK_TEST_RESPONSE"), "$ID", fmt.Sprint(req["id"])))
+	os.Exit(0)
+}
+
+func helperClient(method, params, response string) *Client {
+	return &Client{
+		BinaryPath: os.Args[0],
+		Region:     "us-east-1",
+		Env: []string{
+			"IATK_TEST_HELPER=1",
+			"IATK_TEST_EXPECT_METHOD=" + method,
+			"IATK_TEST_EXPECT_PARAMS=" + params,
+			"IATK_TEST_RESPONSE=" + response,
+		},
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Run("result", func(t *testing.T) {
+		c := helperClient(
+			"test_harness.eventbridge.poll_events",
+			`{"ListenerId":"listener","Profile":"test","Region":"us-east-1"}`,
+			`{"jsonrpc":"2.0","id":"$ID","result":{"output":["event"]}}`,
+		)
+
+		out, err := c.PollEvents(context.Background(), PollEventsParams{ListenerId: "listener", Profile: "test"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"event"}, out)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := helperClient(
+			"get_physical_id",
+			`{"LogicalResourceId":"Queue","Region":"eu-west-1","StackName":"stack"}`,
+			`{"jsonrpc":"2.0","id":"$ID","error":{"code":12,"message":"not found","data":{"service":"CloudFormation","retryable":false}}}`,
+		)
+
+		_, err := c.GetPhysicalId(context.Background(), GetPhysicalIdParams{LogicalResourceId: "Queue", StackName: "stack", Region: "eu-west-1"})
+		var rpcErr *Error
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, 12, rpcErr.Code)
+		assert.Equal(t, "not found", rpcErr.Message)
+		assert.Equal(t, "CloudFormation", rpcErr.Data.Service)
+	})
+	t.Run("no response", func(t *testing.T) {
+		c := helperClient(
+			"get_physical_id",
+			`{"LogicalResourceId":"Queue","Region":"us-east-1","StackName":"stack"}`,
+			`{"jsonrpc":"2.0","id":"another","result":{"output":"queue"}}`,
+		)
+
+		_, err := c.GetPhysicalId(context.Background(), GetPhysicalIdParams{LogicalResourceId: "Queue", StackName: "stack"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no response to request")
+	})
+}
@@ -11,8 +11,9 @@ from setuptools.command.build import build
 from setuptools.command.editable_wheel import editable_wheel
 from setuptools.command.sdist import sdist
 
-def build_and_install_iatk(packages: List[str]) -> None:
-    cmd = ['go', 'build', '-C', './src/iatk_src', '-o', '../iatk_service/', './cmd/iatk']
+def build_and_install_iatk(packages: List[str], version: str) -> None:
+    ldflags = f"-X iatk/internal/pkg/version.Version={version}"
+    cmd = ['go', 'build', '-C', './src/iatk_src', '-ldflags', ldflags, '-o', '../iatk_service/', './cmd/iatk']
     if not os.getenv("IATK_SKIP_BUILD_BINARY"):
         out = call(cmd)
         if out != 0:
@@ -30,12 +31,12 @@ def build_and_install_iatk(packages: List[str]) -> None:
     
 class Build(build):
     def run(self) -> None:
-        build_and_install_iatk(self.distribution.packages)
+        build_and_install_iatk(self.distribution.packages, self.distribution.get_version())
         super().run()
 
 class EditableWheel(editable_wheel):
     def run(self) -> None:
-        build_and_install_iatk(self.distribution.packages)
+        build_and_install_iatk(self.distribution.packages, self.distribution.get_version())
         super().run()
 
 class Sdist(sdist):
@@ -4,6 +4,7 @@
 from subprocess import Popen, PIPE
 from datetime import datetime
 import pathlib
+import os
 import json
 import logging
 from dataclasses import dataclass
@@ -27,14 +28,32 @@ from .add_eb_listener import (
 )
 from .remove_listeners import (
     RemoveListenersOutput,
+    RemoveListeners_Result,
     RemoveListenersParams,
     RemoveListeners_TagFilter,
 )
+from .gc import (
+    GcOutput,
+    GcParams,
+    Gc_Result,
+)
+from .test_harnesses import (
+    DescribeTestHarnessOutput,
+    DescribeTestHarnessParams,
+    ListTestHarnessesOutput,
+    ListTestHarnessesParams,
+    TestHarness_Description,
+)
 from .poll_events import (
     PollEventsOutput,
     PollEventsParams,
     WaitUntilEventMatchedParams,
 )
+from .eb_pattern import (
+    TestEventPatternOutput,
+    TestEventPatternParams,
+    TestEventPattern_Result,
+)
 from .get_trace_tree import (
     GetTraceTreeOutput,
     GetTraceTreeParams
@@ -48,6 +67,13 @@ from .generate_mock_event import (
     GenerateMockEventOutput,
     GenerateMockEventParams,
 )
+from .introspection import (
+    DiscoverOutput,
+    GetCapabilitiesOutput,
+    GetVersionOutput,
+    IntrospectionParams,
+)
+from .rpc import RpcClient
 from .jsonrpc import Payload
 
 if TYPE_CHECKING:
@@ -60,17 +86,56 @@ __all__ = [
     "GetStackOutputsOutput", 
     "AddEbListenerOutput", 
     "RemoveListenersOutput",
+    "RemoveListeners_Result",
     "RemoveListeners_TagFilter",
+    "GcOutput",
+    "Gc_Result",
+    "ListTestHarnessesOutput",
+    "DescribeTestHarnessOutput",
+    "TestHarness_Description",
     "PollEventsOutput",
+    "TestEventPatternOutput",
+    "TestEventPattern_Result",
     "GetTraceTreeOutput",
     "GenerateBareboneEventOutput",
     "GenerateMockEventOutput",
+    "GetVersionOutput",
+    "GetCapabilitiesOutput",
+    "DiscoverOutput",
 ]
 
 LOG = logging.getLogger(__name__)
 iatk_service_logger = logging.getLogger("iatk.service")
 
 
+_SERVICE_LOG_LEVELS = {
+    "DEBUG": logging.DEBUG,
+    "INFO": logging.INFO,
+    "WARN": logging.WARNING,
+    "ERROR": logging.ERROR,
+}
+
+
+def _log_service_line(line: str):
+    """
+    forwards a log line of the iatk binary to the iatk.service logger, at its level and
+    with its fields (request_id, method, listener_id, ...) as extra attributes of the record
+    """
+    try:
+        record = json.loads(line)
+    except ValueError:
+        iatk_service_logger.debug(line)
+        return
+    if not isinstance(record, dict):
+        iatk_service_logger.debug(line)
+        return
+    level = _SERVICE_LOG_LEVELS.get(str(record.pop("level", "")).upper(), logging.DEBUG)
+    record.pop("time", None)
+    message = record.pop("msg", "")
+    fields = " ".join(f"{k}={v}" for k, v in record.items())
+    iatk_service_logger.log(level, f"{message} {fields}".strip(), extra={"iatk": record})
+
+
 def _log_duration(func):
     @wraps(func)
     def wrapper(*args, **kwargs):
@@ -83,10 +148,14 @@ def _log_duration(func):
 
 
 class IatkException(Exception):
-    def __init__(self, message, error_code) -> None:
+    def __init__(self, message, error_code, data=None) -> None:
         super().__init__(message)
 
         self.error_code = error_code
+        # details of the failed AWS call (service, operation, error_code, request_id, http_status_code, retryable, attempts)
+        # or, for an internal error, the stack trace of the panic and the path of its crash report, if any
+        # or, for remove_listeners and gc failing to destroy some test harnesses, the outcome for each of them (report)
+        self.data = data
 
 class RetryableException(Exception):
     def __init__(self, message) -> None:
@@ -103,14 +172,69 @@ class AwsIatk:
         AWS Region used to interact with AWS
     profile: str, optional
         AWS Profile used to communicate with AWS resources
+    endpoint_url: str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator
+    endpoint_urls: Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS or EventBridge.
+        Take precedence over endpoint_url
+    role_arn: str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account
+    external_id: str, optional
+        External ID the trust policy of the role to assume may require
+    role_session_name: str, optional
+        Name of the session of the role to assume, aws-iatk by default
+    duration_seconds: int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900
+    retry_mode: str, optional
+        How failed AWS calls are retried: standard or adaptive, which also slows down calls to throttled services.
+        Defaults to standard
+    max_attempts: int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3
+    max_backoff_seconds: int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20
     """
     region: Optional[str] = None
     profile: Optional[str] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    role_arn: Optional[str] = None
+    external_id: Optional[str] = None
+    role_session_name: Optional[str] = None
+    duration_seconds: Optional[int] = None
+    retry_mode: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
 
     _iatk_binary_path = (
         pathlib.Path(__file__).parent.parent.joinpath("iatk_service", "iatk").absolute()
     )
 
+    @property
+    def rpc(self) -> RpcClient:
+        """
+        Client of the RPC methods of iatk, with types generated from their specs
+
+        Methods taking an AWS Region, Profile, endpoint URLs, role and retry options use the ones of this instance unless set in their params
+        """
+        return RpcClient(self._call_rpc_method, self.region, self.profile, **self._aws_params())
+
+    def _aws_params(self) -> dict:
+        return {
+            "endpoint_url": self.endpoint_url,
+            "endpoint_urls": self.endpoint_urls,
+            "role_arn": self.role_arn,
+            "external_id": self.external_id,
+            "role_session_name": self.role_session_name,
+            "duration_seconds": self.duration_seconds,
+            "retry_mode": self.retry_mode,
+            "max_attempts": self.max_attempts,
+            "max_backoff_seconds": self.max_backoff_seconds,
+        }
+
+    def _call_rpc_method(self, method: str, params: dict):
+        response = self._invoke_iatk(Payload(method, params))
+        return response["result"]["output"]
+
     def get_physical_id_from_stack(
         self, logical_resource_id: str, stack_name: str
     ) -> PhysicalIdFromStackOutput:
@@ -139,7 +263,7 @@ class AwsIatk:
             When failed to fetch Physical Id
         """
         params = PhysicalIdFromStackParams(logical_resource_id, stack_name)
-        payload = params.to_payload(self.region, self.profile)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload)
         output = PhysicalIdFromStackOutput(response)
         LOG.debug(f"Physical id: {output.physical_id}, Logical id: {params.logical_resource_id}")
@@ -171,13 +295,22 @@ class AwsIatk:
             When failed to fetch Stack Outputs
         """
         params = GetStackOutputsParams(stack_name, output_names)
-        payload = params.to_payload(self.region, self.profile)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload)
         output = GetStackOutputsOutput(response)
         LOG.debug(f"Output: {output}")
         return output
 
-    def add_listener(self, event_bus_name: str, rule_name: str, target_id: Optional[str] = None, tags: Optional[Dict[str, str]] = None) -> AddEbListenerOutput:
+    def add_listener(
+        self,
+        event_bus_name: str,
+        rule_name: Optional[str] = None,
+        target_id: Optional[str] = None,
+        tags: Optional[Dict[str, str]] = None,
+        ttl_seconds: Optional[int] = None,
+        event_pattern: Optional[str] = None,
+        event_pattern_refinements: Optional[List[str]] = None,
+    ) -> AddEbListenerOutput:
         """
         Add Listener Resource to an AWS Event Bridge Bus to enable testing
 
@@ -201,12 +334,21 @@ class AwsIatk:
         ----------
         event_bus_name : str
             Name of the AWS Event Bus
-        rule_name : str
-            Name of a Rule on the EventBus to replicate
+        rule_name : str, optional
+            Name of a Rule on the EventBus to replicate. Required if no event_pattern is given
         target_id : str, optional
             Target Id on the given rule to replicate
         tags : Dict[str, str], optional
             A key-value pair associated EventBridge rule.
+        ttl_seconds : int, optional
+            Time-to-live of the listener in seconds. Once it has passed, the listener is destroyed
+            by the next gc, e.g. if the test run crashed before removing it
+        event_pattern : str, optional
+            Event pattern of the listener, overriding the one of the Rule, e.g. to listen for
+            events that no Rule routes yet
+        event_pattern_refinements : List[str], optional
+            Event patterns AND-merged with the event pattern of the listener, e.g. to only listen
+            for some of the events the Rule matches
         
         Returns
         -------
@@ -218,14 +360,16 @@ class AwsIatk:
         IatkException
             When failed to add listener
         """
-        params = AddEbListenerParams(event_bus_name, rule_name, target_id, tags)
-        payload = params.to_payload(self.region, self.profile)
+        params = AddEbListenerParams(
+            event_bus_name, rule_name, target_id, tags, ttl_seconds, event_pattern, event_pattern_refinements
+        )
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload)
         output = AddEbListenerOutput(response)
         LOG.debug(f"Output: {output}")
         return output
 
-    def remove_listeners(self, ids: Optional[List[str]] = None, tag_filters: Optional[List[RemoveListeners_TagFilter]] = None) -> RemoveListenersOutput:
+    def remove_listeners(self, ids: Optional[List[str]] = None, tag_filters: Optional[List[RemoveListeners_TagFilter]] = None, dry_run: bool = False) -> RemoveListenersOutput:
         """
         Remove Listener Resource(s) from an AWS Event Bridge Bus
 
@@ -248,34 +392,199 @@ class AwsIatk:
             List of Listener Ids to remove, one of ids and tag_filters must be supplied
         tag_filters : List[RemoveListeners_TagFilter], optional
             List of RemoveListeners_TagFilter, one of ids and tag_filters must be supplied
+        dry_run : bool, optional
+            Whether to only report the Listeners and Resources that would be removed, without removing them.
+            Defaults to False
         
         Returns
         -------
         RemoveListenersOutput
-            Data Class that holds the Listener(s) that were removed
+            Data Class that holds the outcome of removing each Listener
 
         Raises
         ------
         IatkException
-            When failed to remove listener(s)
+            When failed to remove listener(s), with the outcome of removing each Listener in data["report"]
         """
-        params = RemoveListenersParams(ids, tag_filters)
-        payload = params.to_payload(self.region, self.profile)
+        params = RemoveListenersParams(ids, tag_filters, dry_run)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload)
         output = RemoveListenersOutput(response)
         LOG.debug(f"Output: {output}")
         return output
 
+    def gc(self, max_age_seconds: Optional[int] = None, tag_filters: Optional[List[RemoveListeners_TagFilter]] = None, dry_run: bool = False) -> GcOutput:
+        """
+        Destroy the test harnesses older than max_age_seconds or past the ttl_seconds given to
+        add_listener, e.g. left behind by crashed test runs, and those missing some of their
+        Resources, e.g. a rule whose queue is gone
+
+        IAM Permissions Needed
+        ----------------------
+        tag:GetResources
+
+        sqs:DeleteQueue
+        sqs:GetQueueUrl
+
+        events:DescribeRule
+        events:DeleteRule
+        events:RemoveTargets
+        events:ListTargetsByRule
+
+        Parameters
+        ----------
+        max_age_seconds : int, optional
+            Age in seconds over which test harnesses are destroyed. If not supplied, only the
+            expired and orphaned ones are
+        tag_filters : List[RemoveListeners_TagFilter], optional
+            List of RemoveListeners_TagFilter matching the test harnesses to consider, all iatk-created ones if not supplied
+        dry_run : bool, optional
+            Whether to only report the test harnesses that would be destroyed, without destroying them.
+            Defaults to False
+
+        Returns
+        -------
+        GcOutput
+            Data Class that holds the outcome of collecting each test harness
+
+        Raises
+        ------
+        IatkException
+            When failed to destroy test harness(es), with the outcome of collecting each test harness in data["report"]
+        """
+        params = GcParams(max_age_seconds, tag_filters, dry_run)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
+        response = self._invoke_iatk(payload)
+        output = GcOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
+    def list_test_harnesses(self, tag_filters: Optional[List[RemoveListeners_TagFilter]] = None) -> ListTestHarnessesOutput:
+        """
+        List the test harnesses, e.g. to find those left behind by test runs or to check the
+        events waiting in the queue of a listener
+
+        IAM Permissions Needed
+        ----------------------
+        tag:GetResources
+
+        sqs:GetQueueUrl
+        sqs:GetQueueAttributes
+
+        events:DescribeRule
+
+        Parameters
+        ----------
+        tag_filters : List[RemoveListeners_TagFilter], optional
+            List of RemoveListeners_TagFilter matching the test harnesses to list, all iatk-created ones if not supplied
+
+        Returns
+        -------
+        ListTestHarnessesOutput
+            Data Class that holds the description of each test harness. Test harnesses that
+            could not be described are listed with the error
+
+        Raises
+        ------
+        IatkException
+            When failed to find the test harnesses
+        """
+        params = ListTestHarnessesParams(tag_filters)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
+        response = self._invoke_iatk(payload)
+        output = ListTestHarnessesOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
+    def describe_test_harness(self, id: str) -> DescribeTestHarnessOutput:
+        """
+        Describe a test harness, e.g. the Resources of a listener and the events waiting in its queue
+
+        IAM Permissions Needed
+        ----------------------
+        tag:GetResources
+
+        sqs:GetQueueUrl
+        sqs:GetQueueAttributes
+
+        events:DescribeRule
+
+        Parameters
+        ----------
+        id : str
+            Id of the test harness
+
+        Returns
+        -------
+        DescribeTestHarnessOutput
+            Data Class that holds the description of the test harness
+
+        Raises
+        ------
+        IatkException
+            When the test harness is not found (error_code 12), or failed to describe it
+        """
+        params = DescribeTestHarnessParams(id)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
+        response = self._invoke_iatk(payload)
+        output = DescribeTestHarnessOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
+    def test_event_pattern(
+        self,
+        events: Optional[List[str]] = None,
+        event_pattern: Optional[str] = None,
+        rule_name: Optional[str] = None,
+        event_bus_name: Optional[str] = None,
+    ) -> TestEventPatternOutput:
+        """
+        Match events against an EventBridge event pattern locally, e.g. to check a pattern
+        before adding a listener with it. The event pattern is either given, or the one of a Rule
+
+        IAM Permissions Needed
+        ----------------------
+        events:DescribeRule (only if rule_name is given)
+
+        Parameters
+        ----------
+        events : List[str], optional
+            Events to match against the event pattern, as JSON strings. If not supplied,
+            the event pattern is only validated
+        event_pattern : str, optional
+            Event pattern to test. Required if no rule_name is given
+        rule_name : str, optional
+            Name of a Rule whose event pattern to test
+        event_bus_name : str, optional
+            Name of the AWS Event Bus of the Rule, default if not supplied
+
+        Returns
+        -------
+        TestEventPatternOutput
+            Data Class that holds the outcome of matching each event
+
+        Raises
+        ------
+        IatkException
+            When the event pattern is invalid, or failed to get the Rule
+        """
+        params = TestEventPatternParams(events, event_pattern, rule_name, event_bus_name)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
+        response = self._invoke_iatk(payload)
+        output = TestEventPatternOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
     def _poll_events(self, params: PollEventsParams, caller: dict = None) -> PollEventsOutput:
         """
         underlying implementation for poll_events and wait_until_event_matched
         """
-        payload = params.to_payload(self.region, self.profile)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload, caller)
         output = PollEventsOutput(response)
         return output
 
-    def poll_events(self, listener_id: str, wait_time_seconds: int, max_number_of_messages: int) -> PollEventsOutput:
+    def poll_events(self, listener_id: str, wait_time_seconds: int, max_number_of_messages: int, event_pattern: Optional[str] = None) -> PollEventsOutput:
         """
         Poll Events from a specific Listener
 
@@ -285,6 +594,7 @@ class AwsIatk:
         sqs:ListQueueTags
         sqs:ReceiveMessage
         sqs:DeleteMessage
+        sqs:ChangeMessageVisibility
         sqs:GetQueueAttributes
 
         events:DescribeRule
@@ -297,6 +607,9 @@ class AwsIatk:
             Time in seconds to wait for polling
         max_number_of_messages : int
             Max number of messages to poll
+        event_pattern : str, optional
+            Event pattern the Events returned must match. Events polled that do not match
+            it are left on the queue, e.g. for other assertions sharing the Listener
         
         Returns
         -------
@@ -308,12 +621,12 @@ class AwsIatk:
         IatkException
             When failed to Poll Events
         """
-        params = PollEventsParams(listener_id, wait_time_seconds, max_number_of_messages)
+        params = PollEventsParams(listener_id, wait_time_seconds, max_number_of_messages, event_pattern)
         output = self._poll_events(params)
         LOG.debug(f"Output: {output}")
         return output
 
-    def wait_until_event_matched(self, listener_id: str, assertion_fn: Callable[[str], None], timeout_seconds: int = 30) -> bool:
+    def wait_until_event_matched(self, listener_id: str, assertion_fn: Callable[[str], None], timeout_seconds: int = 30, event_pattern: Optional[str] = None) -> bool:
         """
         Poll Events on a given Listener until a match is found or timeout met.
 
@@ -323,6 +636,7 @@ class AwsIatk:
         sqs:ListQueueTags
         sqs:ReceiveMessage
         sqs:DeleteMessage
+        sqs:ChangeMessageVisibility
         sqs:GetQueueAttributes
 
         events:DescribeRule
@@ -335,6 +649,9 @@ class AwsIatk:
             Callable function that has an assertion and raises an AssertionError if it fails
         timeout_seconds : int
             Timeout (in seconds) to stop the polling
+        event_pattern : str, optional
+            Event pattern the Events must match to be polled. Events that do not match it are
+            left on the queue, so that several assertions can share a Listener
         
         Returns
         -------
@@ -346,7 +663,7 @@ class AwsIatk:
         IatkException
             When failed to Poll Events
         """
-        params = WaitUntilEventMatchedParams(listener_id, assertion_fn, timeout_seconds)
+        params = WaitUntilEventMatchedParams(listener_id, assertion_fn, timeout_seconds, event_pattern)
         start = datetime.now()
         elapsed = lambda _: (datetime.now() - start).total_seconds()
         while elapsed(None) < params.timeout_seconds:
@@ -369,7 +686,7 @@ class AwsIatk:
         """
         underlying implementation for get_trace_tree and retry_get_trace_tree_until
         """
-        payload = params.to_payload(self.region, self.profile)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload, caller)
         output = GetTraceTreeOutput(response)
         return output
@@ -408,7 +725,7 @@ class AwsIatk:
     def _generate_barebone_event(
         self, params: GenerateBareboneEventParams,
     ) -> GenerateBareboneEventOutput:
-        payload = params.to_payload(self.region, self.profile)
+        payload = params.to_payload(self.region, self.profile, **self._aws_params())
         response = self._invoke_iatk(payload)
         output = GenerateBareboneEventOutput(response)
         return output
@@ -544,6 +861,65 @@ class AwsIatk:
             raise IatkException("event is empty, make sure function returns a valid event", 404)
         return generated_event
         
+    def get_version(self) -> GetVersionOutput:
+        """
+        Fetch the release and build details of the iatk binary
+
+        Returns
+        -------
+        GetVersionOutput
+            Data Class that holds the release, Go release, platform and revision of the binary
+
+        Raises
+        ------
+        IatkException
+            When failed to fetch the version
+        """
+        payload = IntrospectionParams("iatk.version").to_payload()
+        response = self._invoke_iatk(payload)
+        output = GetVersionOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
+    def get_capabilities(self) -> GetCapabilitiesOutput:
+        """
+        Fetch the RPC methods and protocol features the iatk binary supports, to detect them
+        instead of pinning a binary version
+
+        Returns
+        -------
+        GetCapabilitiesOutput
+            Data Class that holds the release, methods and features of the binary
+
+        Raises
+        ------
+        IatkException
+            When failed to fetch the capabilities
+        """
+        payload = IntrospectionParams("iatk.capabilities").to_payload()
+        response = self._invoke_iatk(payload)
+        output = GetCapabilitiesOutput(response)
+        LOG.debug(f"Output: {output}")
+        return output
+
+    def discover(self) -> DiscoverOutput:
+        """
+        Fetch the OpenRPC document describing the params and result of every RPC method of the iatk binary
+
+        Returns
+        -------
+        DiscoverOutput
+            Data Class that holds the OpenRPC document
+
+        Raises
+        ------
+        IatkException
+            When failed to fetch the document
+        """
+        payload = IntrospectionParams("rpc.discover").to_payload()
+        response = self._invoke_iatk(payload)
+        return DiscoverOutput(response)
+
     def patch_aws_client(self, client: "boto3.client", sampled = 1) -> "boto3.client":
         """
         Patches boto3 client to register event to include generated x-ray trace id and sampling rule as part of request header before invoke/execution
@@ -580,23 +956,37 @@ class AwsIatk:
         LOG.debug("payload: %s", input_data)
         stdout_data = self._popen_iatk(input_data)
         jsonrpc_data = stdout_data.decode("utf-8")
-        data_dict = json.loads(jsonrpc_data.strip())
+        # Progress notifications, if any, come one per line ahead of the response
+        lines = jsonrpc_data.strip().splitlines()
+        for line in lines[:-1]:
+            notification = json.loads(line)
+            if notification.get("method") == "$/progress":
+                iatk_service_logger.debug("progress: %s", notification.get("params", {}))
+        try:
+            data_dict = json.loads(lines[-1] if lines else jsonrpc_data)
+        except json.JSONDecodeError:
+            raise IatkException(message=f"iatk returned an invalid response: {jsonrpc_data!r}", error_code=-32603)
 
         # Error is only returned in the response if one happened. Otherwise it is omitted
         if data_dict.get("error", None):
             message = data_dict.get("error", {}).get("message", "")
-            error_code = data_dict.get("error", {}).get("Code", 0)
-            raise IatkException(message=message, error_code=error_code)
+            error_code = data_dict.get("error", {}).get("code", 0)
+            data = data_dict.get("error", {}).get("data", None)
+            raise IatkException(message=message, error_code=error_code, data=data)
         
         return data_dict
 
     def _popen_iatk(self, input: bytes, env_vars: Optional[dict]=None) -> bytes:
         LOG.debug("calling iatk rpc with input %s", input)
-        p = Popen([self._iatk_binary_path], stdout=PIPE, stdin=PIPE, stderr=PIPE)
+        env = dict(os.environ)
+        env.setdefault("IATK_LOG_FORMAT", "json")
+        if env_vars:
+            env.update(env_vars)
+        p = Popen([self._iatk_binary_path], stdout=PIPE, stdin=PIPE, stderr=PIPE, env=env)
 
         out, err = p.communicate(input=input)
         for line in err.splitlines():
-            iatk_service_logger.debug(line.decode())
+            _log_service_line(line.decode())
         return out
 
     def _raise_error_if_returned(self, output):
@@ -606,8 +996,9 @@ class AwsIatk:
         # Error is only returned in the response if one happened. Otherwise it is omitted
         if data_dict.get("error", None):
             message = data_dict.get("error", {}).get("message", "")
-            error_code = data_dict.get("error", {}).get("Code", 0)
-            raise IatkException(message=message, error_code=error_code)
+            error_code = data_dict.get("error", {}).get("code", 0)
+            data = data_dict.get("error", {}).get("data", None)
+            raise IatkException(message=message, error_code=error_code, data=data)
 
         
     def retry_get_trace_tree_until(self, tracing_header: str, assertion_fn: Callable[[GetTraceTreeOutput], None], fetch_child_traces: Optional[bool] = False, timeout_seconds: int = 30):
@@ -76,29 +76,45 @@ class AddEbListenerParams:
     ----------
     event_bus_name : str
         Name of the AWS Event Bus
-    rule_name : str
-        Name of a Rule on the EventBus to replicate
+    rule_name : str, optional
+        Name of a Rule on the EventBus to replicate, required if no event_pattern is given
     target_id : str, optional
         Target Id on the given rule to replicate
     tags : Dict[str, str], optional
         A key-value pair associated EventBridge rule.
+    ttl_seconds : int, optional
+        Time-to-live of the listener in seconds, after which AwsIatk.gc destroys it
+    event_pattern : str, optional
+        Event pattern of the listener, overriding the one of the Rule
+    event_pattern_refinements : List[str], optional
+        Event patterns AND-merged with the event pattern of the listener
     """
     event_bus_name: str
-    rule_name: str
+    rule_name: Optional[str] = None
     target_id: Optional[str] = None
     tags: Optional[Dict[str, str]] = None
+    ttl_seconds: Optional[int] = None
+    event_pattern: Optional[str] = None
+    event_pattern_refinements: Optional[List[str]] = None
 
     _rpc_method: str = "test_harness.eventbridge.add_listener"
 
     def to_dict(self) -> dict:
         params = {}
         params["EventBusName"] = self.event_bus_name
-        params["RuleName"] = self.rule_name
+        if self.rule_name:
+            params["RuleName"] = self.rule_name
         if self.target_id:
             params["TargetId"] = self.target_id
         if self.tags:
             params["Tags"] = self.tags
+        if self.ttl_seconds:
+            params["TtlSeconds"] = self.ttl_seconds
+        if self.event_pattern:
+            params["EventPattern"] = self.event_pattern
+        if self.event_pattern_refinements:
+            params["EventPatternRefinements"] = self.event_pattern_refinements
         return params
 
-    def to_payload(self, region, profile) -> Payload:
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params) -> Payload:
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
@@ -0,0 +1,96 @@
+# Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+import logging
+from dataclasses import dataclass
+from typing import List, Optional
+
+from .jsonrpc import Payload
+
+
+LOG = logging.getLogger(__name__)
+
+
+@dataclass
+class TestEventPattern_Result:
+    """
+    Outcome of matching one of the events given to AwsIatk.test_event_pattern
+
+    Parameters
+    ----------
+    match : bool
+        Whether the event matches the event pattern
+    error : str, optional
+        Reason the event could not be matched, e.g. it is not a JSON object
+    """
+    __test__ = False
+
+    match: bool
+    error: Optional[str]
+
+    def __init__(self, jsonrpc_data_dict: dict) -> None:
+        self.match = jsonrpc_data_dict.get("Match", False)
+        self.error = jsonrpc_data_dict.get("Error", None)
+
+
+@dataclass
+class TestEventPatternOutput:
+    """
+    AwsIatk.test_event_pattern Output
+
+    Parameters
+    ----------
+    event_pattern : str
+        Event pattern the events were matched against
+    results : List[TestEventPattern_Result]
+        Outcome of matching each event, in order
+    """
+    __test__ = False
+
+    event_pattern: str
+    results: List[TestEventPattern_Result]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", {}) or {}
+        self.event_pattern = output.get("EventPattern", "")
+        self.results = [TestEventPattern_Result(r) for r in output.get("Results", None) or []]
+
+
+@dataclass
+class TestEventPatternParams:
+    """
+    AwsIatk.test_event_pattern parameters
+
+    Parameters
+    ----------
+    events : List[str], optional
+        Events to match against the event pattern, as JSON strings
+    event_pattern : str, optional
+        Event pattern to test
+    rule_name : str, optional
+        Name of a Rule whose event pattern to test
+    event_bus_name : str, optional
+        Name of the AWS Event Bus of the Rule
+    """
+    __test__ = False
+
+    events: Optional[List[str]] = None
+    event_pattern: Optional[str] = None
+    rule_name: Optional[str] = None
+    event_bus_name: Optional[str] = None
+    _rpc_method: str = "test_harness.eventbridge.test_pattern"
+
+    def to_dict(self) -> dict:
+        params = {}
+        if self.events:
+            params["Events"] = self.events
+        if self.event_pattern:
+            params["EventPattern"] = self.event_pattern
+        if self.rule_name:
+            params["RuleName"] = self.rule_name
+        if self.event_bus_name:
+            params["EventBusName"] = self.event_bus_name
+        return params
+
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
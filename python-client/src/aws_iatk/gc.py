@@ -0,0 +1,115 @@
+# Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+import logging
+from dataclasses import dataclass
+from typing import List, Optional
+
+from .jsonrpc import Payload
+from .remove_listeners import RemoveListeners_TagFilter
+
+
+LOG = logging.getLogger(__name__)
+
+
+@dataclass
+class Gc_Result:
+    """
+    Outcome of collecting one of the test harnesses found by AwsIatk.gc
+
+    Parameters
+    ----------
+    id : str
+        Id of the test harness
+    type : str
+        Type of the test harness, e.g. EventBridge.Listener
+    created : str
+        Creation time of the test harness, in RFC3339 format
+    reason : str
+        Expired, TTLExpired if its time-to-live has passed, or Orphaned if some of its Resources are gone
+    status : str
+        Destroyed, AlreadyAbsent, Failed or, on a dry run, WouldDestroy
+    error : str, optional
+        Reason the test harness could not be destroyed, if it failed
+    leftover_arns : List[str]
+        Arns of the Resources of the test harness left behind, if it failed
+    component_arns : List[str]
+        Arns of the Resources of the test harness that would be destroyed, on a dry run
+    """
+    id: str
+    type: str
+    created: str
+    reason: str
+    status: str
+    error: Optional[str]
+    leftover_arns: List[str]
+    component_arns: List[str]
+
+    def __init__(self, jsonrpc_data_dict: dict) -> None:
+        self.id = jsonrpc_data_dict.get("Id", "")
+        self.type = jsonrpc_data_dict.get("Type", "")
+        self.created = jsonrpc_data_dict.get("Created", "")
+        self.reason = jsonrpc_data_dict.get("Reason", "")
+        self.status = jsonrpc_data_dict.get("Status", "")
+        self.error = jsonrpc_data_dict.get("Error", None)
+        self.leftover_arns = jsonrpc_data_dict.get("LeftoverArns", [])
+        self.component_arns = jsonrpc_data_dict.get("ComponentArns", [])
+
+
+@dataclass
+class GcOutput:
+    """
+    AwsIatk.gc Output
+
+    Parameters
+    ----------
+    results : List[Gc_Result]
+        Outcome of collecting each test harness found
+    """
+    results: List[Gc_Result]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", [])
+        self.results = [Gc_Result(result) for result in output or []]
+
+    def failed(self) -> List[Gc_Result]:
+        """
+        Results of the test harnesses that could not be destroyed
+        """
+        return [result for result in self.results if result.status == "Failed"]
+
+
+@dataclass
+class GcParams:
+    """
+    AwsIatk.gc parameters
+
+    Parameters
+    ----------
+    max_age_seconds : int, optional
+        Age in seconds over which test harnesses are destroyed
+    tag_filters : List[RemoveListeners_TagFilter], optional
+        List of RemoveListeners_TagFilter matching the test harnesses to consider
+    dry_run : bool, optional
+        Whether to only report what would be destroyed, without destroying anything
+    """
+
+    max_age_seconds: Optional[int] = None
+    tag_filters: Optional[List[RemoveListeners_TagFilter]] = None
+    dry_run: bool = False
+    _rpc_method: str = "test_harness.gc"
+
+    def to_dict(self) -> dict:
+        params = {}
+        if self.max_age_seconds:
+            params["MaxAgeSeconds"] = self.max_age_seconds
+        if self.tag_filters:
+            params["TagFilters"] = [
+                tag_filter.to_dict() for tag_filter in self.tag_filters
+            ]
+        if self.dry_run:
+            params["DryRun"] = self.dry_run
+        return params
+
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
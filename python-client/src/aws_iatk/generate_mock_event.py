@@ -69,8 +69,8 @@ class GenerateBareboneEventParams:
             params["SkipOptional"] = self.skip_optional
         return params
 
-    def to_payload(self, region, profile) -> Payload:
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params) -> Payload:
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
 
 
 # NOTE (hawflau): client method output
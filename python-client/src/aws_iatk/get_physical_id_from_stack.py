@@ -48,6 +48,6 @@ class PhysicalIdFromStackParams:
             "StackName": self.stack_name,
         }
 
-    def to_payload(self, region, profile) -> Payload:
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params) -> Payload:
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
         
@@ -50,5 +50,5 @@ class GetStackOutputsParams:
             "OutputNames": self.output_names,
         }
 
-    def to_payload(self, region, profile) -> Payload:
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params) -> Payload:
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
@@ -50,5 +50,5 @@ class GetTraceTreeParams:
             "FetchChildTraces": self.fetch_child_traces
         }
 
-    def to_payload(self, region, profile) -> Payload:
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params) -> Payload:
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
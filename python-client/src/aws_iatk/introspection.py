@@ -0,0 +1,117 @@
+# Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+import logging
+from dataclasses import dataclass
+from typing import List, Optional
+
+from .jsonrpc import Payload
+
+
+LOG = logging.getLogger(__name__)
+
+
+@dataclass
+class GetVersionOutput:
+    """
+    AwsIatk.get_version output
+
+    Parameters
+    ----------
+    version : str
+        Release of the iatk binary
+    go_version : str
+        Go release the binary was built with
+    platform : str
+        Operating system and architecture the binary was built for, e.g. linux/amd64
+    revision : str, optional
+        VCS revision the binary was built from, if known
+    """
+    version: str
+    go_version: str
+    platform: str
+    revision: Optional[str]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", {})
+        self.version = output.get("Version")
+        self.go_version = output.get("GoVersion")
+        self.platform = output.get("Platform")
+        self.revision = output.get("Revision")
+
+
+@dataclass
+class GetCapabilitiesOutput:
+    """
+    AwsIatk.get_capabilities output
+
+    Parameters
+    ----------
+    version : str
+        Release of the iatk binary
+    methods : List[str]
+        Names of the RPC methods the binary supports
+    features : List[str]
+        Optional parts of the protocol the binary supports, e.g. batch, progress
+    """
+    version: str
+    methods: List[str]
+    features: List[str]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", {})
+        self.version = output.get("Version")
+        self.methods = output.get("Methods", [])
+        self.features = output.get("Features", [])
+
+    def supports_method(self, method: str) -> bool:
+        """
+        whether the binary supports the RPC method of the given name
+        """
+        return method in self.methods
+
+    def supports_feature(self, feature: str) -> bool:
+        """
+        whether the binary supports the protocol feature of the given name
+        """
+        return feature in self.features
+
+
+@dataclass
+class DiscoverOutput:
+    """
+    AwsIatk.discover output, an OpenRPC document (https://spec.open-rpc.org)
+
+    Parameters
+    ----------
+    openrpc : str
+        Version of the OpenRPC specification the document follows
+    info : dict
+        Title and version of the API
+    methods : List[dict]
+        Names, params and result of the RPC methods the binary supports
+    components : dict
+        JSON Schemas of the types shared by methods
+    """
+    openrpc: str
+    info: dict
+    methods: List[dict]
+    components: dict
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", {})
+        self.openrpc = output.get("openrpc")
+        self.info = output.get("info", {})
+        self.methods = output.get("methods", [])
+        self.components = output.get("components", {})
+
+
+@dataclass
+class IntrospectionParams:
+    """
+    Parameters of the introspection methods of AwsIatk, which take none
+    """
+    _rpc_method: str
+
+    def to_payload(self) -> Payload:
+        return Payload(self._rpc_method, {})
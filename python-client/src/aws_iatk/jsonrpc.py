@@ -21,7 +21,20 @@ class Payload:
     _version: str = _version
     _client_version: str = python_version()
 
-    def __init__(self, method: str, params: dict, region: str = None, profile: str = None):
+    # AWS params taken as keyword arguments, with the names of the RPC params
+    _aws_params = {
+        "endpoint_url": "EndpointURL",
+        "endpoint_urls": "EndpointURLs",
+        "role_arn": "RoleArn",
+        "external_id": "ExternalId",
+        "role_session_name": "RoleSessionName",
+        "duration_seconds": "DurationSeconds",
+        "retry_mode": "RetryMode",
+        "max_attempts": "MaxAttempts",
+        "max_backoff_seconds": "MaxBackoffSeconds",
+    }
+
+    def __init__(self, method: str, params: dict, region: str = None, profile: str = None, **aws_params):
         self.id = str(uuid4())
         self.method = method
         self.params = params
@@ -29,6 +42,9 @@ class Payload:
             self.params["Region"] = region
         if profile:
             self.params["Profile"] = profile
+        for name, value in aws_params.items():
+            if value:
+                self.params[self._aws_params[name]] = value
 
     def to_dict(self, caller: dict):
         _dict = {
@@ -41,7 +57,8 @@ class Payload:
                 "version": self._version,
                 "caller": caller["caller"] if caller and caller["caller"] else self.method,
                 "client_version": self._client_version,
-                "dedup_key": caller["dedup_key"] if caller and caller["dedup_key"] else str(uuid4())
+                "dedup_key": caller["dedup_key"] if caller and caller["dedup_key"] else str(uuid4()),
+                "progress": True,
             }
         }
         return _dict
@@ -3,7 +3,7 @@
 
 import logging
 from dataclasses import dataclass
-from typing import List, Callable
+from typing import List, Callable, Optional
 
 from .jsonrpc import Payload
 
@@ -41,10 +41,13 @@ class PollEventsParams:
         Time in seconds to wait for polling
     max_number_of_messages : int
         Max number of messages to poll
+    event_pattern : str, optional
+        Event pattern the events returned must match, the others are left on the queue
     """
     listener_id: str
     wait_time_seconds: int
     max_number_of_messages: int
+    event_pattern: Optional[str] = None
 
     _rpc_method: str = "test_harness.eventbridge.poll_events"
 
@@ -55,10 +58,12 @@ class PollEventsParams:
             params["WaitTimeSeconds"] = self.wait_time_seconds
         if self.max_number_of_messages is not None:
             params["MaxNumberOfMessages"] = self.max_number_of_messages
+        if self.event_pattern:
+            params["EventPattern"] = self.event_pattern
         return params
 
-    def to_payload(self, region, profile):
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
 
 
 @dataclass
@@ -74,6 +79,8 @@ class WaitUntilEventMatchedParams:
         Callable fuction that makes an assertion and raises an AssertionError if it fails
     timeout_seconds : int
         Timeout (in seconds) to stop the polling
+    event_pattern : str, optional
+        Event pattern the events must match to be polled
     """
     assertion_fn: Callable[[str], None]    
     timeout_seconds: int
@@ -85,6 +92,7 @@ class WaitUntilEventMatchedParams:
         listener_id: str,
         assertion_fn: Callable[[str], None],
         timeout_seconds: int = 30,
+        event_pattern: Optional[str] = None,
     ):
         if timeout_seconds <= 0 or timeout_seconds > 999:
             raise InvalidParamException("timeout_seconds must be between 1 and 999")
@@ -96,6 +104,7 @@ class WaitUntilEventMatchedParams:
             listener_id=listener_id,
             wait_time_seconds=3,
             max_number_of_messages=10,
+            event_pattern=event_pattern,
         )
 
 
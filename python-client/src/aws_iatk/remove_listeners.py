@@ -11,6 +11,38 @@ from .jsonrpc import Payload
 LOG = logging.getLogger(__name__)
 
 
+@dataclass
+class RemoveListeners_Result:
+    """
+    Outcome of removing one of the Listeners given to AwsIatk.remove_listeners
+
+    Parameters
+    ----------
+    id : str
+        Id of the Listener
+    status : str
+        Destroyed, AlreadyAbsent, Failed or, on a dry run, WouldDestroy
+    error : str, optional
+        Reason the Listener could not be removed, if it failed
+    leftover_arns : List[str]
+        Arns of the Resources of the Listener left behind, if it failed
+    component_arns : List[str]
+        Arns of the Resources of the Listener that would be removed, on a dry run
+    """
+    id: str
+    status: str
+    error: Optional[str]
+    leftover_arns: List[str]
+    component_arns: List[str]
+
+    def __init__(self, jsonrpc_data_dict: dict) -> None:
+        self.id = jsonrpc_data_dict.get("Id", "")
+        self.status = jsonrpc_data_dict.get("Status", "")
+        self.error = jsonrpc_data_dict.get("Error", None)
+        self.leftover_arns = jsonrpc_data_dict.get("LeftoverArns", [])
+        self.component_arns = jsonrpc_data_dict.get("ComponentArns", [])
+
+
 @dataclass
 class RemoveListenersOutput:
     """
@@ -20,11 +52,28 @@ class RemoveListenersOutput:
     ----------
     message : str
         Message indicates whether or not the remove succeeded.
+    results : List[RemoveListeners_Result]
+        Outcome of removing each of the Listeners, in the order they were given
     """
     message: str
+    results: List[RemoveListeners_Result]
 
     def __init__(self, data_dict: dict) -> None:
-        self.message = data_dict.get("result", {}).get("output", "")
+        output = data_dict.get("result", {}).get("output", [])
+        self.results = [RemoveListeners_Result(result) for result in output or []]
+        failed = self.failed()
+        if failed:
+            self.message = "failed to destroy following listener(s): " + ", ".join(
+                f"{{resource group id: {result.id}, reason: {result.error}}}" for result in failed
+            )
+        else:
+            self.message = "success"
+
+    def failed(self) -> List[RemoveListeners_Result]:
+        """
+        Results of the Listeners that could not be removed
+        """
+        return [result for result in self.results if result.status == "Failed"]
 
 
 @dataclass
@@ -62,10 +111,13 @@ class RemoveListenersParams:
         List of Listener Ids to remove
     tag_filters : List[RemoveListeners_TagFilter], optional
         List of RemoveListeners_TagFilter
+    dry_run : bool, optional
+        Whether to only report what would be removed, without removing anything
     """
 
     ids: Optional[List[str]] = None
     tag_filters: Optional[List[RemoveListeners_TagFilter]] = None
+    dry_run: bool = False
     _rpc_method: str = "test_harness.eventbridge.remove_listeners"
 
     def to_dict(self) -> dict:
@@ -76,7 +128,9 @@ class RemoveListenersParams:
             params["TagFilters"] = [
                 tag_filter.to_dict() for tag_filter in self.tag_filters
             ]
+        if self.dry_run:
+            params["DryRun"] = self.dry_run
         return params
     
-    def to_payload(self, region, profile):
-        return Payload(self._rpc_method, self.to_dict(), region, profile)
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
@@ -0,0 +1,2649 @@
+# Code generated by rpcspecs. DO NOT EDIT.
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Any, Callable, Dict, List, Optional
+
+
+def _to_json(value):
+    if hasattr(value, "to_dict"):
+        return value.to_dict()
+    if isinstance(value, list):
+        return [_to_json(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _to_json(v) for k, v in value.items()}
+    return value
+
+
+def _drop_none(d: dict) -> dict:
+    return {k: v for k, v in d.items() if v is not None}
+
+
+@dataclass
+class GcOutput:
+    """
+    gc.Output
+
+    Parameters
+    ----------
+    created : str
+        Creation time of the test harness, in RFC3339 format.
+    id : str
+    reason : str
+        One of: Expired, TTLExpired, Orphaned.
+    status : str
+        One of: Destroyed, AlreadyAbsent, Failed, WouldDestroy.
+    type : str
+    component_arns : List[str], optional
+        ARNs of the resources of the test harness that would be destroyed, on a dry run.
+    error : str, optional
+        Reason the test harness could not be destroyed, if it failed.
+    leftover_arns : List[str], optional
+        ARNs of the resources of the test harness left behind, if it failed.
+    """
+
+    created: str
+    id: str
+    reason: str
+    status: str
+    type: str
+    component_arns: Optional[List[str]] = None
+    error: Optional[str] = None
+    leftover_arns: Optional[List[str]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Created": _to_json(self.created),
+            "Id": _to_json(self.id),
+            "Reason": _to_json(self.reason),
+            "Status": _to_json(self.status),
+            "Type": _to_json(self.type),
+            "ComponentArns": _to_json(self.component_arns),
+            "Error": _to_json(self.error),
+            "LeftoverArns": _to_json(self.leftover_arns),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GcOutput:
+        return cls(
+            created=data.get("Created"),
+            id=data.get("Id"),
+            reason=data.get("Reason"),
+            status=data.get("Status"),
+            type=data.get("Type"),
+            component_arns=data.get("ComponentArns"),
+            error=data.get("Error"),
+            leftover_arns=data.get("LeftoverArns"),
+        )
+
+
+@dataclass
+class HarnessResource:
+    """
+    harness.Resource
+
+    Parameters
+    ----------
+    arn : str
+    physical_id : str
+    type : str
+    """
+
+    arn: str
+    physical_id: str
+    type: str
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "ARN": _to_json(self.arn),
+            "PhysicalID": _to_json(self.physical_id),
+            "Type": _to_json(self.type),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> HarnessResource:
+        return cls(
+            arn=data.get("ARN"),
+            physical_id=data.get("PhysicalID"),
+            type=data.get("Type"),
+        )
+
+
+@dataclass
+class InventoryOutput:
+    """
+    inventory.Output
+
+    Parameters
+    ----------
+    components : List[HarnessResource]
+        Resources of the test harness that exist.
+    id : str
+    tags : Dict[str, str]
+        Custom tags of the test harness.
+    target : str
+        ARN of the resource under test, e.g. the event bus of a Listener.
+    type : str
+    approximate_queue_depth : int, optional
+        Approximate number of events waiting in the queue of a Listener.
+    created : str, optional
+        Creation time of the test harness, in RFC3339 format.
+    error : str, optional
+        Reason the test harness could not be described, if it failed.
+    """
+
+    components: List[HarnessResource]
+    id: str
+    tags: Dict[str, str]
+    target: str
+    type: str
+    approximate_queue_depth: Optional[int] = None
+    created: Optional[str] = None
+    error: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Components": _to_json(self.components),
+            "Id": _to_json(self.id),
+            "Tags": _to_json(self.tags),
+            "Target": _to_json(self.target),
+            "Type": _to_json(self.type),
+            "ApproximateQueueDepth": _to_json(self.approximate_queue_depth),
+            "Created": _to_json(self.created),
+            "Error": _to_json(self.error),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> InventoryOutput:
+        return cls(
+            components=None if data.get("Components") is None else [None if v is None else HarnessResource.from_dict(v) for v in data.get("Components")],
+            id=data.get("Id"),
+            tags=data.get("Tags"),
+            target=data.get("Target"),
+            type=data.get("Type"),
+            approximate_queue_depth=data.get("ApproximateQueueDepth"),
+            created=data.get("Created"),
+            error=data.get("Error"),
+        )
+
+
+@dataclass
+class ListenerDestroyOutput:
+    """
+    listener.DestroyOutput
+
+    Parameters
+    ----------
+    id : str
+    status : str
+        One of: Destroyed, AlreadyAbsent, Failed, WouldDestroy.
+    component_arns : List[str], optional
+        ARNs of the components of the Listener that would be removed, on a dry run.
+    error : str, optional
+        Reason the Listener could not be removed, if it failed.
+    leftover_arns : List[str], optional
+        ARNs of the components of the Listener left behind, if it failed.
+    """
+
+    id: str
+    status: str
+    component_arns: Optional[List[str]] = None
+    error: Optional[str] = None
+    leftover_arns: Optional[List[str]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Id": _to_json(self.id),
+            "Status": _to_json(self.status),
+            "ComponentArns": _to_json(self.component_arns),
+            "Error": _to_json(self.error),
+            "LeftoverArns": _to_json(self.leftover_arns),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> ListenerDestroyOutput:
+        return cls(
+            id=data.get("Id"),
+            status=data.get("Status"),
+            component_arns=data.get("ComponentArns"),
+            error=data.get("Error"),
+            leftover_arns=data.get("LeftoverArns"),
+        )
+
+
+@dataclass
+class PatternResult:
+    """
+    pattern.Result
+
+    Parameters
+    ----------
+    match : bool
+    error : str, optional
+        Reason the event could not be matched, e.g. it is not a JSON object.
+    """
+
+    match: bool
+    error: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Match": _to_json(self.match),
+            "Error": _to_json(self.error),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> PatternResult:
+        return cls(
+            match=data.get("Match"),
+            error=data.get("Error"),
+        )
+
+
+@dataclass
+class ResourcegroupstaggingapiTagFilter:
+    """
+    resourcegroupstaggingapi.TagFilter
+
+    Parameters
+    ----------
+    key : str, optional
+    values : List[str], optional
+    """
+
+    key: Optional[str] = None
+    values: Optional[List[str]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Key": _to_json(self.key),
+            "Values": _to_json(self.values),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> ResourcegroupstaggingapiTagFilter:
+        return cls(
+            key=data.get("Key"),
+            values=data.get("Values"),
+        )
+
+
+@dataclass
+class RpcspecsContentDescriptor:
+    """
+    rpcspecs.ContentDescriptor
+
+    Parameters
+    ----------
+    name : str
+    description : str, optional
+    required : bool, optional
+    schema : RpcspecsProperty, optional
+    """
+
+    name: str
+    description: Optional[str] = None
+    required: Optional[bool] = None
+    schema: Optional[RpcspecsProperty] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "name": _to_json(self.name),
+            "description": _to_json(self.description),
+            "required": _to_json(self.required),
+            "schema": _to_json(self.schema),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RpcspecsContentDescriptor:
+        return cls(
+            name=data.get("name"),
+            description=data.get("description"),
+            required=data.get("required"),
+            schema=None if data.get("schema") is None else RpcspecsProperty.from_dict(data.get("schema")),
+        )
+
+
+@dataclass
+class RpcspecsOpenRPCComponents:
+    """
+    rpcspecs.OpenRPCComponents
+
+    Parameters
+    ----------
+    schemas : Dict[str, RpcspecsProperty], optional
+    """
+
+    schemas: Optional[Dict[str, RpcspecsProperty]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "schemas": _to_json(self.schemas),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RpcspecsOpenRPCComponents:
+        return cls(
+            schemas=None if data.get("schemas") is None else {k: None if v is None else RpcspecsProperty.from_dict(v) for k, v in data.get("schemas").items()},
+        )
+
+
+@dataclass
+class RpcspecsOpenRPCInfo:
+    """
+    rpcspecs.OpenRPCInfo
+
+    Parameters
+    ----------
+    title : str
+    version : str
+    """
+
+    title: str
+    version: str
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "title": _to_json(self.title),
+            "version": _to_json(self.version),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RpcspecsOpenRPCInfo:
+        return cls(
+            title=data.get("title"),
+            version=data.get("version"),
+        )
+
+
+@dataclass
+class RpcspecsOpenRPCMethod:
+    """
+    rpcspecs.OpenRPCMethod
+
+    Parameters
+    ----------
+    name : str
+    param_structure : str
+        One of: by-name, by-position, either.
+    params : List[RpcspecsContentDescriptor]
+    result : RpcspecsContentDescriptor
+    """
+
+    name: str
+    param_structure: str
+    params: List[RpcspecsContentDescriptor]
+    result: RpcspecsContentDescriptor
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "name": _to_json(self.name),
+            "paramStructure": _to_json(self.param_structure),
+            "params": _to_json(self.params),
+            "result": _to_json(self.result),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RpcspecsOpenRPCMethod:
+        return cls(
+            name=data.get("name"),
+            param_structure=data.get("paramStructure"),
+            params=None if data.get("params") is None else [None if v is None else RpcspecsContentDescriptor.from_dict(v) for v in data.get("params")],
+            result=None if data.get("result") is None else RpcspecsContentDescriptor.from_dict(data.get("result")),
+        )
+
+
+@dataclass
+class RpcspecsProperty:
+    """
+    rpcspecs.Property
+
+    Parameters
+    ----------
+    _ref : str, optional
+    additional_properties : Any, optional
+    description : str, optional
+    enum : List[str], optional
+    items : RpcspecsProperty, optional
+    properties : Dict[str, RpcspecsProperty], optional
+    required : List[str], optional
+    type : str, optional
+    """
+
+    _ref: Optional[str] = None
+    additional_properties: Optional[Any] = None
+    description: Optional[str] = None
+    enum: Optional[List[str]] = None
+    items: Optional[RpcspecsProperty] = None
+    properties: Optional[Dict[str, RpcspecsProperty]] = None
+    required: Optional[List[str]] = None
+    type: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "$ref": _to_json(self._ref),
+            "additionalProperties": _to_json(self.additional_properties),
+            "description": _to_json(self.description),
+            "enum": _to_json(self.enum),
+            "items": _to_json(self.items),
+            "properties": _to_json(self.properties),
+            "required": _to_json(self.required),
+            "type": _to_json(self.type),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RpcspecsProperty:
+        return cls(
+            _ref=data.get("$ref"),
+            additional_properties=data.get("additionalProperties"),
+            description=data.get("description"),
+            enum=data.get("enum"),
+            items=None if data.get("items") is None else RpcspecsProperty.from_dict(data.get("items")),
+            properties=None if data.get("properties") is None else {k: None if v is None else RpcspecsProperty.from_dict(v) for k, v in data.get("properties").items()},
+            required=data.get("required"),
+            type=data.get("type"),
+        )
+
+
+@dataclass
+class XrayHttp:
+    """
+    xray.Http
+
+    Parameters
+    ----------
+    request : XrayRequest, optional
+    response : XrayResponse, optional
+    """
+
+    request: Optional[XrayRequest] = None
+    response: Optional[XrayResponse] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "request": _to_json(self.request),
+            "response": _to_json(self.response),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayHttp:
+        return cls(
+            request=None if data.get("request") is None else XrayRequest.from_dict(data.get("request")),
+            response=None if data.get("response") is None else XrayResponse.from_dict(data.get("response")),
+        )
+
+
+@dataclass
+class XrayLink:
+    """
+    xray.Link
+
+    Parameters
+    ----------
+    attributes : XrayLinkAttributes, optional
+    id : str, optional
+    trace_id : str, optional
+    """
+
+    attributes: Optional[XrayLinkAttributes] = None
+    id: Optional[str] = None
+    trace_id: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "attributes": _to_json(self.attributes),
+            "id": _to_json(self.id),
+            "trace_id": _to_json(self.trace_id),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayLink:
+        return cls(
+            attributes=None if data.get("attributes") is None else XrayLinkAttributes.from_dict(data.get("attributes")),
+            id=data.get("id"),
+            trace_id=data.get("trace_id"),
+        )
+
+
+@dataclass
+class XrayLinkAttributes:
+    """
+    xray.LinkAttributes
+
+    Parameters
+    ----------
+    aws_xray_reserved_reference_type : str, optional
+        One of: parent, child.
+    """
+
+    aws_xray_reserved_reference_type: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "aws.xray.reserved.reference_type": _to_json(self.aws_xray_reserved_reference_type),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayLinkAttributes:
+        return cls(
+            aws_xray_reserved_reference_type=data.get("aws.xray.reserved.reference_type"),
+        )
+
+
+@dataclass
+class XrayRequest:
+    """
+    xray.Request
+
+    Parameters
+    ----------
+    client_ip : str, optional
+    method : str, optional
+    traced : bool, optional
+    url : str, optional
+    user_agent : str, optional
+    x_forwarded_for : bool, optional
+    """
+
+    client_ip: Optional[str] = None
+    method: Optional[str] = None
+    traced: Optional[bool] = None
+    url: Optional[str] = None
+    user_agent: Optional[str] = None
+    x_forwarded_for: Optional[bool] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "client_ip": _to_json(self.client_ip),
+            "method": _to_json(self.method),
+            "traced": _to_json(self.traced),
+            "url": _to_json(self.url),
+            "user_agent": _to_json(self.user_agent),
+            "x_forwarded_for": _to_json(self.x_forwarded_for),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayRequest:
+        return cls(
+            client_ip=data.get("client_ip"),
+            method=data.get("method"),
+            traced=data.get("traced"),
+            url=data.get("url"),
+            user_agent=data.get("user_agent"),
+            x_forwarded_for=data.get("x_forwarded_for"),
+        )
+
+
+@dataclass
+class XrayResponse:
+    """
+    xray.Response
+
+    Parameters
+    ----------
+    content_length : int, optional
+    status : int, optional
+    """
+
+    content_length: Optional[int] = None
+    status: Optional[int] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "content_length": _to_json(self.content_length),
+            "status": _to_json(self.status),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayResponse:
+        return cls(
+            content_length=data.get("content_length"),
+            status=data.get("status"),
+        )
+
+
+@dataclass
+class XraySegment:
+    """
+    xray.Segment
+
+    Parameters
+    ----------
+    annotations : Dict[str, Any], optional
+    aws : Any, optional
+    cause : Any, optional
+    end_time : float, optional
+    error : bool, optional
+    fault : bool, optional
+    http : XrayHttp, optional
+    id : str, optional
+    in_progress : bool, optional
+    links : List[XrayLink], optional
+    metadata : Dict[str, Any], optional
+    name : str, optional
+    origin : str, optional
+    parent_id : str, optional
+    service : XrayService, optional
+    start_time : float, optional
+    subsegments : List[XraySubsegment], optional
+    throttle : bool, optional
+    trace_id : str, optional
+    user : str, optional
+    """
+
+    annotations: Optional[Dict[str, Any]] = None
+    aws: Optional[Any] = None
+    cause: Optional[Any] = None
+    end_time: Optional[float] = None
+    error: Optional[bool] = None
+    fault: Optional[bool] = None
+    http: Optional[XrayHttp] = None
+    id: Optional[str] = None
+    in_progress: Optional[bool] = None
+    links: Optional[List[XrayLink]] = None
+    metadata: Optional[Dict[str, Any]] = None
+    name: Optional[str] = None
+    origin: Optional[str] = None
+    parent_id: Optional[str] = None
+    service: Optional[XrayService] = None
+    start_time: Optional[float] = None
+    subsegments: Optional[List[XraySubsegment]] = None
+    throttle: Optional[bool] = None
+    trace_id: Optional[str] = None
+    user: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "annotations": _to_json(self.annotations),
+            "aws": _to_json(self.aws),
+            "cause": _to_json(self.cause),
+            "end_time": _to_json(self.end_time),
+            "error": _to_json(self.error),
+            "fault": _to_json(self.fault),
+            "http": _to_json(self.http),
+            "id": _to_json(self.id),
+            "in_progress": _to_json(self.in_progress),
+            "links": _to_json(self.links),
+            "metadata": _to_json(self.metadata),
+            "name": _to_json(self.name),
+            "origin": _to_json(self.origin),
+            "parent_id": _to_json(self.parent_id),
+            "service": _to_json(self.service),
+            "start_time": _to_json(self.start_time),
+            "subsegments": _to_json(self.subsegments),
+            "throttle": _to_json(self.throttle),
+            "trace_id": _to_json(self.trace_id),
+            "user": _to_json(self.user),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XraySegment:
+        return cls(
+            annotations=data.get("annotations"),
+            aws=data.get("aws"),
+            cause=data.get("cause"),
+            end_time=data.get("end_time"),
+            error=data.get("error"),
+            fault=data.get("fault"),
+            http=None if data.get("http") is None else XrayHttp.from_dict(data.get("http")),
+            id=data.get("id"),
+            in_progress=data.get("in_progress"),
+            links=None if data.get("links") is None else [None if v is None else XrayLink.from_dict(v) for v in data.get("links")],
+            metadata=data.get("metadata"),
+            name=data.get("name"),
+            origin=data.get("origin"),
+            parent_id=data.get("parent_id"),
+            service=None if data.get("service") is None else XrayService.from_dict(data.get("service")),
+            start_time=data.get("start_time"),
+            subsegments=None if data.get("subsegments") is None else [None if v is None else XraySubsegment.from_dict(v) for v in data.get("subsegments")],
+            throttle=data.get("throttle"),
+            trace_id=data.get("trace_id"),
+            user=data.get("user"),
+        )
+
+
+@dataclass
+class XrayService:
+    """
+    xray.Service
+
+    Parameters
+    ----------
+    type : str, optional
+    """
+
+    type: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "type": _to_json(self.type),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayService:
+        return cls(
+            type=data.get("type"),
+        )
+
+
+@dataclass
+class XraySql:
+    """
+    xray.Sql
+
+    Parameters
+    ----------
+    connection_string : str, optional
+    database_type : str, optional
+    database_version : str, optional
+    driver_version : str, optional
+    preparation : str, optional
+    sanitized_query : str, optional
+    url : str, optional
+    user : str, optional
+    """
+
+    connection_string: Optional[str] = None
+    database_type: Optional[str] = None
+    database_version: Optional[str] = None
+    driver_version: Optional[str] = None
+    preparation: Optional[str] = None
+    sanitized_query: Optional[str] = None
+    url: Optional[str] = None
+    user: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "connection_string": _to_json(self.connection_string),
+            "database_type": _to_json(self.database_type),
+            "database_version": _to_json(self.database_version),
+            "driver_version": _to_json(self.driver_version),
+            "preparation": _to_json(self.preparation),
+            "sanitized_query": _to_json(self.sanitized_query),
+            "url": _to_json(self.url),
+            "user": _to_json(self.user),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XraySql:
+        return cls(
+            connection_string=data.get("connection_string"),
+            database_type=data.get("database_type"),
+            database_version=data.get("database_version"),
+            driver_version=data.get("driver_version"),
+            preparation=data.get("preparation"),
+            sanitized_query=data.get("sanitized_query"),
+            url=data.get("url"),
+            user=data.get("user"),
+        )
+
+
+@dataclass
+class XraySubsegment:
+    """
+    xray.Subsegment
+
+    Parameters
+    ----------
+    annotations : Dict[str, Any], optional
+    aws : Any, optional
+    cause : Any, optional
+    end_time : float, optional
+    error : bool, optional
+    fault : bool, optional
+    http : XrayHttp, optional
+    id : str, optional
+    in_progress : bool, optional
+    links : List[XrayLink], optional
+    metadata : Dict[str, Any], optional
+    name : str, optional
+    namespace : str, optional
+    parent_id : str, optional
+    precursor_ids : List[str], optional
+    sql : XraySql, optional
+    start_time : float, optional
+    subsegments : List[XraySubsegment], optional
+    throttle : bool, optional
+    trace_id : str, optional
+    traced : bool, optional
+    type : str, optional
+    """
+
+    annotations: Optional[Dict[str, Any]] = None
+    aws: Optional[Any] = None
+    cause: Optional[Any] = None
+    end_time: Optional[float] = None
+    error: Optional[bool] = None
+    fault: Optional[bool] = None
+    http: Optional[XrayHttp] = None
+    id: Optional[str] = None
+    in_progress: Optional[bool] = None
+    links: Optional[List[XrayLink]] = None
+    metadata: Optional[Dict[str, Any]] = None
+    name: Optional[str] = None
+    namespace: Optional[str] = None
+    parent_id: Optional[str] = None
+    precursor_ids: Optional[List[str]] = None
+    sql: Optional[XraySql] = None
+    start_time: Optional[float] = None
+    subsegments: Optional[List[XraySubsegment]] = None
+    throttle: Optional[bool] = None
+    trace_id: Optional[str] = None
+    traced: Optional[bool] = None
+    type: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "annotations": _to_json(self.annotations),
+            "aws": _to_json(self.aws),
+            "cause": _to_json(self.cause),
+            "end_time": _to_json(self.end_time),
+            "error": _to_json(self.error),
+            "fault": _to_json(self.fault),
+            "http": _to_json(self.http),
+            "id": _to_json(self.id),
+            "in_progress": _to_json(self.in_progress),
+            "links": _to_json(self.links),
+            "metadata": _to_json(self.metadata),
+            "name": _to_json(self.name),
+            "namespace": _to_json(self.namespace),
+            "parent_id": _to_json(self.parent_id),
+            "precursor_ids": _to_json(self.precursor_ids),
+            "sql": _to_json(self.sql),
+            "start_time": _to_json(self.start_time),
+            "subsegments": _to_json(self.subsegments),
+            "throttle": _to_json(self.throttle),
+            "trace_id": _to_json(self.trace_id),
+            "traced": _to_json(self.traced),
+            "type": _to_json(self.type),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XraySubsegment:
+        return cls(
+            annotations=data.get("annotations"),
+            aws=data.get("aws"),
+            cause=data.get("cause"),
+            end_time=data.get("end_time"),
+            error=data.get("error"),
+            fault=data.get("fault"),
+            http=None if data.get("http") is None else XrayHttp.from_dict(data.get("http")),
+            id=data.get("id"),
+            in_progress=data.get("in_progress"),
+            links=None if data.get("links") is None else [None if v is None else XrayLink.from_dict(v) for v in data.get("links")],
+            metadata=data.get("metadata"),
+            name=data.get("name"),
+            namespace=data.get("namespace"),
+            parent_id=data.get("parent_id"),
+            precursor_ids=data.get("precursor_ids"),
+            sql=None if data.get("sql") is None else XraySql.from_dict(data.get("sql")),
+            start_time=data.get("start_time"),
+            subsegments=None if data.get("subsegments") is None else [None if v is None else XraySubsegment.from_dict(v) for v in data.get("subsegments")],
+            throttle=data.get("throttle"),
+            trace_id=data.get("trace_id"),
+            traced=data.get("traced"),
+            type=data.get("type"),
+        )
+
+
+@dataclass
+class XrayTrace:
+    """
+    xray.Trace
+
+    Parameters
+    ----------
+    segments : List[XraySegment]
+    duration : float, optional
+    id : str, optional
+    limit_exceeded : bool, optional
+    """
+
+    segments: List[XraySegment]
+    duration: Optional[float] = None
+    id: Optional[str] = None
+    limit_exceeded: Optional[bool] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "segments": _to_json(self.segments),
+            "duration": _to_json(self.duration),
+            "id": _to_json(self.id),
+            "limitExceeded": _to_json(self.limit_exceeded),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> XrayTrace:
+        return cls(
+            segments=None if data.get("segments") is None else [None if v is None else XraySegment.from_dict(v) for v in data.get("segments")],
+            duration=data.get("duration"),
+            id=data.get("id"),
+            limit_exceeded=data.get("limitExceeded"),
+        )
+
+
+@dataclass
+class GetPhysicalIdParams:
+    """
+    params of get_physical_id
+
+    Parameters
+    ----------
+    logical_resource_id : str
+        Name of the Logical Id within the Stack to fetch.
+    stack_name : str
+        Name of the CloudFormation Stack.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    """
+
+    logical_resource_id: str
+    stack_name: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "LogicalResourceId": _to_json(self.logical_resource_id),
+            "StackName": _to_json(self.stack_name),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GetPhysicalIdParams:
+        return cls(
+            logical_resource_id=data.get("LogicalResourceId"),
+            stack_name=data.get("StackName"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+        )
+
+
+@dataclass
+class GetStackOutputsParams:
+    """
+    params of get_stack_outputs
+
+    Parameters
+    ----------
+    output_names : List[str]
+        Keys of the Stack Outputs to fetch.
+    stack_name : str
+        Name of the CloudFormation Stack.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    """
+
+    output_names: List[str]
+    stack_name: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "OutputNames": _to_json(self.output_names),
+            "StackName": _to_json(self.stack_name),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GetStackOutputsParams:
+        return cls(
+            output_names=data.get("OutputNames"),
+            stack_name=data.get("StackName"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+        )
+
+
+@dataclass
+class GetTraceTreeParams:
+    """
+    params of get_trace_tree
+
+    Parameters
+    ----------
+    tracing_header : str
+        Trace header to get the trace tree.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    fetch_child_traces : bool, optional
+        Flag to determine if linked traces will be included in the tree.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    """
+
+    tracing_header: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    fetch_child_traces: Optional[bool] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "TracingHeader": _to_json(self.tracing_header),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "FetchChildTraces": _to_json(self.fetch_child_traces),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GetTraceTreeParams:
+        return cls(
+            tracing_header=data.get("TracingHeader"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            fetch_child_traces=data.get("FetchChildTraces"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+        )
+
+
+@dataclass
+class GetTraceTreeResult:
+    """
+    result of get_trace_tree
+
+    Parameters
+    ----------
+    linked_trace_limit_exceeded : bool
+    paths : List[List[XraySegment]]
+    root : XraySegment, optional
+    source_trace : XrayTrace, optional
+    """
+
+    linked_trace_limit_exceeded: bool
+    paths: List[List[XraySegment]]
+    root: Optional[XraySegment] = None
+    source_trace: Optional[XrayTrace] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "linked_trace_limit_exceeded": _to_json(self.linked_trace_limit_exceeded),
+            "paths": _to_json(self.paths),
+            "root": _to_json(self.root),
+            "source_trace": _to_json(self.source_trace),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GetTraceTreeResult:
+        return cls(
+            linked_trace_limit_exceeded=data.get("linked_trace_limit_exceeded"),
+            paths=None if data.get("paths") is None else [None if v is None else [None if v is None else XraySegment.from_dict(v) for v in v] for v in data.get("paths")],
+            root=None if data.get("root") is None else XraySegment.from_dict(data.get("root")),
+            source_trace=None if data.get("source_trace") is None else XrayTrace.from_dict(data.get("source_trace")),
+        )
+
+
+@dataclass
+class CapabilitiesParams:
+    """
+    params of iatk.capabilities
+    """
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> CapabilitiesParams:
+        return cls(
+        )
+
+
+@dataclass
+class CapabilitiesResult:
+    """
+    result of iatk.capabilities
+
+    Parameters
+    ----------
+    features : List[str]
+        Optional parts of the protocol the binary supports.
+    methods : List[str]
+        Names of the RPC methods the binary supports, sorted.
+    version : str
+        Release of the binary.
+    """
+
+    features: List[str]
+    methods: List[str]
+    version: str
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Features": _to_json(self.features),
+            "Methods": _to_json(self.methods),
+            "Version": _to_json(self.version),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> CapabilitiesResult:
+        return cls(
+            features=data.get("Features"),
+            methods=data.get("Methods"),
+            version=data.get("Version"),
+        )
+
+
+@dataclass
+class VersionParams:
+    """
+    params of iatk.version
+    """
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> VersionParams:
+        return cls(
+        )
+
+
+@dataclass
+class VersionResult:
+    """
+    result of iatk.version
+
+    Parameters
+    ----------
+    go_version : str
+        Go release the binary was built with.
+    platform : str
+        Operating system and architecture the binary was built for.
+    version : str
+        Release of the binary.
+    revision : str, optional
+        VCS revision the binary was built from, if known.
+    """
+
+    go_version: str
+    platform: str
+    version: str
+    revision: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "GoVersion": _to_json(self.go_version),
+            "Platform": _to_json(self.platform),
+            "Version": _to_json(self.version),
+            "Revision": _to_json(self.revision),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> VersionResult:
+        return cls(
+            go_version=data.get("GoVersion"),
+            platform=data.get("Platform"),
+            version=data.get("Version"),
+            revision=data.get("Revision"),
+        )
+
+
+@dataclass
+class GenerateBareboneEventParams:
+    """
+    params of mock.generate_barebone_event
+
+    Parameters
+    ----------
+    registry_name : str
+        Name of the registry of the schema stored in EventBridge Schema Registry.
+    schema_name : str
+        Name of the schema stored in EventBridge Schema Registry.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    event_ref : str, optional
+        Location to the event in the schema in json schema ref syntax, only applicable for openapi schema.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    schema_version : str, optional
+        Version of the schema stored in EventBridge Schema Registry.
+    skip_optional : bool, optional
+        If set to true, do not generate optional fields.
+    """
+
+    registry_name: str
+    schema_name: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    event_ref: Optional[str] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    schema_version: Optional[str] = None
+    skip_optional: Optional[bool] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "RegistryName": _to_json(self.registry_name),
+            "SchemaName": _to_json(self.schema_name),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "EventRef": _to_json(self.event_ref),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "SchemaVersion": _to_json(self.schema_version),
+            "SkipOptional": _to_json(self.skip_optional),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GenerateBareboneEventParams:
+        return cls(
+            registry_name=data.get("RegistryName"),
+            schema_name=data.get("SchemaName"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            event_ref=data.get("EventRef"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            schema_version=data.get("SchemaVersion"),
+            skip_optional=data.get("SkipOptional"),
+        )
+
+
+@dataclass
+class DiscoverParams:
+    """
+    params of rpc.discover
+    """
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> DiscoverParams:
+        return cls(
+        )
+
+
+@dataclass
+class DiscoverResult:
+    """
+    result of rpc.discover
+
+    Parameters
+    ----------
+    components : RpcspecsOpenRPCComponents
+    info : RpcspecsOpenRPCInfo
+    methods : List[RpcspecsOpenRPCMethod]
+    openrpc : str
+        Version of the OpenRPC specification the document follows.
+    """
+
+    components: RpcspecsOpenRPCComponents
+    info: RpcspecsOpenRPCInfo
+    methods: List[RpcspecsOpenRPCMethod]
+    openrpc: str
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "components": _to_json(self.components),
+            "info": _to_json(self.info),
+            "methods": _to_json(self.methods),
+            "openrpc": _to_json(self.openrpc),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> DiscoverResult:
+        return cls(
+            components=None if data.get("components") is None else RpcspecsOpenRPCComponents.from_dict(data.get("components")),
+            info=None if data.get("info") is None else RpcspecsOpenRPCInfo.from_dict(data.get("info")),
+            methods=None if data.get("methods") is None else [None if v is None else RpcspecsOpenRPCMethod.from_dict(v) for v in data.get("methods")],
+            openrpc=data.get("openrpc"),
+        )
+
+
+@dataclass
+class DescribeParams:
+    """
+    params of test_harness.describe
+
+    Parameters
+    ----------
+    id : str
+        Id of the test harness to describe.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    """
+
+    id: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Id": _to_json(self.id),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> DescribeParams:
+        return cls(
+            id=data.get("Id"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+        )
+
+
+@dataclass
+class DescribeResult:
+    """
+    result of test_harness.describe
+
+    Parameters
+    ----------
+    components : List[HarnessResource]
+        Resources of the test harness that exist.
+    id : str
+    tags : Dict[str, str]
+        Custom tags of the test harness.
+    target : str
+        ARN of the resource under test, e.g. the event bus of a Listener.
+    type : str
+    approximate_queue_depth : int, optional
+        Approximate number of events waiting in the queue of a Listener.
+    created : str, optional
+        Creation time of the test harness, in RFC3339 format.
+    error : str, optional
+        Reason the test harness could not be described, if it failed.
+    """
+
+    components: List[HarnessResource]
+    id: str
+    tags: Dict[str, str]
+    target: str
+    type: str
+    approximate_queue_depth: Optional[int] = None
+    created: Optional[str] = None
+    error: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Components": _to_json(self.components),
+            "Id": _to_json(self.id),
+            "Tags": _to_json(self.tags),
+            "Target": _to_json(self.target),
+            "Type": _to_json(self.type),
+            "ApproximateQueueDepth": _to_json(self.approximate_queue_depth),
+            "Created": _to_json(self.created),
+            "Error": _to_json(self.error),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> DescribeResult:
+        return cls(
+            components=None if data.get("Components") is None else [None if v is None else HarnessResource.from_dict(v) for v in data.get("Components")],
+            id=data.get("Id"),
+            tags=data.get("Tags"),
+            target=data.get("Target"),
+            type=data.get("Type"),
+            approximate_queue_depth=data.get("ApproximateQueueDepth"),
+            created=data.get("Created"),
+            error=data.get("Error"),
+        )
+
+
+@dataclass
+class AddListenerParams:
+    """
+    params of test_harness.eventbridge.add_listener
+
+    Parameters
+    ----------
+    event_bus_name : str
+        Name of the AWS Event Bus.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    event_pattern : str, optional
+        Event pattern of the listener, overriding the one of the Rule if supplied.
+    event_pattern_refinements : List[str], optional
+        Event patterns AND-merged with the event pattern of the listener.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    rule_name : str, optional
+        Name of a Rule on the EventBus to replicate. Required if no EventPattern is supplied.
+    tags : Dict[str, str], optional
+        A key-value pair associated EventBridge rule.
+    target_id : str, optional
+        Target Id on the given rule to replicate.
+    ttl_seconds : int, optional
+        Time-to-live of the listener in seconds, after which test_harness.gc destroys it.
+    """
+
+    event_bus_name: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    event_pattern: Optional[str] = None
+    event_pattern_refinements: Optional[List[str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    rule_name: Optional[str] = None
+    tags: Optional[Dict[str, str]] = None
+    target_id: Optional[str] = None
+    ttl_seconds: Optional[int] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "EventBusName": _to_json(self.event_bus_name),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "EventPattern": _to_json(self.event_pattern),
+            "EventPatternRefinements": _to_json(self.event_pattern_refinements),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "RuleName": _to_json(self.rule_name),
+            "Tags": _to_json(self.tags),
+            "TargetId": _to_json(self.target_id),
+            "TtlSeconds": _to_json(self.ttl_seconds),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> AddListenerParams:
+        return cls(
+            event_bus_name=data.get("EventBusName"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            event_pattern=data.get("EventPattern"),
+            event_pattern_refinements=data.get("EventPatternRefinements"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            rule_name=data.get("RuleName"),
+            tags=data.get("Tags"),
+            target_id=data.get("TargetId"),
+            ttl_seconds=data.get("TtlSeconds"),
+        )
+
+
+@dataclass
+class AddListenerResult:
+    """
+    result of test_harness.eventbridge.add_listener
+
+    Parameters
+    ----------
+    components : List[HarnessResource]
+    id : str
+    target_under_test : HarnessResource
+    """
+
+    components: List[HarnessResource]
+    id: str
+    target_under_test: HarnessResource
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "Components": _to_json(self.components),
+            "Id": _to_json(self.id),
+            "TargetUnderTest": _to_json(self.target_under_test),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> AddListenerResult:
+        return cls(
+            components=None if data.get("Components") is None else [None if v is None else HarnessResource.from_dict(v) for v in data.get("Components")],
+            id=data.get("Id"),
+            target_under_test=None if data.get("TargetUnderTest") is None else HarnessResource.from_dict(data.get("TargetUnderTest")),
+        )
+
+
+@dataclass
+class PollEventsParams:
+    """
+    params of test_harness.eventbridge.poll_events
+
+    Parameters
+    ----------
+    listener_id : str
+        Id of the Listener that was created.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    event_pattern : str, optional
+        Event pattern the events returned must match. Events polled that do not match it are left on the queue.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    max_number_of_messages : int, optional
+        Max number of messages to poll.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    wait_time_seconds : int, optional
+        Time in seconds to wait for polling.
+    """
+
+    listener_id: str
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    event_pattern: Optional[str] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    max_number_of_messages: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    wait_time_seconds: Optional[int] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "ListenerId": _to_json(self.listener_id),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "EventPattern": _to_json(self.event_pattern),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "MaxNumberOfMessages": _to_json(self.max_number_of_messages),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "WaitTimeSeconds": _to_json(self.wait_time_seconds),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> PollEventsParams:
+        return cls(
+            listener_id=data.get("ListenerId"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            event_pattern=data.get("EventPattern"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            max_number_of_messages=data.get("MaxNumberOfMessages"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            wait_time_seconds=data.get("WaitTimeSeconds"),
+        )
+
+
+@dataclass
+class RemoveListenersParams:
+    """
+    params of test_harness.eventbridge.remove_listeners
+
+    Parameters
+    ----------
+    dry_run : bool, optional
+        Whether to only report the Listeners and components that would be removed, without removing them.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    ids : List[str], optional
+        Ids of the Listeners to remove, one of Ids and TagFilters must be supplied.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    tag_filters : List[ResourcegroupstaggingapiTagFilter], optional
+        Tag filters matching the Listeners to remove, one of Ids and TagFilters must be supplied.
+    """
+
+    dry_run: Optional[bool] = None
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    ids: Optional[List[str]] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    tag_filters: Optional[List[ResourcegroupstaggingapiTagFilter]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "DryRun": _to_json(self.dry_run),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "Ids": _to_json(self.ids),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "TagFilters": _to_json(self.tag_filters),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> RemoveListenersParams:
+        return cls(
+            dry_run=data.get("DryRun"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            ids=data.get("Ids"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            tag_filters=None if data.get("TagFilters") is None else [None if v is None else ResourcegroupstaggingapiTagFilter.from_dict(v) for v in data.get("TagFilters")],
+        )
+
+
+@dataclass
+class TestPatternParams:
+    """
+    params of test_harness.eventbridge.test_pattern
+
+    Parameters
+    ----------
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    event_bus_name : str, optional
+        Name of the AWS Event Bus of the Rule, default if not supplied.
+    event_pattern : str, optional
+        Event pattern to test. Required if no RuleName is supplied.
+    events : List[str], optional
+        Events to match against the event pattern, as JSON strings. If not supplied, the event pattern is only validated.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    rule_name : str, optional
+        Name of a Rule whose event pattern to test.
+    """
+
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    event_bus_name: Optional[str] = None
+    event_pattern: Optional[str] = None
+    events: Optional[List[str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    rule_name: Optional[str] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "EventBusName": _to_json(self.event_bus_name),
+            "EventPattern": _to_json(self.event_pattern),
+            "Events": _to_json(self.events),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "RuleName": _to_json(self.rule_name),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> TestPatternParams:
+        return cls(
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            event_bus_name=data.get("EventBusName"),
+            event_pattern=data.get("EventPattern"),
+            events=data.get("Events"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            rule_name=data.get("RuleName"),
+        )
+
+
+@dataclass
+class TestPatternResult:
+    """
+    result of test_harness.eventbridge.test_pattern
+
+    Parameters
+    ----------
+    event_pattern : str
+        Event pattern the events were matched against.
+    results : List[PatternResult]
+        Outcome of matching each event, in order.
+    """
+
+    event_pattern: str
+    results: List[PatternResult]
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "EventPattern": _to_json(self.event_pattern),
+            "Results": _to_json(self.results),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> TestPatternResult:
+        return cls(
+            event_pattern=data.get("EventPattern"),
+            results=None if data.get("Results") is None else [None if v is None else PatternResult.from_dict(v) for v in data.get("Results")],
+        )
+
+
+@dataclass
+class GcParams:
+    """
+    params of test_harness.gc
+
+    Parameters
+    ----------
+    dry_run : bool, optional
+        Whether to only report the test harnesses that would be destroyed, without destroying them.
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_age_seconds : int, optional
+        Age in seconds over which test harnesses are destroyed. If not supplied, only the expired and orphaned ones are.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    tag_filters : List[ResourcegroupstaggingapiTagFilter], optional
+        Tag filters matching the test harnesses to consider, all iatk-created ones if not supplied.
+    """
+
+    dry_run: Optional[bool] = None
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    max_age_seconds: Optional[int] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    tag_filters: Optional[List[ResourcegroupstaggingapiTagFilter]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "DryRun": _to_json(self.dry_run),
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAgeSeconds": _to_json(self.max_age_seconds),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "TagFilters": _to_json(self.tag_filters),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> GcParams:
+        return cls(
+            dry_run=data.get("DryRun"),
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            max_age_seconds=data.get("MaxAgeSeconds"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            tag_filters=None if data.get("TagFilters") is None else [None if v is None else ResourcegroupstaggingapiTagFilter.from_dict(v) for v in data.get("TagFilters")],
+        )
+
+
+@dataclass
+class ListParams:
+    """
+    params of test_harness.list
+
+    Parameters
+    ----------
+    duration_seconds : int, optional
+        Duration of the session of the role to assume, in seconds, from 900 to 43200. Defaults to 900.
+    endpoint_url : str, optional
+        URL AWS requests are sent to instead of the AWS endpoints, e.g. http://localhost:4566 for a local emulator.
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to, keyed by service ID, e.g. SQS, EventBridge, XRay, Schemas or CloudFormation; take precedence over EndpointURL.
+    external_id : str, optional
+        External ID the trust policy of the role to assume may require.
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call, the first one included. Defaults to 3.
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call, in seconds. Defaults to 20.
+    profile : str, optional
+        AWS Profile used to communicate with AWS resources.
+    region : str, optional
+        AWS Region used to interact with AWS.
+    retry_mode : str, optional
+        How failed AWS calls are retried, adaptive also slowing down calls to throttled services. Defaults to standard. One of: standard, adaptive.
+    role_arn : str, optional
+        ARN of an IAM role to assume through STS to interact with AWS, e.g. in another account.
+    role_session_name : str, optional
+        Name of the session of the role to assume, aws-iatk by default.
+    tag_filters : List[ResourcegroupstaggingapiTagFilter], optional
+        Tag filters matching the test harnesses to list, all iatk-created ones if not supplied.
+    """
+
+    duration_seconds: Optional[int] = None
+    endpoint_url: Optional[str] = None
+    endpoint_urls: Optional[Dict[str, str]] = None
+    external_id: Optional[str] = None
+    max_attempts: Optional[int] = None
+    max_backoff_seconds: Optional[int] = None
+    profile: Optional[str] = None
+    region: Optional[str] = None
+    retry_mode: Optional[str] = None
+    role_arn: Optional[str] = None
+    role_session_name: Optional[str] = None
+    tag_filters: Optional[List[ResourcegroupstaggingapiTagFilter]] = None
+
+    def to_dict(self) -> dict:
+        return _drop_none({
+            "DurationSeconds": _to_json(self.duration_seconds),
+            "EndpointURL": _to_json(self.endpoint_url),
+            "EndpointURLs": _to_json(self.endpoint_urls),
+            "ExternalId": _to_json(self.external_id),
+            "MaxAttempts": _to_json(self.max_attempts),
+            "MaxBackoffSeconds": _to_json(self.max_backoff_seconds),
+            "Profile": _to_json(self.profile),
+            "Region": _to_json(self.region),
+            "RetryMode": _to_json(self.retry_mode),
+            "RoleArn": _to_json(self.role_arn),
+            "RoleSessionName": _to_json(self.role_session_name),
+            "TagFilters": _to_json(self.tag_filters),
+        })
+
+    @classmethod
+    def from_dict(cls, data: dict) -> ListParams:
+        return cls(
+            duration_seconds=data.get("DurationSeconds"),
+            endpoint_url=data.get("EndpointURL"),
+            endpoint_urls=data.get("EndpointURLs"),
+            external_id=data.get("ExternalId"),
+            max_attempts=data.get("MaxAttempts"),
+            max_backoff_seconds=data.get("MaxBackoffSeconds"),
+            profile=data.get("Profile"),
+            region=data.get("Region"),
+            retry_mode=data.get("RetryMode"),
+            role_arn=data.get("RoleArn"),
+            role_session_name=data.get("RoleSessionName"),
+            tag_filters=None if data.get("TagFilters") is None else [None if v is None else ResourcegroupstaggingapiTagFilter.from_dict(v) for v in data.get("TagFilters")],
+        )
+
+
+class RpcClient:
+    """
+    Calls the RPC methods of iatk
+
+    Parameters
+    ----------
+    invoke : Callable[[str, dict], Any]
+        Calls the RPC method of the given name with the given params and returns its output
+    region : str, optional
+        AWS Region used by default by methods taking one
+    profile : str, optional
+        AWS Profile used by default by methods taking one
+    endpoint_url : str, optional
+        URL AWS requests are sent to by default by methods taking one, e.g. the one of a local emulator
+    endpoint_urls : Dict[str, str], optional
+        URLs the AWS requests of some services are sent to by default by methods taking them, keyed by service ID
+    role_arn : str, optional
+        ARN of the IAM role assumed by default by methods taking one
+    external_id : str, optional
+        External ID used by default to assume the role
+    role_session_name : str, optional
+        Name of the session of the role used by default
+    duration_seconds : int, optional
+        Duration of the session of the role used by default, in seconds
+    retry_mode : str, optional
+        How failed AWS calls are retried by default: standard or adaptive
+    max_attempts : int, optional
+        Maximum number of attempts of each AWS call used by default
+    max_backoff_seconds : int, optional
+        Maximum delay between two attempts of an AWS call used by default, in seconds
+    """
+
+    def __init__(
+        self,
+        invoke: Callable[[str, dict], Any],
+        region: Optional[str] = None,
+        profile: Optional[str] = None,
+        endpoint_url: Optional[str] = None,
+        endpoint_urls: Optional[Dict[str, str]] = None,
+        role_arn: Optional[str] = None,
+        external_id: Optional[str] = None,
+        role_session_name: Optional[str] = None,
+        duration_seconds: Optional[int] = None,
+        retry_mode: Optional[str] = None,
+        max_attempts: Optional[int] = None,
+        max_backoff_seconds: Optional[int] = None,
+    ) -> None:
+        self._invoke = invoke
+        self._region = region
+        self._profile = profile
+        self._endpoint_url = endpoint_url
+        self._endpoint_urls = endpoint_urls
+        self._role_arn = role_arn
+        self._external_id = external_id
+        self._role_session_name = role_session_name
+        self._duration_seconds = duration_seconds
+        self._retry_mode = retry_mode
+        self._max_attempts = max_attempts
+        self._max_backoff_seconds = max_backoff_seconds
+
+    def get_physical_id(self, params: GetPhysicalIdParams) -> str:
+        """
+        calls get_physical_id
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("get_physical_id", data)
+        return output
+
+    def get_stack_outputs(self, params: GetStackOutputsParams) -> Dict[str, str]:
+        """
+        calls get_stack_outputs
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("get_stack_outputs", data)
+        return output
+
+    def get_trace_tree(self, params: GetTraceTreeParams) -> GetTraceTreeResult:
+        """
+        calls get_trace_tree
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("get_trace_tree", data)
+        return GetTraceTreeResult.from_dict(output)
+
+    def capabilities(self, params: CapabilitiesParams) -> CapabilitiesResult:
+        """
+        calls iatk.capabilities
+        """
+        data = params.to_dict()
+        output = self._invoke("iatk.capabilities", data)
+        return CapabilitiesResult.from_dict(output)
+
+    def version(self, params: VersionParams) -> VersionResult:
+        """
+        calls iatk.version
+        """
+        data = params.to_dict()
+        output = self._invoke("iatk.version", data)
+        return VersionResult.from_dict(output)
+
+    def generate_barebone_event(self, params: GenerateBareboneEventParams) -> str:
+        """
+        calls mock.generate_barebone_event
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("mock.generate_barebone_event", data)
+        return output
+
+    def discover(self, params: DiscoverParams) -> DiscoverResult:
+        """
+        calls rpc.discover
+        """
+        data = params.to_dict()
+        output = self._invoke("rpc.discover", data)
+        return DiscoverResult.from_dict(output)
+
+    def describe(self, params: DescribeParams) -> DescribeResult:
+        """
+        calls test_harness.describe
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.describe", data)
+        return DescribeResult.from_dict(output)
+
+    def add_listener(self, params: AddListenerParams) -> AddListenerResult:
+        """
+        calls test_harness.eventbridge.add_listener
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.eventbridge.add_listener", data)
+        return AddListenerResult.from_dict(output)
+
+    def poll_events(self, params: PollEventsParams) -> List[str]:
+        """
+        calls test_harness.eventbridge.poll_events
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.eventbridge.poll_events", data)
+        return output
+
+    def remove_listeners(self, params: RemoveListenersParams) -> List[ListenerDestroyOutput]:
+        """
+        calls test_harness.eventbridge.remove_listeners
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.eventbridge.remove_listeners", data)
+        return None if output is None else [None if v is None else ListenerDestroyOutput.from_dict(v) for v in output]
+
+    def test_pattern(self, params: TestPatternParams) -> TestPatternResult:
+        """
+        calls test_harness.eventbridge.test_pattern
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.eventbridge.test_pattern", data)
+        return TestPatternResult.from_dict(output)
+
+    def gc(self, params: GcParams) -> List[GcOutput]:
+        """
+        calls test_harness.gc
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.gc", data)
+        return None if output is None else [None if v is None else GcOutput.from_dict(v) for v in output]
+
+    def list(self, params: ListParams) -> List[InventoryOutput]:
+        """
+        calls test_harness.list
+        """
+        data = params.to_dict()
+        if self._region is not None:
+            data.setdefault("Region", self._region)
+        if self._profile is not None:
+            data.setdefault("Profile", self._profile)
+        if self._endpoint_url is not None:
+            data.setdefault("EndpointURL", self._endpoint_url)
+        if self._endpoint_urls is not None:
+            data.setdefault("EndpointURLs", self._endpoint_urls)
+        if self._role_arn is not None:
+            data.setdefault("RoleArn", self._role_arn)
+        if self._external_id is not None:
+            data.setdefault("ExternalId", self._external_id)
+        if self._role_session_name is not None:
+            data.setdefault("RoleSessionName", self._role_session_name)
+        if self._duration_seconds is not None:
+            data.setdefault("DurationSeconds", self._duration_seconds)
+        if self._retry_mode is not None:
+            data.setdefault("RetryMode", self._retry_mode)
+        if self._max_attempts is not None:
+            data.setdefault("MaxAttempts", self._max_attempts)
+        if self._max_backoff_seconds is not None:
+            data.setdefault("MaxBackoffSeconds", self._max_backoff_seconds)
+        output = self._invoke("test_harness.list", data)
+        return None if output is None else [None if v is None else InventoryOutput.from_dict(v) for v in output]
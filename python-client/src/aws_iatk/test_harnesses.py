@@ -0,0 +1,165 @@
+# Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+import logging
+from dataclasses import dataclass
+from typing import Dict, List, Optional
+
+from .add_eb_listener import AddEbListener_Resource
+from .jsonrpc import Payload
+from .remove_listeners import RemoveListeners_TagFilter
+
+
+LOG = logging.getLogger(__name__)
+
+
+@dataclass
+class TestHarness_Description:
+    """
+    Description of a test harness, as returned by AwsIatk.list_test_harnesses
+    and AwsIatk.describe_test_harness
+
+    Parameters
+    ----------
+    id : str
+        Id of the test harness
+    type : str
+        Type of the test harness, e.g. EventBridge.Listener
+    target : str
+        Arn of the resource under test, e.g. the event bus of a listener
+    created : str, optional
+        Creation time of the test harness, in RFC3339 format
+    tags : Dict[str, str]
+        Custom tags of the test harness
+    components : List[AddEbListener_Resource]
+        Resources of the test harness that exist
+    approximate_queue_depth : int, optional
+        Approximate number of events waiting in the queue of a listener
+    error : str, optional
+        Reason the test harness could not be described, if it failed
+    """
+    __test__ = False
+
+    id: str
+    type: str
+    target: str
+    created: Optional[str]
+    tags: Dict[str, str]
+    components: List[AddEbListener_Resource]
+    approximate_queue_depth: Optional[int]
+    error: Optional[str]
+
+    def __init__(self, jsonrpc_data_dict: dict) -> None:
+        self.id = jsonrpc_data_dict.get("Id", "")
+        self.type = jsonrpc_data_dict.get("Type", "")
+        self.target = jsonrpc_data_dict.get("Target", "")
+        self.created = jsonrpc_data_dict.get("Created", None)
+        self.tags = jsonrpc_data_dict.get("Tags", None) or {}
+        self.components = [
+            AddEbListener_Resource(component)
+            for component in jsonrpc_data_dict.get("Components", None) or []
+        ]
+        self.approximate_queue_depth = jsonrpc_data_dict.get("ApproximateQueueDepth", None)
+        self.error = jsonrpc_data_dict.get("Error", None)
+
+
+@dataclass
+class ListTestHarnessesOutput:
+    """
+    AwsIatk.list_test_harnesses Output
+
+    Parameters
+    ----------
+    test_harnesses : List[TestHarness_Description]
+        Description of each test harness found
+    """
+    test_harnesses: List[TestHarness_Description]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", [])
+        self.test_harnesses = [TestHarness_Description(d) for d in output or []]
+
+
+@dataclass
+class ListTestHarnessesParams:
+    """
+    AwsIatk.list_test_harnesses parameters
+
+    Parameters
+    ----------
+    tag_filters : List[RemoveListeners_TagFilter], optional
+        List of RemoveListeners_TagFilter matching the test harnesses to list
+    """
+
+    tag_filters: Optional[List[RemoveListeners_TagFilter]] = None
+    _rpc_method: str = "test_harness.list"
+
+    def to_dict(self) -> dict:
+        params = {}
+        if self.tag_filters:
+            params["TagFilters"] = [
+                tag_filter.to_dict() for tag_filter in self.tag_filters
+            ]
+        return params
+
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
+
+
+@dataclass
+class DescribeTestHarnessOutput(TestHarness_Description):
+    """
+    AwsIatk.describe_test_harness Output
+
+    Parameters
+    ----------
+    id : str
+        Id of the test harness
+    type : str
+        Type of the test harness, e.g. EventBridge.Listener
+    target : str
+        Arn of the resource under test, e.g. the event bus of a listener
+    created : str, optional
+        Creation time of the test harness, in RFC3339 format
+    tags : Dict[str, str]
+        Custom tags of the test harness
+    components : List[AddEbListener_Resource]
+        Resources of the test harness that exist
+    approximate_queue_depth : int, optional
+        Approximate number of events waiting in the queue of a listener
+    error : str, optional
+        Reason the test harness could not be described, if it failed
+    """
+    id: str
+    type: str
+    target: str
+    created: Optional[str]
+    tags: Dict[str, str]
+    components: List[AddEbListener_Resource]
+    approximate_queue_depth: Optional[int]
+    error: Optional[str]
+
+    def __init__(self, data_dict: dict) -> None:
+        output = data_dict.get("result", {}).get("output", {})
+        super().__init__(output or {})
+
+
+@dataclass
+class DescribeTestHarnessParams:
+    """
+    AwsIatk.describe_test_harness parameters
+
+    Parameters
+    ----------
+    id : str
+        Id of the test harness
+    """
+
+    id: str
+    _rpc_method: str = "test_harness.describe"
+
+    def to_dict(self) -> dict:
+        return {"Id": self.id}
+
+    def to_payload(self, region, profile, **aws_params):
+        return Payload(self._rpc_method, self.to_dict(), region, profile, **aws_params)
@@ -16,6 +16,19 @@ import aws_iatk
 LOG = logging.getLogger(__name__)
 LOG.setLevel(logging.DEBUG)
 RPC_SPECS_LOC = "../../../schema/rpc-specs.json"
+AWS_PARAMS = [
+    "Region",
+    "Profile",
+    "EndpointURL",
+    "EndpointURLs",
+    "RoleArn",
+    "ExternalId",
+    "RoleSessionName",
+    "DurationSeconds",
+    "RetryMode",
+    "MaxAttempts",
+    "MaxBackoffSeconds",
+]
 
 
 class NotAClientMethodException(Exception):
@@ -47,10 +60,17 @@ class ClientContractTest(TestCase):
     specs: dict
     method_map: dict = {
         "add_listener": "test_harness.eventbridge.add_listener",
+        "describe_test_harness": "test_harness.describe",
+        "discover": "rpc.discover",
+        "gc": "test_harness.gc",
         "generate_mock_event": "mock.generate_barebone_event",
+        "get_capabilities": "iatk.capabilities",
         "get_physical_id_from_stack": "get_physical_id",
+        "get_version": "iatk.version",
+        "list_test_harnesses": "test_harness.list",
         "poll_events": "test_harness.eventbridge.poll_events",
-        "remove_listeners": "test_harness.eventbridge.remove_listeners"
+        "remove_listeners": "test_harness.eventbridge.remove_listeners",
+        "test_event_pattern": "test_harness.eventbridge.test_pattern"
     }
 
     @classmethod
@@ -80,8 +100,13 @@ class ClientContractTest(TestCase):
             params_spec = (
                 self.specs["methods"].get(resolved_method, {}).get("parameters")
             )
+            # AWS params are set on the client instance rather than per method
             spec_params = set(
-                [to_snake_case(k) for k in params_spec["properties"].keys()]
+                [
+                    to_snake_case(k)
+                    for k in params_spec.get("properties", {}).keys()
+                    if k not in AWS_PARAMS
+                ]
             )
             
             # is this a client side only not in the rpc method sig
@@ -89,7 +114,7 @@ class ClientContractTest(TestCase):
                 spec_params.add("contexts") 
             
             self.assertEqual(
-                spec_params - set(["region", "profile"]),
+                spec_params,
                 set(method.params),
                 f"method: {name}",
             )
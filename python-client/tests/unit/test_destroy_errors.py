@@ -0,0 +1,46 @@
+import json
+from unittest import TestCase
+from unittest.mock import patch
+
+import aws_iatk
+
+
+def error_response(message: str, report: list) -> bytes:
+    return json.dumps({
+        "jsonrpc": "2.0",
+        "id": "1",
+        "error": {"code": 10, "message": message, "data": {"retryable": False, "report": report}},
+    }).encode()
+
+
+class TestDestroyErrors(TestCase):
+    def setUp(self):
+        self.iatk = aws_iatk.AwsIatk(region="us-east-1")
+
+    def test_gc_failed(self):
+        report = [
+            {"Id": "iatk_eb_1", "Type": "EventBridge.Listener", "Reason": "Expired", "Status": "Destroyed"},
+            {"Id": "iatk_eb_2", "Type": "EventBridge.Listener", "Reason": "Expired", "Status": "Failed", "Error": "access denied"},
+        ]
+        message = "failed to destroy following listener(s): {resource group id: iatk_eb_2, reason: access denied}"
+        with patch.object(aws_iatk.AwsIatk, "_popen_iatk", return_value=error_response(message, report)):
+            with self.assertRaises(aws_iatk.IatkException) as ctx:
+                self.iatk.gc(max_age_seconds=3600)
+
+        self.assertEqual(str(ctx.exception), message)
+        self.assertEqual(ctx.exception.error_code, 10)
+        self.assertEqual(ctx.exception.data["report"], report)
+
+    def test_remove_listeners_failed(self):
+        report = [
+            {"Id": "iatk_eb_1", "Status": "Destroyed"},
+            {"Id": "iatk_eb_2", "Status": "Failed", "Error": "access denied", "LeftoverArns": ["arn:aws:sqs:us-east-1:123456789012:iatk_eb_2"]},
+        ]
+        message = "failed to destroy following listener(s): {resource group id: iatk_eb_2, reason: access denied}"
+        with patch.object(aws_iatk.AwsIatk, "_popen_iatk", return_value=error_response(message, report)):
+            with self.assertRaises(aws_iatk.IatkException) as ctx:
+                self.iatk.remove_listeners(ids=["iatk_eb_1", "iatk_eb_2"])
+
+        self.assertEqual(str(ctx.exception), message)
+        self.assertEqual(ctx.exception.error_code, 10)
+        self.assertEqual(ctx.exception.data["report"], report)